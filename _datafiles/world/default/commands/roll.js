@@ -0,0 +1,38 @@
+const command = {
+    name: "roll",
+    aliases: ["dice"],
+    help: `Usage:
+
+  <ansi fg="command">roll</ansi> - Rolls a six sided die for everyone in the room to see.
+  <ansi fg="command">roll 2d20</ansi> - Rolls two twenty sided dice.`,
+    adminOnly: false,
+    disabledWhenDowned: true
+};
+
+function onCommand(rest, user, room) {
+
+    var diceQty = 1;
+    var diceSides = 6;
+
+    if ( rest != "" ) {
+        var parts = rest.toLowerCase().split("d");
+        if ( parts.length != 2 ) {
+            user.SendText(`Roll what? Try <ansi fg="command">roll 2d6</ansi>.`);
+            return true;
+        }
+        diceQty = parts[0] == "" ? 1 : parseInt(parts[0]);
+        diceSides = parseInt(parts[1]);
+    }
+
+    if ( isNaN(diceQty) || isNaN(diceSides) || diceQty < 1 || diceQty > 10 || diceSides < 2 || diceSides > 100 ) {
+        user.SendText(`You can roll 1 to 10 dice, with 2 to 100 sides each.`);
+        return true;
+    }
+
+    var total = UtilDiceRoll(diceQty, diceSides);
+
+    user.SendText(`You roll <ansi fg="yellow">` + diceQty + `d` + diceSides + `</ansi> and get <ansi fg="yellow-bold">` + total + `</ansi>.`);
+    room.SendText(user.GetCharacterName(true) + ` rolls <ansi fg="yellow">` + diceQty + `d` + diceSides + `</ansi> and gets <ansi fg="yellow-bold">` + total + `</ansi>.`, user.UserId());
+
+    return true;
+}
@@ -1,4 +1,5 @@
 
+const tutorial = require("tutorial");
 
 const allowed_commands = ["help", "broadcast"];
 const teach_commands = ["look", "look orb", "look", "look east", "east"];
@@ -16,7 +17,7 @@ function onCommand(cmd, rest, user, room) {
 
     ignoreCommand = false;
 
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
 
     // Make sure they are only doing stuff that's allowed.
 
@@ -91,11 +92,11 @@ function onCommand(cmd, rest, user, room) {
 
 // If there is no book here, add the book item
 function onEnter(user, room) {
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
     canGoEast = false;
     commandNow = 0;
 
-    sendWorkingCommands(user);
+    tutorial.sendDisabledNotice(user);
 
     teacherMob.Command('emote appears in a ' + UtilApplyColorPattern("flash of light!", "glowing"));
     
@@ -109,45 +110,10 @@ function onEnter(user, room) {
 
 function onExit(user , room) {
     // Destroy the guide (cleanup)
-    destroyTeacher(room);
+    tutorial.dismiss(room, teacherMobId);
 }
 
 function onLoad(room) {
     canGoEast = false;
     commandNow = 0;
 }
-
-function getTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId, true);
-    mobActor.SetCharacterName(teacherName);
-    return mobActor;
-}
-
-function destroyTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId);
-    if ( mobActor != null ) {
-        mobActor.Command(`suicide vanish`);
-    } 
-}
-
-function sendWorkingCommands(user) {
-
-    ac = [];
-    unlockedCommands = teach_commands.slice(0, commandNow);
-
-    for (var i in allowed_commands ) {
-        ac.push(allowed_commands[i]);
-    }
-    
-    for ( i in unlockedCommands ) {
-        ac.push(unlockedCommands[i]);
-    }
-    
-    user.SendText("");
-    user.SendText("");
-    user.SendText('    <ansi fg="red">NOTE:</ansi> Most commands have been <ansi fg="203">DISABLED</ansi> and <ansi fg="203">WILL NOT WORK</ansi> until you <ansi fg="51">COMPLETE THIS TUTORIAL</ansi>!');
-    //user.SendText('          The commands currently available are: <ansi fg="command">'+ac.join('</ansi>, <ansi fg="command">')+'</ansi>');
-    user.SendText("");
-    user.SendText("");
-
-}
@@ -1,4 +1,5 @@
 
+const tutorial = require("tutorial");
 
 const allowed_commands = ["help", "broadcast", "look"];
 const teach_commands = ["status", "inventory", "experience", "conditions", "south"];
@@ -17,7 +18,7 @@ function onCommand(cmd, rest, user, room) {
 
     ignoreCommand = false;
 
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
 
     // Make sure they are only doing stuff that's allowed.
 
@@ -102,9 +103,9 @@ function onEnter(user, room) {
         user.GiveItem(itm);
     }
     
-    sendWorkingCommands(user);
+    tutorial.sendDisabledNotice(user);
     
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
 
     teacherMob.Command('emote appears in a ' + UtilApplyColorPattern("flash of light!", "glowing"));
     
@@ -115,7 +116,7 @@ function onEnter(user, room) {
 
 function onExit(user , room) {
     // Destroy the guide (cleanup)
-    destroyTeacher(room);
+    tutorial.dismiss(room, teacherMobId);
     canGoSouth = false;
     commandNow = 0;
 }
@@ -124,39 +125,3 @@ function onLoad(room) {
     canGoSouth = false;
     commandNow = 0;
 }
-
-function getTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId, true);
-    mobActor.SetCharacterName(teacherName);
-
-    return mobActor;
-}
-
-function destroyTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId);
-    if ( mobActor != null ) {
-        mobActor.Command(`suicide vanish`);
-    } 
-}
-
-function sendWorkingCommands(user) {
-
-    ac = [];
-    unlockedCommands = teach_commands.slice(0, commandNow);
-
-    for (var i in allowed_commands ) {
-        ac.push(allowed_commands[i]);
-    }
-    
-    for ( i in unlockedCommands ) {
-        ac.push(unlockedCommands[i]);
-    }
-    
-    user.SendText("");
-    user.SendText("");
-    user.SendText('    <ansi fg="red">NOTE:</ansi> Most commands have been <ansi fg="203">DISABLED</ansi> and <ansi fg="203">WILL NOT WORK</ansi> until you <ansi fg="51">COMPLETE THIS TUTORIAL</ansi>!');
-    //user.SendText('          The commands currently available are: <ansi fg="command">'+ac.join('</ansi>, <ansi fg="command">')+'</ansi>');
-    user.SendText("");
-    user.SendText("");
-
-}
@@ -1,4 +1,6 @@
 
+const tutorial = require("tutorial");
+
 const allowed_commands = ["help", "broadcast", "look", "status", "inventory", "experience", "conditions"];
 const teach_commands = ["equip stick", "attack dummy", "west"];
 const teacherMobId = 57;
@@ -15,7 +17,7 @@ function onCommand(cmd, rest, user, room) {
 
     ignoreCommand = false;
 
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
 
     // Make sure they are only doing stuff that's allowed.
 
@@ -69,7 +71,7 @@ function onCommand(cmd, rest, user, room) {
             break;
         case 1:
 
-            getDummy(room);
+            tutorial.summon(room, dummyMobId);
 
             teacherMob.Command('say You may have noticed the <ansi fg="mobname">training dummy</ansi> here.', 1.0);
             teacherMob.Command('say Go ahead and engage in combat by typing <ansi fg="command">attack dummy</ansi>. Don\'t worry, it can\'t hurt you.', 1.0);
@@ -91,10 +93,10 @@ function onCommand(cmd, rest, user, room) {
 function onEnter(user, room) {
     room.SetLocked("north", true);
     
-    teacherMob = getTeacher(room);
-    getDummy(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
+    tutorial.summon(room, dummyMobId);
 
-    sendWorkingCommands(user);
+    tutorial.sendDisabledNotice(user);
 
     teacherMob.Command('emote appears in a ' + UtilApplyColorPattern("flash of light!", "glowing"));
     
@@ -112,8 +114,8 @@ function onEnter(user, room) {
 
 function onExit(user , room) {
     // Destroy the guide (cleanup)
-    destroyTeacher(room);
-    destroyDummy(room);
+    tutorial.dismiss(room, teacherMobId);
+    tutorial.dismiss(room, dummyMobId);
     canGoSouth = false;
     commandNow = 0;
 }
@@ -122,49 +124,3 @@ function onLoad(room) {
     canGoSouth = false;
     commandNow = 0;
 }
-
-function getTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId, true);
-    mobActor.SetCharacterName(teacherName);
-    return mobActor;
-}
-
-function destroyTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId);
-    if ( mobActor != null ) {
-        mobActor.Command(`suicide vanish`);
-    } 
-}
-
-function getDummy(room) {
-    return room.GetMob(dummyMobId, true);
-}
-
-function destroyDummy(room) {
-    var mobActor = room.GetMob(dummyMobId);
-    if ( mobActor != null ) {
-        mobActor.Command(`suicide vanish`);
-    }
-}
-
-function sendWorkingCommands(user) {
-
-    ac = [];
-    unlockedCommands = teach_commands.slice(0, commandNow);
-
-    for (var i in allowed_commands ) {
-        ac.push(allowed_commands[i]);
-    }
-    
-    for ( i in unlockedCommands ) {
-        ac.push(unlockedCommands[i]);
-    }
-    
-    user.SendText("");
-    user.SendText("");
-    user.SendText('    <ansi fg="red">NOTE:</ansi> Most commands have been <ansi fg="203">DISABLED</ansi> and <ansi fg="203">WILL NOT WORK</ansi> until you <ansi fg="51">COMPLETE THIS TUTORIAL</ansi>!');
-    //user.SendText('          The commands currently available are: <ansi fg="command">'+ac.join('</ansi>, <ansi fg="command">')+'</ansi>');
-    user.SendText("");
-    user.SendText("");
-
-}
@@ -1,4 +1,6 @@
 
+const tutorial = require("tutorial");
+
 const allowed_commands = ["help", "broadcast", "look", "status", "inventory", "experience", "conditions", "equip"];
 const teach_commands = ["get cap", "equip cap", "portal"];
 const teacherMobId = 57;
@@ -15,7 +17,7 @@ function onCommand(cmd, rest, user, room) {
     
     ignoreCommand = false;
 
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
 
     fullCommand = ExpandCommand(cmd);
     if ( rest.length > 0 ) {
@@ -80,10 +82,10 @@ function onCommand(cmd, rest, user, room) {
 // If there is no book here, add the book item
 function onEnter(user, room) {
     
-    teacherMob = getTeacher(room);
+    teacherMob = tutorial.summon(room, teacherMobId, teacherName);
     clearGroundItems(room);
     
-    sendWorkingCommands(user);
+    tutorial.sendDisabledNotice(user);
 
     itm = CreateItem(capItemId);
     teacherMob.GiveItem(itm);
@@ -103,7 +105,7 @@ function onEnter(user, room) {
 
 function onExit(user , room) {
     // Destroy the guide (cleanup)
-    destroyTeacher(room);
+    tutorial.dismiss(room, teacherMobId);
     
     canGoSouth = false;
     commandNow = 0;
@@ -114,41 +116,6 @@ function onLoad(room) {
     commandNow = 0;
 }
 
-function getTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId, true);
-    mobActor.SetCharacterName(teacherName);
-    return mobActor;
-}
-
-function destroyTeacher(room) {
-    var mobActor = room.GetMob(teacherMobId);
-    if ( mobActor != null ) {
-        mobActor.Command(`suicide vanish`);
-    } 
-}
-
-function sendWorkingCommands(user) {
-
-    ac = [];
-    unlockedCommands = teach_commands.slice(0, commandNow);
-
-    for (var i in allowed_commands ) {
-        ac.push(allowed_commands[i]);
-    }
-    
-    for ( i in unlockedCommands ) {
-        ac.push(unlockedCommands[i]);
-    }
-    
-    user.SendText("");
-    user.SendText("");
-    user.SendText('    <ansi fg="red">NOTE:</ansi> Most commands have been <ansi fg="203">DISABLED</ansi> and <ansi fg="203">WILL NOT WORK</ansi> until you <ansi fg="51">COMPLETE THIS TUTORIAL</ansi>!');
-    //user.SendText('          The commands currently available are: <ansi fg="command">'+ac.join('</ansi>, <ansi fg="command">')+'</ansi>');
-    user.SendText("");
-    user.SendText("");
-
-}
-
 function clearGroundItems(room) {
 
     allGroundItems = room.GetItems();
@@ -156,4 +123,4 @@ function clearGroundItems(room) {
         room.DestroyItem(allGroundItems[i]);
     }
 
-}
\ No newline at end of file
+}
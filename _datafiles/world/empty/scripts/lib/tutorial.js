@@ -0,0 +1,30 @@
+//
+// Helpers shared by the tutorial rooms. Use it with:
+//   const tutorial = require("tutorial");
+//
+
+// Finds the mob in the room, spawning it if it isn't there, and names it if a name is given
+exports.summon = function(room, mobId, name) {
+    var mobActor = room.GetMob(mobId, true);
+    if ( name ) {
+        mobActor.SetCharacterName(name);
+    }
+    return mobActor;
+};
+
+// Makes a mob the tutorial summoned disappear, if it's still around
+exports.dismiss = function(room, mobId) {
+    var mobActor = room.GetMob(mobId);
+    if ( mobActor != null ) {
+        mobActor.Command(`suicide vanish`);
+    }
+};
+
+// Warns that most commands won't work until the tutorial is over
+exports.sendDisabledNotice = function(user) {
+    user.SendText("");
+    user.SendText("");
+    user.SendText('    <ansi fg="red">NOTE:</ansi> Most commands have been <ansi fg="203">DISABLED</ansi> and <ansi fg="203">WILL NOT WORK</ansi> until you <ansi fg="51">COMPLETE THIS TUTORIAL</ansi>!');
+    user.SendText("");
+    user.SendText("");
+};
@@ -0,0 +1,2214 @@
+// Code generated by go generate; DO NOT EDIT.
+//
+// Everything world scripts are given, for editors to autocomplete.
+// Start a script with // @ts-check to have it type checked as well.
+//
+// Regenerate with: go generate (from the project root)
+
+/**
+ * Returns a formatted list of actor names, separated by commas, then "and".
+ *
+ * _Example: "Tim, Jim and Henry"_
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | actors | An array of ActorObjects. |
+ */
+declare function ActorNames(actorList: ScriptActor[]): string;
+
+declare function ColorWrap(txt: string, ...colorClass: string[]): string;
+
+/**
+ * Returns an Object with key/value pairs of `ProvidedRoomId`=>`NewRoomId`
+ * Creates ephemeral instances of the RoomId's provided.
+ *
+ * NOTE: Ephemeral rooms clean up (delete from memory) mobs, items, etc. when no players occupy the set of created rooms.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | RoomIds | an array of integers containing RoomId's you want instanced |
+ */
+declare function CreateInstancesFromRoomIds(roomList: number[]): { [key: number]: number };
+
+/**
+ * Returns an Object with key/value pairs of `ProvidedRoomId`=>`NewRoomId`
+ * Creates ephemeral instances of the  RoomIds of the zone provided.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | zoneName | The name of the zone to create instances from the zone rooms |
+ */
+declare function CreateInstancesFromZone(zoneName: string): { [key: number]: number };
+
+/**
+ * CreateItem creates a NEW instance of an item by id
+ */
+declare function CreateItem(itemId: number): ScriptItem;
+
+declare const EventFlags: {
+    CmdBlockInput: number;
+    CmdBlockInputUntilComplete: number;
+    CmdIsRequeue: number;
+    CmdNone: number;
+    CmdSecretly: number;
+    CmdSkipScripts: number;
+    CmdUnBlockInput: number;
+};
+
+declare function ExpandCommand(cmd: string, ...limit: number[]): string;
+
+/**
+ * mapRoomId    - Room the map is centered on
+ * mapSize      - wide or normal
+ * mapHeight	- Height of the map
+ * mapWidth     - Width of the map
+ * mapName 		- The title of the map
+ * showSecrets  - Include secret exits/rooms?
+ * mapMarkers   - A list of strings representing custom map markers:
+ *
+ * 	[roomId],[symbol],[legend text]
+ * 	1,×,Here
+ */
+declare function GetMap(mapRoomId: number, zoomLevel: number, mapHeight: number, mapWidth: number, mapName: string, showSecrets: boolean, ...mapMarkers: string[]): string;
+
+/**
+ * Retrieves a ActorObject for a given mobInstanceId.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | mobInstanceId | The target mobInstanceId to get. |
+ * | createIfMissing | If true and mob isn't found, the mob will be created and returned. |
+ */
+declare function GetMob(mobInstanceId: number): ScriptActor;
+
+/**
+ * Retrieves a RoomObject for a given roomId.
+ */
+declare function GetRoom(roomId: number): ScriptRoom;
+
+/**
+ * Retrieves a ActorObject for a given userId.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | userId | The target user id to get. |
+ */
+declare function GetUser(userId: number): ScriptActor;
+
+declare function RaiseEvent(name: string, data: { [key: string]: any }): void;
+
+/**
+ * Sends a message to everyone on the server
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | message | The message to send. |
+ */
+declare function SendBroadcast(message: string): void;
+
+/**
+ * Sends a message to all rooms with an exit leading to this room
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | roomId | The roomId to transmit the message to. |
+ * | message | The message to send. |
+ * | isQuiet | If true, only those with superior "hearing" will see it. |
+ * | excludeUserIds | One or more comma separated userIds to exclude from receiving the message. |
+ */
+declare function SendRoomExitsMessage(roomId: number, message: string, isQuiet: boolean, ...excludeUserIds: number[]): void;
+
+/**
+ * Sends a message to all users in the roomId specified
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | roomId | The roomId to transmit the message to. |
+ * | message | The message to send. |
+ * | excludeUserIds | One or more comma separated userIds to exclude from receiving the message. |
+ */
+declare function SendRoomMessage(roomId: number, message: string, ...excludeIds: number[]): void;
+
+/**
+ * Sends a message to the userId specified
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | userId | The userId who should receive the message. |
+ * | message | The message to send. |
+ */
+declare function SendUserMessage(userId: number, message: string): void;
+
+/**
+ * Applies a color pattern to a string, and returns the colorized string
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | input | plain text string you want to colorize |
+ * | patternName | the name of the color pattern you want to apply, such as "rainbow" - [see colorpatterns/colorpatterns.go](../../colorpatterns/colorpatterns.go) |
+ * | wordsOnly | If true, colors only change on a per-word basis. |
+ */
+declare function UtilApplyColorPattern(input: string, patternName: string, ...wordsOnly: boolean[]): string;
+
+/**
+ * Deletes a value from the world state. Returns `false` if it wasn't set.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | namespace | The group the value is in. |
+ * | key | The name of the value. |
+ */
+declare function UtilDeleteWorldState(namespace: string, key: string): boolean;
+
+/**
+ * Simulates a dice roll and returns a result.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | diceQty | How many dice to roll. |
+ * | diceSides | How many sides on each dice. |
+ */
+declare function UtilDiceRoll(diceQty: number, diceSides: number): number;
+
+/**
+ * Searches for a match in a list and returns a close and/or exact match. Close matches must be at least the first 3 letters of the subject
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | search | The text to search for. |
+ * | items | An array of strings to search. |
+ *
+ * The `object` has the following properties:
+ * |  Property | Explanation |
+ * | --- | --- |
+ * | object.found | `true` if either an exact or close match were found. |
+ * | object.exact | empty string or Exact matching string. |
+ * | object.close | empty string or Close matching string. |
+ */
+declare function UtilFindMatchIn(search: string, items: string[]): { [key: string]: any };
+
+/**
+ * Returns a config object with properties defined in the config yaml
+ */
+declare function UtilGetConfig(): Config;
+
+/**
+ * Converts a number of minutes into a number of rounds
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | minutes | How many minutes you want converted into a round count. |
+ */
+declare function UtilGetMinutesToRounds(minutes: number): number;
+
+/**
+ * Converts a number of minutes into a number of turns
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | minutes | How many minutes you want converted into a turn count. |
+ */
+declare function UtilGetMinutesToTurns(minutes: number): number;
+
+/**
+ * _Gets the current Round number, which always counts up_
+ */
+declare function UtilGetRoundNumber(): number;
+
+/**
+ * Converts a number of seconds into a number of rounds
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | seconds | How many seconds you want converted into a round count. |
+ */
+declare function UtilGetSecondsToRounds(seconds: number): number;
+
+/**
+ * Converts a number of seconds into a number of turns
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | seconds | How many seconds you want converted into a turn count. |
+ */
+declare function UtilGetSecondsToTurns(seconds: number): number;
+
+/**
+ * Returns an object with details about the current day/time
+ *
+ * The returned `object` has the following properties:
+ * |  Property | Explanation |
+ * | --- | --- |
+ * | object.Day | `int` representing how many days have passed. |
+ * | object.Hour | `int` current hour. |
+ * | object.Hour24 | `int` current hour in 24 hour format. |
+ * | object.Minute | `int` current minute. |
+ * | object.AmPm | `AM` or `PM` |
+ * | object.Night | `true` if is it currently nighttime. |
+ * | object.DayStart | Hour that day starts (24 hour format). |
+ * | object.NightStart | Hour that night starts (24 hour format). |
+ */
+declare function UtilGetTime(): GameDate;
+
+declare function UtilGetTimeString(): string;
+
+/**
+ * Returns a value from the world state, or `null` if it isn't set (or has expired).
+ *
+ * The world state is kept for the whole world rather than any one room, mob or user, and is saved with the autosave. It's for things like whether a bridge has been repaired, or how many times the dragon has been slain this week. Admins can see and change it with the `worldstate` command.
+ *
+ * Values are grouped into namespaces, so scripts for different areas don't step on each other's keys.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | namespace | The group the value is in, such as the area's name. |
+ * | key | The name of the value. |
+ */
+declare function UtilGetWorldState(namespace: string, key: string): any;
+
+/**
+ * Returns how many seconds until a world state value expires, `-1` if it never expires, or `0` if it isn't set.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | namespace | The group the value is in. |
+ * | key | The name of the value. |
+ */
+declare function UtilGetWorldStateTTL(namespace: string, key: string): number;
+
+/**
+ * Adds to a whole number in the world state, and returns the new number. A value that isn't set starts at 0. Any expiry the value has is kept.
+ *
+ * Returns 0 (and changes nothing) if the value is set to something other than a whole number.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | namespace | The group the value is in, such as the area's name. |
+ * | key | The name of the value. A single word. |
+ * | amount | Positive or Negative number to add. Defaults to 1. |
+ */
+declare function UtilIncrementWorldState(namespace: string, key: string, ...amount: number[]): number;
+
+/**
+ * Returns true if it is currently daytime.
+ */
+declare function UtilIsDay(): boolean;
+
+/**
+ * Returns the roomId of the user, or 0 (zero) if not found.
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | search | username or userId to find |
+ */
+declare function UtilLocateUser(idOrName: any): number;
+
+/**
+ * Sets the game time to a specific `hour:minutes`, in 24 hour time.
+ *
+ * _Example: `5:30pm` would be `UtilSetTime(17, 30)`_
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | hour | The hour to set to (0-23) |
+ * | minutes | The minutes to set to (0-59) |
+ */
+declare function UtilSetTime(hour: number, minutes: number): void;
+
+/**
+ * Sets the time to 1 round before day breaks.
+ */
+declare function UtilSetTimeDay(): void;
+
+declare function UtilSetTimeNight(): void;
+
+/**
+ * Sets a value in the world state. Returns `false` if it couldn't be set.
+ *
+ * Setting `null` deletes the value.
+ *
+ * _Note: Every change is passed to [onWorldStateChange()](SCRIPTING_ROOMS.md) in room scripts._
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | namespace | The group the value is in, such as the area's name. |
+ * | key | The name of the value. A single word. |
+ * | value | A string, number, boolean, array or object. |
+ * | ttlSeconds | How many seconds until the value expires and is deleted. If left out (or 0) it never expires. |
+ */
+declare function UtilSetWorldState(namespace: string, key: string, value: any, ...ttlSeconds: number[]): boolean;
+
+/**
+ * Strips out common prepositions and some other grammatical annoyances (such as into,to,from,the,my, etc.)
+ *
+ * |  Argument | Explanation |
+ * | --- | --- |
+ * | input | The string to strip and return. |
+ */
+declare function UtilStripPrepositions(input: string): string;
+
+declare const console: {
+    debug(msg: any): void;
+    error(msg: any): void;
+    info(msg: any): void;
+    log(msg: any): void;
+    warn(msg: any): void;
+};
+
+declare const modules: {
+    auctions: {
+        version(): string;
+    };
+    cleanup: {
+        version(): string;
+    };
+    follow: {
+        /**
+         * Intended to be invoked by a script.
+         */
+        GetFollowers(targetActor: ScriptActor): ScriptActor[];
+        version(): string;
+    };
+    gmcp: {
+        version(): string;
+    };
+    gmcp_Char: {
+        version(): string;
+    };
+    gmcp_Comm: {
+        version(): string;
+    };
+    gmcp_Game: {
+        version(): string;
+    };
+    gmcp_Mudlet: {
+        version(): string;
+    };
+    gmcp_Room: {
+        version(): string;
+    };
+    leaderboards: {
+        version(): string;
+    };
+    time: {
+        version(): string;
+    };
+    webhelp: {
+        version(): string;
+    };
+};
+
+declare function require(name: string): any;
+
+interface Config {
+    /**
+     * Start config subsections
+     */
+    Server: Server;
+    Memory: Memory;
+    LootGoblin: LootGoblin;
+    Timing: Timing;
+    FilePaths: FilePaths;
+    GamePlay: GamePlay;
+    Integrations: Integrations;
+    TextFormats: TextFormats;
+    Translation: Translation;
+    Network: Network;
+    Scripting: Scripting;
+    SpecialRooms: SpecialRooms;
+    Validation: Validation;
+    Roles: { [key: string]: string[] };
+    /**
+     * Plugins is a special case
+     */
+    Modules: { [key: string]: any };
+    AllConfigData(...excludeStrings: string[]): { [key: string]: any };
+    DotPaths(): { [key: string]: any };
+    IsBannedName(name: string): [string, boolean];
+    /**
+     * OverlayDotMap overlays values from a dot-syntax map onto the Config.
+     */
+    OverlayOverrides(dotMap: { [key: string]: any }): void;
+    SeedInt(): number;
+    SetOverrides(newOverrides: { [key: string]: any }): void;
+    /**
+     * Ensures certain ranges and defaults are observed
+     */
+    Validate(): void;
+}
+
+interface Damage {
+    /**
+     * How many attacks this weapon gets (usually 1)
+     */
+    Attacks: number;
+    /**
+     * 1d6, etc.
+     */
+    DiceRoll: string;
+    /**
+     * If this damage is a crit, what buffs does it apply?
+     */
+    CritBuffIds: number[];
+    /**
+     * how many dice to roll for this weapons damage
+     */
+    DiceCount: number;
+    /**
+     * how many sides per dice roll
+     */
+    SideCount: number;
+    /**
+     * flat damage bonus, so for example 1d6+1
+     */
+    BonusDamage: number;
+    FormatDiceRoll(): string;
+    InitDiceRoll(dRoll: string): void;
+    String(): string;
+}
+
+interface FilePaths {
+    WebDomain: string;
+    WebCDNLocation: string;
+    DataFiles: string;
+    PublicHtml: string;
+    AdminHtml: string;
+    HttpsCertFile: string;
+    HttpsKeyFile: string;
+    CarefulSaveFiles: boolean;
+    Validate(): void;
+}
+
+interface GameDate {
+    /**
+     * The round number this GameDate represents
+     */
+    RoundNumber: number;
+    RoundsPerDay: number;
+    NightHoursPerDay: number;
+    Year: number;
+    Month: number;
+    Week: number;
+    Day: number;
+    Hour: number;
+    Hour24: number;
+    Minute: number;
+    MinuteFloat: number;
+    AmPm: string;
+    Night: boolean;
+    DayStart: number;
+    NightStart: number;
+    Add(adjustHours: number, adjustDays: number, adjustYears: number): GameDate;
+    /**
+     * Example:
+     * gd := gametime.GetDate()
+     * nextPeriodRound := gd.AddPeriod(`10 days`)
+     * Accepts: x years, x months, x weeks, x days, x hours, x rounds
+     * If `IRL` or `real` are in the mix, such as `x irl days` or `x days irl`, then it will use real world time
+     */
+    AddPeriod(periodStr: string): number;
+    ReCalculate(): void;
+    String(...symbolOnly: boolean[]): string;
+}
+
+interface GamePlay {
+    AllowItemBuffRemoval: boolean;
+    /**
+     * Death related settings
+     */
+    Death: GameplayDeath;
+    /**
+     * Starting permadeath lives
+     */
+    LivesStart: number;
+    /**
+     * Maximum permadeath lives
+     */
+    LivesMax: number;
+    /**
+     * # lives gained on level up
+     */
+    LivesOnLevelUp: number;
+    /**
+     * Price in gold to buy new lives
+     */
+    PricePerLife: number;
+    /**
+     * Shops/Conatiners
+     */
+    ShopRestockRate: string;
+    /**
+     * How many objects containers can hold before overflowing
+     */
+    ContainerSizeMax: number;
+    /**
+     * Alt chars
+     */
+    MaxAltCharacters: number;
+    /**
+     * Combat
+     */
+    ConsistentAttackMessages: boolean;
+    /**
+     * PVP Restrictions
+     */
+    PVP: string;
+    PVPMinimumLevel: number;
+    /**
+     * XpScale (difficulty)
+     */
+    XPScale: number;
+    /**
+     * Chance 1-100 of attempting to converse when idle
+     */
+    MobConverseChance: number;
+    Validate(): void;
+}
+
+interface GameplayDeath {
+    /**
+     * Chance a player will drop a given piece of equipment on death
+     */
+    EquipmentDropChance: number;
+    /**
+     * If true, players will always drop their backpack items on death
+     */
+    AlwaysDropBackpack: boolean;
+    /**
+     * Possible values are: none, level, 10%, 25%, 50%, 75%, 90%, 100%
+     */
+    XPPenalty: string;
+    /**
+     * How many levels is the user protected from death penalties for?
+     */
+    ProtectionLevels: number;
+    /**
+     * Is permadeath enabled?
+     */
+    PermaDeath: boolean;
+    /**
+     * Whether corpses are left behind after mob/player deaths
+     */
+    CorpsesEnabled: boolean;
+    /**
+     * How long until corpses decay to dust (go away)
+     */
+    CorpseDecayTime: string;
+}
+
+interface Integrations {
+    Discord: IntegrationsDiscord;
+    MSSP: IntegrationsMSSP;
+    Validate(): void;
+}
+
+interface IntegrationsDiscord {
+    /**
+     * Optional Discord URL to post updates to
+     */
+    WebhookUrl: string;
+}
+
+interface IntegrationsMSSP {
+    /**
+     * Whether to answer MSSP requests from MUD listing crawlers
+     */
+    Enabled: boolean;
+    /**
+     * Extra MSSP variables to report, such as CONTACT or WEBSITE
+     */
+    Fields: { [key: string]: string };
+}
+
+interface Item {
+    ItemId: number;
+    /**
+     * `yaml:"uuid,omitempty"`
+     */
+    UUID: number[];
+    /**
+     * Does this item have a blob? Should be base64 encoded.
+     */
+    Blob: string;
+    /**
+     * How many uses it has left
+     */
+    Uses: number;
+    /**
+     * Last round this item was used
+     */
+    LastUsedRound: number;
+    Spec: ItemSpec;
+    /**
+     * Is this item uncursed?
+     */
+    Uncursed: boolean;
+    /**
+     * Is this item enchanted?
+     */
+    Enchantments: number;
+    /**
+     * Decorative text for the name of the item (e.g. "exploding")
+     */
+    Adjectives: string[];
+    /**
+     * userid of whoever stashed this item
+     */
+    StashedBy: number;
+    AddWornBuff(buffId: number): void;
+    AttrString(): string;
+    /**
+     * performs a break test and returns true if the item breaks
+     * Pass a uint8 to increase the chance of breaking.
+     */
+    BreakTest(...increaseChance: number[]): boolean;
+    DisplayName(): string;
+    /**
+     * enchantmentLevel is 0-100. If 0(zero) remove any enchantments.
+     */
+    Enchant(damageBonus: number, defenseBonus: number, statBonus: { [key: string]: number }, cursed: boolean): void;
+    Equals(b: Item): boolean;
+    GetBlob(): string;
+    GetDamage(): Damage;
+    /**
+     * Returns a random number up to the total possible reduction for this item.
+     */
+    GetDefense(): number;
+    /**
+     * Gets the specifics of the item damage
+     * Considers overrides
+     */
+    GetDiceRoll(): [number, number, number, number, number[]];
+    GetLongDescription(): string;
+    GetScript(): string;
+    GetSpec(): ItemSpec;
+    GetTempData(key: string): any;
+    HasAdjective(adj: string): boolean;
+    IsBetterThan(otherItm: Item): boolean;
+    IsCursed(): boolean;
+    IsDisabled(): boolean;
+    IsEnchanted(): boolean;
+    IsSpecial(): boolean;
+    IsValid(): boolean;
+    Name(): string;
+    NameComplex(): string;
+    NameMatch(input: string, allowContains: boolean): [boolean, boolean];
+    NameSimple(): string;
+    Redescribe(newDescription: string): void;
+    Rename(newName: string, ...displayNameOrStyle: string[]): void;
+    SetAdjective(adj: string, addToList: boolean): void;
+    SetBlob(blob: string): void;
+    SetTempData(key: string, value: any): void;
+    ShorthandId(): string;
+    StatMod(...statName: string[]): number;
+    UnEnchant(): void;
+    Uncurse(): void;
+    Validate(): void;
+}
+
+interface ItemSpec {
+    ItemId: number;
+    Value: number;
+    /**
+     * How many uses it starts with
+     */
+    Uses: number;
+    /**
+     * What buffs it can apply (if used)
+     */
+    BuffIds: number[];
+    /**
+     * BuffId's that are applied while worn, and expired when removed.
+     */
+    WornBuffIds: number[];
+    /**
+     * % of damage it reduces when it blocks attacks
+     */
+    DamageReduction: number;
+    /**
+     * How many extra rounds each combat requires
+     */
+    WaitRounds: number;
+    /**
+     * How many hands it takes to wield
+     */
+    Hands: number;
+    Name: string;
+    /**
+     * Name that is typically displayed to the user
+     */
+    DisplayName: string;
+    /**
+     * A simpler name for the item, for example "Golden Battleaxe" should be "Battleaxe" or "Axe" for simple
+     */
+    NameSimple: string;
+    Description: string;
+    /**
+     * Grants this quest if given/picked up
+     */
+    QuestToken: string;
+    Type: string;
+    Subtype: string;
+    Damage: Damage;
+    Element: string;
+    /**
+     * What stats it modifies when equipped
+     */
+    StatMods: { [key: string]: number };
+    /**
+     * Chance in 100 that the item will break when used, or when the character is hit with it equipped, or if it is in the characters inventory during an explosion, etc.
+     */
+    BreakChance: number;
+    /**
+     * Can't be removed once equipped
+     */
+    Cursed: boolean;
+    /**
+     * Example: `778-north` - If it's a key, what lock does it open? roomid-exitname etc.
+     */
+    KeyLockId: string;
+    AutoCalculateValue(): void;
+    Filename(): string;
+    Filepath(): string;
+    GetScript(): string;
+    GetScriptPath(): string;
+    /**
+     * Presumably to ensure the datafile hasn't messed something up.
+     */
+    Id(): number;
+    ItemFolder(...baseonly: boolean[]): string;
+    /**
+     * Presumably to ensure the datafile hasn't messed something up.
+     */
+    Validate(): void;
+}
+
+interface LootGoblin {
+    /**
+     * Item/floor cleanup
+     */
+    RoomId: number;
+    /**
+     * How often to spawn a loot goblin
+     */
+    RoundCount: number;
+    /**
+     * How many items on the ground to attract the loot goblin
+     */
+    MinimumItems: number;
+    /**
+     * How much gold on the ground to attract the loot goblin
+     */
+    MinimumGold: number;
+    /**
+     * should the goblin include rooms that have been visited recently?
+     */
+    IncludeRecentRooms: boolean;
+    Validate(): void;
+}
+
+interface Memory {
+    /**
+     * Mob/Room memory unload thresholds
+     */
+    MaxMobBoredom: number;
+    MobUnloadThreshold: number;
+    RoomUnloadRounds: number;
+    RoomUnloadThreshold: number;
+    Validate(): void;
+}
+
+interface Network {
+    /**
+     * Maximum number of telnet connections to accept
+     */
+    MaxTelnetConnections: number;
+    /**
+     * One or more Ports used to accept telnet connections
+     */
+    TelnetPort: string[];
+    /**
+     * Port used to accept telnet connections over TLS (uses HttpsCertFile/HttpsKeyFile)
+     */
+    TelnetTlsPort: number;
+    /**
+     * Port used for admin connections, localhost only
+     */
+    LocalPort: number;
+    /**
+     * Port used for web requests
+     */
+    HttpPort: number;
+    /**
+     * Port used for web https requests
+     */
+    HttpsPort: number;
+    /**
+     * If true, http traffic will be redirected to https
+     */
+    HttpsRedirect: boolean;
+    /**
+     * How long until a player is marked as afk?
+     */
+    AfkSeconds: number;
+    /**
+     * How many seconds a player can go without a command in game before being kicked.
+     */
+    MaxIdleSeconds: number;
+    /**
+     * Whether to kick admin/mods when idle too long.
+     */
+    TimeoutMods: boolean;
+    /**
+     * How many seconds a player will be a zombie allowing them to reconnect.
+     */
+    ZombieSeconds: number;
+    /**
+     * How many rounds of uninterrupted meditation must be completed to log out.
+     */
+    LogoutRounds: number;
+    /**
+     * Whether to offer MCCP2 (zlib) compression to telnet clients
+     */
+    MCCPEnabled: boolean;
+    /**
+     * Whether to offer MXP (clickable links) to telnet clients
+     */
+    MXPEnabled: boolean;
+    /**
+     * Whether to read PROXY protocol v1/v2 headers on telnet ports (from TrustedProxies only)
+     */
+    ProxyProtocol: boolean;
+    /**
+     * IPs or CIDR ranges of proxies/load balancers allowed to report the real client address
+     */
+    TrustedProxies: string[];
+    /**
+     * How many /api/v1 requests a token may make per minute
+     */
+    ApiRateLimit: number;
+    /**
+     * How long a web admin login lasts
+     */
+    AdminSessionMinutes: number;
+    /**
+     * Whether to serve Prometheus metrics at /metrics
+     */
+    MetricsEnabled: boolean;
+    /**
+     * If set, /metrics requires "Authorization: Bearer <token>"
+     */
+    MetricsToken: string;
+    Validate(): void;
+}
+
+interface Pet {
+    /**
+     * Name of the pet (player provided hopefully)
+     */
+    Name: string;
+    /**
+     * Optional color pattern to apply
+     */
+    NameStyle: string;
+    /**
+     * type of pet
+     */
+    Type: string;
+    /**
+     * how much food the pet has
+     */
+    Food: number;
+    /**
+     * When the pet was last fed
+     */
+    LastMealRound: number;
+    /**
+     * When the pet was last fed
+     */
+    Damage: Damage;
+    /**
+     * stat mods the pet provides
+     */
+    StatMods: { [key: string]: number };
+    /**
+     * Permabuffs this pet affords the player
+     */
+    BuffIds: number[];
+    /**
+     * How many items this mob can carry
+     */
+    Capacity: number;
+    /**
+     * Items held by this pet
+     */
+    Items: Item[];
+    DisplayName(): string;
+    Exists(): boolean;
+    Filename(): string;
+    Filepath(): string;
+    FindItem(itemName: string): [Item, boolean];
+    GetBuffs(): number[];
+    GetDiceRoll(): [number, number, number, number, number[]];
+    Id(): string;
+    RemoveItem(i: Item): boolean;
+    Save(): void;
+    StatMod(statName: string): number;
+    StoreItem(i: Item): boolean;
+    Validate(): void;
+}
+
+interface ScriptActor {
+    /**
+     * Adds a line to the users Event Log (`history`)
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | category | A short single word category  |
+     * | message | A single line describing the event |
+     */
+    AddEventLog(category: string, message: string): void;
+    /**
+     * Update how much gold an ActorObject has
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amt | A positive or negative amount of gold to alter the actors gold by. |
+     * | bankAmt (optional) | A positive or negative amount of gold to alter the actors bank gold by. |
+     */
+    AddGold(amt: number, ...bankAmt: number[]): void;
+    /**
+     * Update how much health an ActorObject has, and returns the actual amount their health changed.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amt | A positive or negative amount of health to alter the actors health by. |
+     */
+    AddHealth(amt: number): number;
+    AddMana(amt: number): number;
+    /**
+     * Cancels any buffs that have the flag provided. Returns `true` if one or more were found.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | buffFlag | The buff flag to check [see buffspec.go](../buffs/buffspec.go). |
+     */
+    CancelBuffWithFlag(buffFlag: string): boolean;
+    /**
+     * Update the alignment by a relative amount. Caps result at -100 to 100
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | alignmentChange | The alignment adjustment, from -200 to 200 |
+     */
+    ChangeAlignment(alignmentChange: number): void;
+    /**
+     * Forces the current charm of the mob to expire
+     */
+    CharmExpire(): void;
+    /**
+     * Immediately discards any charm effect without expiration effects.
+     */
+    CharmRemove(): void;
+    /**
+     * Sets a mob to charmed by a user for a set number of rounds.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | userId | userId that the mob will be charmed to |
+     * | charmRounds | How many rounds it should last, or -1 for unlimited. |
+     * | onRevertCommand | One or more commands for the mob to execute when the charm expires |
+     */
+    CharmSet(userId: number, charmRounds: number, ...onRevertCommand: string[]): void;
+    /**
+     * Stops a timer. Returns false if it already ran, or isn't one of this mob's timers.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | timerId | The id `SetTimeout()` or one of the others returned. |
+     */
+    ClearTimer(timerId: number): boolean;
+    /**
+     * Forces an ActorObject to execute a command as if they entered it
+     *
+     * _Note: Don't underestimate the power of this function! Complex and interesting behaviors or interactions can emerge from using it._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | cmd | The command to execute such as `look west` or `say goodbye`. |
+     * | waitTurns (optional) | The number of turns (NOT rounds) to wait before executing the command. |
+     */
+    Command(cmd: string, ...waitSeconds: number[]): void;
+    /**
+     * Forces an ActorObject to execute a command as if they entered it.
+     * WARNING: Advanced Usage. Required a flag integer.
+     *
+     * _Note: Don't underestimate the power of this function! Complex and interesting behaviors or interactions can emerge from using it._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | cmd | The command to execute such as `look west` or `say goodbye`. |
+     * | flag | The special control flag to pass to the command. |
+     * | waitTurns (optional) | The number of turns (NOT rounds) to wait before executing the command. |
+     */
+    CommandFlagged(cmd: string, flags: number, ...waitSeconds: number[]): void;
+    /**
+     * Get the numeric representation of a ActorObjects alignment, from -100 to 100
+     */
+    GetAlignment(): number;
+    /**
+     * Get the name of an ActorObjects alignment, from Unholy to Holy
+     */
+    GetAlignmentName(): string;
+    /**
+     * Get a list of Item objects in the ActorObjects backpack
+     *
+     * _Note: See [/scripting/docs/FUNCTIONS_ITEMS.md](FUNCTIONS_ITEMS.md) for details on ItemObject objects._
+     */
+    GetBackpackItems(): ScriptItem[];
+    /**
+     * Get the chance in 100 to tame a target
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | target | [ActorObject](FUNCTIONS_ACTORS.md) |
+     */
+    GetChanceToTame(target: ScriptActor): number;
+    /**
+     * Retrieves the name of a ActorObject.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | wrapInTags | If true, will return the name wrapped in ansi tags with the fg set to `username` or `mobname`. |
+     */
+    GetCharacterName(wrapInTags: boolean): string;
+    /**
+     * Returns the number of charmed creatures in the actors control
+     */
+    GetCharmCount(): number;
+    /**
+     * Returns the userId that charmed this actor (or zero if none)
+     */
+    GetCharmedUserId(): number;
+    /**
+     * Returns current actor health
+     */
+    GetHealth(): number;
+    /**
+     * Returns current actor max health
+     */
+    GetHealthMax(): number;
+    /**
+     * Returns current actor health as a percentage
+     */
+    GetHealthPct(): number;
+    /**
+     * Returns the last round number the user input anything at all
+     */
+    GetLastInputRound(): number;
+    /**
+     * Returns the level of the actor
+     */
+    GetLevel(): number;
+    /**
+     * Returns current actor mana
+     */
+    GetMana(): number;
+    /**
+     * Returns current actor max mana
+     */
+    GetManaMax(): number;
+    /**
+     * Returns current actor mana as a percentage
+     */
+    GetManaPct(): number;
+    /**
+     * Returns the maximum allowed charmed creatures for this actor
+     */
+    GetMaxCharmCount(): number;
+    /**
+     * Gets permanent data for the ActorObject.
+     *
+     * _Note: This miscellaneous data is attached to the character data, not the user data. If the user changes characters, it will not follow._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     */
+    GetMiscCharacterData(key: string): any;
+    /**
+     * Gets a list of misc data keys for the ActorObject.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | prefix1, prefix2, etc | Optional strings of prefixes to return matching keys. |
+     */
+    GetMiscCharacterDataKeys(...prefixMatches: string[]): string[];
+    /**
+     * Returns the number of times the actor has killed a certain mobId
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mobId | ID of the mob to check |
+     */
+    GetMobKills(mobId: number): number;
+    /**
+     * Returns a list of actors in the party, both players and mobs.
+     */
+    GetPartyMembers(): ScriptActor[];
+    /**
+     * Returns the pet object for the actor, or null
+     */
+    GetPet(): Pet;
+    /**
+     * Gets the race name of the actor, such as Human, Elf, Rodent, etc.
+     */
+    GetRace(): string;
+    /**
+     * Returns the number of times the actor has killed a certain race of mob
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | raceName | race name such as human, goblin, rodent |
+     */
+    GetRaceKills(race: string): number;
+    /**
+     * Returns the roomId a ActorObject is in.
+     */
+    GetRoomId(): number;
+    /**
+     * Returns `small`, `medium`, or `large`
+     */
+    GetSize(): string;
+    /**
+     * Returns the current skil level for the skillName, or zero if none.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | skillName | The name of the skill to train, such as `map` or `backstab`. |
+     */
+    GetSkillLevel(skillName: string): number;
+    /**
+     * Returns the named stat value.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | statName | A stat name such as `strength`, `smarts`, `perception`, etc. |
+     */
+    GetStat(statName: string): number;
+    /**
+     * returns the total specific statmod from worn items and buffs
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | statModName | The name of the special stat mod, such as "strength" or "tame" |
+     */
+    GetStatMod(statModName: string): number;
+    /**
+     * Returns the number of Stat Points the actor has.
+     */
+    GetStatPoints(): number;
+    /**
+     * Returns an object where keys are the mobId and the value is the tame level
+     */
+    GetTameMastery(): { [key: number]: number };
+    /**
+     * Gets temporary data for the ActorObject.
+     *
+     * _Note: This is useful for saving/retrieving data that a ActorObject can carry along to multiple room scripts._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     */
+    GetTempData(key: string): any;
+    /**
+     * Returns the number of Training Points the actor has.
+     */
+    GetTrainingPoints(): number;
+    /**
+     * Grants an ActorObject a Buff
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | buffId | The ID of the buff to give them. |
+     * | source | The source of the buff, "item", "spell", "trap", "curse", etc. or empty. |
+     */
+    GiveBuff(buffId: number, source: string): void;
+    /**
+     * Increases extra lives by 1 for the player/actor
+     */
+    GiveExtraLife(): void;
+    /**
+     * Accepts an ItemObject to put into the players backpack. This can be called multiple times to duplicate an item.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | ItemObject | The item object to give them. |
+     */
+    GiveItem(itm: any): void;
+    /**
+     * Grants a quest or progress on a quest to a ActorObject. If they are in a party, grants to the party members as well.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | questId | The quest identifier string to give, such as `3-start`. |
+     */
+    GiveQuest(questId: string): void;
+    /**
+     * Increases stat points for player
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amt | How many stat points to give |
+     */
+    GiveStatPoints(ct: number): void;
+    /**
+     * Increases training points for player
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amt | How many training points to give |
+     */
+    GiveTrainingPoints(ct: number): void;
+    /**
+     * Gives experience points to the actor
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | xpAmt | How much experience to grant |
+     * | reason | Short reasons such as "combat", "trash cleanup" |
+     */
+    GrantXP(xpAmt: number, reason: string): void;
+    /**
+     * Returns true if the Actor has the buffId supplied
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | buffId | The ID of the buff to look for. |
+     */
+    HasBuff(buffId: number): boolean;
+    /**
+     * Find out if an ActorObject has a specific buff flag
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | buffFlag | The buff flag to check [see buffspec.go](../buffs/buffspec.go). |
+     */
+    HasBuffFlag(buffFlag: string): boolean;
+    /**
+     * Check whether an ActorObject has an item id in their backpack
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | itemId | The ItemId to check for. |
+     * | itemId (optional) | Ignore worn items? |
+     */
+    HasItemId(itemId: number, ...excludeWorn: boolean[]): boolean;
+    /**
+     * Get whether a ActorObject has a quest/progress.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | questId | The quest identifier string to check, such as `3-start`. |
+     */
+    HasQuest(questId: string): boolean;
+    /**
+     * Returns true if the actor has the spell supplied
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | spellId | The ID of the spell |
+     */
+    HasSpell(spellId: string): boolean;
+    /**
+     * Returns the mobInstanceId of the ActorObject.
+     *
+     * _Note: Only useful for Mob ActorObjects - Returns zero otherwise._
+     */
+    InstanceId(): number;
+    /**
+     * Returns true if the actor is aggro vs targetActor
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | targetActor | [ActorObject](FUNCTIONS_ACTORS.md) |
+     */
+    IsAggro(actor: ScriptActor): boolean;
+    /**
+     * Returns true if a mob is charmed by/friendly to a player.
+     * If userId is ommitted, it will return true if the mob is charmed by any player.
+     */
+    IsCharmed(...userId: number[]): boolean;
+    /**
+     * (mobs only) Returns true if the actor is at their home roomId
+     */
+    IsHome(): boolean;
+    /**
+     * Returns `true` if actor can be tamed.
+     */
+    IsTameable(): boolean;
+    /**
+     * Adds the spell to the Actors spellbook. Returns true if learned, false if already known.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | spellId | The ID of the spell |
+     */
+    LearnSpell(spellId: string): boolean;
+    /**
+     * Returns the base mobId used to spawn new instances.
+     *
+     * _Note: Only useful for Mob ActorObjects - Returns zero otherwise._
+     */
+    MobTypeId(): number;
+    /**
+     * Quietly moves an ActorObject to a new room
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | destRoomId | The room id to move them to. |
+     * | leaveCharmedMobsBehind | If true, does not also move charmed mobs with the user. |
+     */
+    MoveRoom(destRoomId: number, ...leaveCharmedMobs: boolean[]): void;
+    /**
+     * (mobs only) Returns true if actor is currently pathing
+     */
+    Pathing(): boolean;
+    /**
+     * (mobs only) Returns true if actor is pathing and at a waypoint.
+     */
+    PathingAtWaypoint(): boolean;
+    /**
+     * Remove a buff silently
+     */
+    RemoveBuff(buffId: number): boolean;
+    /**
+     * Sends a message to the actor.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | msg | the message to send |
+     */
+    SendText(msg: string): void;
+    /**
+     * Adds or removes a specific text adjective to the characters name
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | adj | Adjective such as "sleeping", "crying" or "busy" |
+     * | addIt | `true` to add it. `false` to remove it. |
+     */
+    SetAdjective(adj: string, addIt: boolean): void;
+    /**
+     * Retrieves the name of a ActorObject.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | newName | The new name for the mob or player. |
+     */
+    SetCharacterName(newName: string): void;
+    /**
+     * Sets actor health to a specific amount. If this exceeds their maximum health, sets to their maximum health.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amt | number of hitpoints to set them to |
+     */
+    SetHealth(amt: number): void;
+    /**
+     * Calls `callback(mob, room)` every `rounds` rounds, until it's cleared. Returns the timer's id, for `ClearTimer()`.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds between each call. Less than 1 is every round. |
+     */
+    SetInterval(callback: Function | string, rounds: number): number;
+    /**
+     * Sets permanent data for the ActorObject.
+     *
+     * _Note: This miscellaneous data is attached to the character data, not the user data. If the user changes characters, it will not follow._
+     *
+     * _Note: There is a special key: `StartRoom` that will override the Start RoomId for the character if set._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     * | value | What you will be saving. If null, frees from memory. |
+     */
+    SetMiscCharacterData(key: string, value: any): void;
+    /**
+     * Sets the tame mastery of a specific mobId to a specific skill level
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mobId | MobId for the type |
+     * | newSkillLevel | New level to set it at |
+     */
+    SetTameMastery(mobId: number, newSkillLevel: number): void;
+    /**
+     * Sets temporary data for the ActorObject (Lasts until the ActorObject is removed from memory).
+     *
+     * _Note: This is useful for saving/retrieving data that an ActorObject can carry along to multiple room scripts._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     * | value | What you will be saving. If null, frees from memory. |
+     */
+    SetTempData(key: string, value: any): void;
+    /**
+     * Calls `callback(mob, room)` once, after `rounds` rounds. Returns the timer's id, for `ClearTimer()`.
+     *
+     * (mobs only) Timers belong to the mob, and stop when it dies or despawns. Setting one on a user throws an error. See [RoomObject.SetTimeout()](FUNCTIONS_ROOMS.md) for more.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds to wait. Less than 1 waits 1. |
+     */
+    SetTimeout(callback: Function | string, rounds: number): number;
+    /**
+     * The same as `SetInterval()`, but counted in turns.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns between each call. Less than 1 is every turn. |
+     */
+    SetTurnInterval(callback: Function | string, turns: number): number;
+    /**
+     * The same as `SetTimeout()`, but counted in turns, for delays shorter than a round.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns to wait. Less than 1 waits 1. |
+     */
+    SetTurnTimeout(callback: Function | string, turns: number): number;
+    /**
+     * Returns the shorthand ID string to refer to the mob or player ( `@123` or `#122` )
+     */
+    ShorthandId(): string;
+    /**
+     * Force a mob to wait this many seconds before executing any additional behaviors
+     *
+     * _Note: Only works on mobs._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | seconds | How many seconds to wait. |
+     */
+    Sleep(seconds: number): void;
+    /**
+     * Takes an object from the users backpack.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | ItemObject | The item object to take. |
+     */
+    TakeItem(itm: ScriptItem): void;
+    /**
+     * Returns true if the specified timer exists.
+     * Set timers always exist until they are checked for expiration with `TimerExpired(name string)`
+     */
+    TimerExists(name: string): boolean;
+    /**
+     * Returns true if the specified timer has expired or doesn't exist.
+     */
+    TimerExpired(name: string): boolean;
+    /**
+     * Starts a new Round timer
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | name | A string identifier. Reusing names will overwrite previously assigned names. |
+     * | period | How long until the timer expires. `1 real hour`, `1 hour`, etc. |
+     */
+    TimerSet(name: string, period: string): void;
+    /**
+     * Sets an ActorObject skill level, if it's greater than what they already have
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | skillName | The name of the skill to train, such as `map` or `backstab`. |
+     */
+    TrainSkill(skillName: string, skillLevel: number): boolean;
+    /**
+     * Uncurses any objects the target has equipped
+     */
+    Uncurse(): ScriptItem[];
+    /**
+     * Accepts an ItemObject to update in the players backpack. If the item does not already exist in the players backpack, it is ignored.
+     *
+     * _Note: This is the only way to save changes made to an item in the players backpack._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | ItemObject | The item object to give them. |
+     */
+    UpdateItem(itm: ScriptItem): void;
+    /**
+     * Returns the userId of the ActorObject.˚
+     *
+     * _Note: Only useful for User ActorObjects - Returns zero otherwise._
+     */
+    UserId(): number;
+}
+
+interface ScriptAttack {
+    /**
+     * Adds a positive or negative amount to the damage the attacker will take, and returns the new total.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | Positive or Negative number to add. |
+     */
+    AddAttackerDamage(amount: number): number;
+    /**
+     * Adds a positive or negative amount to the damage the defender will take, and returns the new total.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | Positive or Negative number to add. |
+     */
+    AddDamage(amount: number): number;
+    /**
+     * Stops the attack from doing anything: no damage, no buffs and none of the usual combat messages. Messages scripts have sent with the `SendTo` functions are still sent.
+     *
+     * No more combat events are fired for a cancelled attack.
+     */
+    Cancel(): void;
+    /**
+     * Returns the user or mob making the attack.
+     */
+    GetAttacker(): ScriptActor;
+    /**
+     * Returns how much damage the attacker will take from their own attack (usually none).
+     */
+    GetAttackerDamage(): number;
+    /**
+     * Returns how much damage the defender will take.
+     */
+    GetDamage(): number;
+    /**
+     * Returns the user or mob being attacked.
+     */
+    GetDefender(): ScriptActor;
+    /**
+     * Returns true if a script has cancelled the attack.
+     */
+    IsCancelled(): boolean;
+    /**
+     * Returns true if any of the attacks was a critical hit.
+     */
+    IsCrit(): boolean;
+    /**
+     * Returns true if any of the attacks connected.
+     */
+    IsHit(): boolean;
+    /**
+     * Sends a message to the attacker along with the rest of the round's combat messages.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | msg | The message to send. |
+     */
+    SendToAttacker(msg: string): void;
+    /**
+     * Sends a message to the defender along with the rest of the round's combat messages.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | msg | The message to send. |
+     */
+    SendToDefender(msg: string): void;
+    /**
+     * Sends a message to everyone else in the attacker's room along with the rest of the round's combat messages.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | msg | The message to send. |
+     */
+    SendToRoom(msg: string): void;
+    /**
+     * Sets how much damage the attacker will take from their own attack, such as from thorns, and returns it. It can't go below zero.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | The damage to do. |
+     */
+    SetAttackerDamage(amount: number): number;
+    /**
+     * Sets how much damage the defender will take, and returns it. It can't go below zero.
+     *
+     * _Note: the combat messages have already been written, so any damage numbers in them won't change._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | The damage to do. |
+     */
+    SetDamage(amount: number): number;
+}
+
+interface ScriptItem {
+    /**
+     * Adds a positive or negative quantity of uses to the item.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | Positive of Negative number to add. |
+     */
+    AddUsesLeft(amount: number): number;
+    /**
+     * Stops a timer. Returns false if it already ran, or isn't one of this item's timers.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | timerId | The id `SetTimeout()` or one of the others returned. |
+     */
+    ClearTimer(timerId: number): boolean;
+    /**
+     * Gets the last round number the item was used.
+     */
+    GetLastUsedRound(): number;
+    /**
+     * Sets temporary data of any sort on the item. This data is not saved/loaded when despawning.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | The name to retrieve data under. |
+     */
+    GetTempData(key: string): any;
+    /**
+     * Returns the number of uses remaining on the item (if any).
+     */
+    GetUsesLeft(): number;
+    /**
+     * Returns the itemId of the ItemObject.
+     */
+    ItemId(): number;
+    /**
+     * Set the last used round to the current round, or optionally clear it.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | clear (optional) | If true, will clear the last used back to zero |
+     */
+    MarkLastUsed(...clear: boolean[]): number;
+    Name(...simpleVersion: boolean[]): string;
+    /**
+     * Returns the complex name of the object, such as "Glowing Batteaxe +2 [c]"
+     */
+    NameComplex(): string;
+    /**
+     * Returns the simple name of the object. For example, a "Glowing Battleaxe" may just be "Axe"
+     */
+    NameSimple(): string;
+    /**
+     * Change the description for an item
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | newDescription | The plaintext new description. |
+     */
+    Redescribe(newDescription: string): void;
+    /**
+     * Renames the item, also optionally provide a fancy name or colorpattern
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | newName | The plaintext name. |
+     * | displayNameOrStyle | A fancy name in ansi tags, color short tags, or a pattern like :flame |
+     */
+    Rename(newName: string, ...displayNameOrStyle: string[]): void;
+    /**
+     * Calls `callback(actor, item, room)` every `rounds` rounds, until it's cleared. Returns the timer's id, for `ClearTimer()`.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds between each call. Less than 1 is every round. |
+     */
+    SetInterval(callback: Function | string, rounds: number): number;
+    /**
+     * Sets temporary data of any sort on the item. This data is not saved/loaded when despawning.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | The name to store the data under. Also used to retrieve the data later. |
+     * | vaue | The data to store. |
+     */
+    SetTempData(key: string, value: any): void;
+    /**
+     * Calls `callback(actor, item, room)` once, after `rounds` rounds. Returns the timer's id, for `ClearTimer()`.
+     *
+     * Timers belong to the item and follow it to whoever is carrying it, which is the `actor` the callback gets. They stop once nobody is carrying it (e.g. it's dropped or destroyed). Changes the callback makes to the item are saved. See [RoomObject.SetTimeout()](FUNCTIONS_ROOMS.md) for more.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds to wait. Less than 1 waits 1. |
+     */
+    SetTimeout(callback: Function | string, rounds: number): number;
+    /**
+     * The same as `SetInterval()`, but counted in turns.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns between each call. Less than 1 is every turn. |
+     */
+    SetTurnInterval(callback: Function | string, turns: number): number;
+    /**
+     * The same as `SetTimeout()`, but counted in turns, for delays shorter than a round.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns to wait. Less than 1 waits 1. |
+     */
+    SetTurnTimeout(callback: Function | string, turns: number): number;
+    /**
+     * Sets the remaining uses for the item to a specific number.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | amount | The number of uses to set the item to. |
+     */
+    SetUsesLeft(amount: number): number;
+    ShorthandId(): string;
+}
+
+interface ScriptRoom {
+    /**
+     * Adds a new mutator to a room.
+     *
+     * _Note: If the mutator already exists this is ignored._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mutName | the MutatorId of the mutator. |
+     */
+    AddMutator(mutName: string): void;
+    /**
+     * Adds a temporary exit to the room for the specified amount of time.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | exitNameSimple | The simple plain text exit name. |
+     * | exitNameFancy | Should be the simple name, but can have color tags. |
+     * | exitRoomId | The roomId the exit should lead to. |
+     * | expiresTimeString | Time string (1 day, 1 real day, 4 hours, etc) before it vanishes. |
+     */
+    AddTemporaryExit(exitNameSimple: string, exitNameFancy: string, exitRoomId: number, expiresTimeString: string): boolean;
+    /**
+     * Stops a timer. Returns false if it already ran, or isn't one of this room's timers.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | timerId | The id `SetTimeout()` or one of the others returned. |
+     */
+    ClearTimer(timerId: number): boolean;
+    /**
+     * Destroy an item from the ground.
+     */
+    DestroyItem(itm: ScriptItem): void;
+    /**
+     * Returns an array of all `Actor`s in the room.
+     */
+    GetAllActors(): ScriptActor[];
+    /**
+     * Gets a list of container names in the room.
+     */
+    GetContainers(): string[];
+    /**
+     * Gets a list of exits in the room.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | roomId | The room id to get containers for. |
+     *
+     * Each `object` in the returned array has the following properties:
+     * |  Property | Explanation |
+     * | --- | --- |
+     * | Name | Name of the exit such as `north` or `cave`. |
+     * | Secret | Whether or not the exit is secret/hidden. |
+     * | Lock | `false` if no lock |
+     * | Lock.LockId | Id if the lock (Some keys may match it) |
+     * | Lock.Difficulty | Difficulty rating of the lock |
+     * | Lock.Sequence | Lockpicking sequence of the lock such as `UUDU` |
+     */
+    GetExits(): { [key: string]: any }[];
+    /**
+     * Returns an array of items on the floor of the room.
+     *
+     * _Note: See [/scripting/docs/FUNCTIONS_ITEMS.md](FUNCTIONS_ITEMS.md) for details on ItemObject objects._
+     */
+    GetItems(): ScriptItem[];
+    /**
+     * Get the first mob of the MobId type provided.
+     */
+    GetMob(mobId: number, ...createIfMissing: boolean[]): ScriptActor;
+    /**
+     * Optionally can provide a MobId to look for
+     */
+    GetMobs(...mobId: number[]): ScriptActor[];
+    /**
+     * Gets permanently saved data for the room.
+     *
+     * _Note: This is useful for long term saving/retrieving data between room scripts, such as a leaderboard or clan ownership._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     */
+    GetPermData(key: string): any;
+    /**
+     * Returns an array of player `Actor`s in the room.
+     */
+    GetPlayers(): ScriptActor[];
+    /**
+     * Gets temporarily saved data for the room. Data is ephemeral.
+     *
+     * _Note: This is useful for short term saving/retrieving data between room scripts, such as a switch being triggered._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     */
+    GetTempData(key: string): any;
+    /**
+     * Returns true if the room has the specified mutator
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mutName | the MutatorId of the mutator. |
+     */
+    HasMutator(mutName: string): boolean;
+    /**
+     * Returns a list of userIds found to have the questId
+     * if userIdParty is specified, will only check users in the party of the user.
+     */
+    HasQuest(questId: string, ...partyUserId: number[]): number[];
+    /**
+     * Returns true if the room is an Ephemeral Copy of a room.
+     *
+     * _Note: This only expires it. It may be a mutator that respawns, in which case this doens't really completely remove it._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mutName | the MutatorId of the mutator. |
+     */
+    IsEphemeral(): boolean;
+    /**
+     * Returns true if exit is locked, false if unlocked or has no lock.
+     */
+    IsLocked(exitName: string): boolean;
+    /**
+     * Returns a list of userIds found to NOT have the questId
+     * if userIdParty is specified, will only check users in the party of the user.
+     */
+    MissingQuest(questId: string, ...partyUserId: number[]): number[];
+    /**
+     * Removes a mutator from a room.
+     *
+     * _Note: This only expires it. It may be a mutator that respawns, in which case this doens't really completely remove it._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mutName | the MutatorId of the mutator. |
+     */
+    RemoveMutator(mutName: string): void;
+    /**
+     * Removes a temporary exit
+     *
+     * _Note: all 3 parameters much match an existing temporary exit for it to be removed._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | exitNameSimple | The simple plain text exit name. |
+     * | exitNameFancy | Should be the simple name, but can have color tags. |
+     * | exitRoomId | The roomId the exit should lead to. |
+     */
+    RemoveTemporaryExit(exitNameSimple: string, exitNameFancy: string, exitRoomId: number): boolean;
+    /**
+     * Removes a temporary exit
+     *
+     * _Note: all 3 parameters much match an existing temporary exit for it to be removed._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | itemId | What item? |
+     * | roundInterval | How many rounds until the item respawns after it is taken/removed from the room? |
+     * | containerName | Optional container for the item to spawn into. |
+     */
+    RepeatSpawnItem(itemId: number, roundFrequency: number, ...containerName: string[]): boolean;
+    /**
+     * Returns the roomId of the room.
+     */
+    RoomId(): number;
+    /**
+     * Returns the source RoomId if this room is an ephemeral copy, otherwise just the normal RoomId
+     */
+    RoomIdSource(): number;
+    /**
+     * Sends a message to everyone in the room.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | msg | the message to send |
+     */
+    SendText(msg: string, ...excludeIds: number[]): void;
+    SendTextToExits(msg: string, isQuiet: boolean, ...excludeUserIds: number[]): void;
+    /**
+     * Calls `callback(room)` every `rounds` rounds, until it's cleared. Returns the timer's id, for `ClearTimer()`.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds between each call. Less than 1 is every round. |
+     * | persistent | Optional. If true, the timer is saved with the room and keeps going after a restart. `callback` must be a function the room script defines by name. |
+     */
+    SetInterval(callback: Function | string, rounds: number, persistent?: boolean): number;
+    /**
+     * Sets an exit to locked or not (If it has a lock)
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | exitName | The exitname to lock/unlock |
+     * | lockIt | if true, sets it to locked. Otherwise, unlocks it. |
+     */
+    SetLocked(exitName: string, lockIt: boolean): void;
+    /**
+     * Sets permanent data for the room (Saved even when room is unloaded from memory).
+     *
+     * _Note: This is useful for long term saving/retrieving data between room scripts, such as a leaderboard or clan ownership._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     * | value | What you will be saving. |
+     */
+    SetPermData(key: string, value: any): void;
+    /**
+     * Sets temporary data for the room (Lasts until the room is unloaded from memory).
+     *
+     * _Note: This is useful for short term saving/retrieving data between room scripts, such as a switch being triggered._
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | key | A unique identifier for the data. |
+     * | value | What you will be saving. |
+     */
+    SetTempData(key: string, value: any): void;
+    /**
+     * Calls `callback(room)` once, after `rounds` rounds. Returns the timer's id, for `ClearTimer()`.
+     *
+     * Timers are counted by the server, so scripts don't need to queue commands with wait turns to do something later. They belong to the room, and stop when it's unloaded, unless they're persistent. Persistent timers start again when the room is next loaded, and any that came due while it wasn't run straight away. A room keeps one persistent timer per function, so setting one again (e.g. in `onLoad()`) replaces it. Timers that aren't persistent also stop on a `reload`, since their scripts are reloaded.
+     *
+     * ```
+     * function onLoad(room) {
+     *     room.SetInterval(rumble, 10, true);
+     * }
+     *
+     * function rumble(room) {
+     *     room.SendText("The ground rumbles beneath your feet.");
+     * }
+     * ```
+     *
+     * Admins can see every timer with `script timers`.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | rounds | How many rounds to wait. Less than 1 waits 1. |
+     * | persistent | Optional. If true, the timer is saved with the room and keeps going after a restart. `callback` must be a function the room script defines by name. |
+     */
+    SetTimeout(callback: Function | string, rounds: number, persistent?: boolean): number;
+    /**
+     * The same as `SetInterval()`, but counted in turns.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns between each call. Less than 1 is every turn. |
+     * | persistent | Optional. If true, the timer is saved with the room and keeps going after a restart. `callback` must be a function the room script defines by name. |
+     */
+    SetTurnInterval(callback: Function | string, turns: number, persistent?: boolean): number;
+    /**
+     * The same as `SetTimeout()`, but counted in turns, for delays shorter than a round. Persistent ones are saved to the nearest round.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | callback | A function, or the name of one the script defines. |
+     * | turns | How many turns to wait. Less than 1 waits 1. |
+     * | persistent | Optional. If true, the timer is saved with the room and keeps going after a restart. `callback` must be a function the room script defines by name. |
+     */
+    SetTurnTimeout(callback: Function | string, turns: number, persistent?: boolean): number;
+    /**
+     * Spawns an item in the room.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | itemId | ItemId to spawn. |
+     * | inStash | If true, spawns stashed instead of visible. |
+     */
+    SpawnItem(itemId: number, inStash: boolean): void;
+    /**
+     * Creates a new instance of MobId,and returns the `Actor` of the mob.
+     *
+     * |  Argument | Explanation |
+     * | --- | --- |
+     * | mobId | The ID if the mob type to spawn. NOT THE INSTANCE ID. |
+     */
+    SpawnMob(mobId: number): ScriptActor;
+}
+
+interface Scripting {
+    /**
+     * How long to spend the first time a script is loaded into memory
+     */
+    LoadTimeoutMs: number;
+    /**
+     * How many milliseconds to allow a script to run before it is interrupted
+     */
+    RoomTimeoutMs: number;
+    Validate(): void;
+}
+
+interface Server {
+    /**
+     * Name of the MUD
+     */
+    MudName: string;
+    /**
+     * Current version this mud has been updated to
+     */
+    CurrentVersion: string;
+    /**
+     * Seed that may be used for generating content
+     */
+    Seed: string;
+    /**
+     * How many cores to allow for multi-core operations
+     */
+    MaxCPUCores: number;
+    /**
+     * Commands to run when a user logs in
+     */
+    OnLoginCommands: string[];
+    /**
+     * Message of the day to display when a user logs in
+     */
+    Motd: string;
+    /**
+     * The next room id to use when creating a new room
+     */
+    NextRoomId: number;
+    /**
+     * List of locked config properties that cannot be changed without editing the file directly.
+     */
+    Locked: string[];
+    Validate(): void;
+}
+
+interface SpecialRooms {
+    /**
+     * Default starting room.
+     */
+    StartRoom: number;
+    /**
+     * Recovery room after dying.
+     */
+    DeathRecoveryRoom: number;
+    /**
+     * List of all rooms that can be used to begin the tutorial process
+     */
+    TutorialRooms: string[];
+    Validate(): void;
+}
+
+interface TextFormats {
+    /**
+     * The in-game status prompt style
+     */
+    Prompt: string;
+    /**
+     * Special enter messages
+     */
+    EnterRoomMessageWrapper: string;
+    /**
+     * Special exit messages
+     */
+    ExitRoomMessageWrapper: string;
+    /**
+     * How to format time when displaying real time
+     */
+    Time: string;
+    /**
+     * How to format time when displaying real time (shortform)
+     */
+    TimeShort: string;
+    Validate(): void;
+}
+
+interface Timing {
+    TurnMs: number;
+    RoundSeconds: number;
+    RoundsPerAutoSave: number;
+    /**
+     * How many rounds are in a day
+     */
+    RoundsPerDay: number;
+    /**
+     * How many hours of night
+     */
+    NightHours: number;
+    /**
+     * Log a warning when one event listener takes longer than this (0 = off)
+     */
+    SlowListenerMs: number;
+    MinutesToRounds(minutes: number): number;
+    MinutesToTurns(minutes: number): number;
+    RoundsToSeconds(rounds: number): number;
+    SecondsToRounds(seconds: number): number;
+    SecondsToTurns(seconds: number): number;
+    TurnsPerAutoSave(): number;
+    TurnsPerRound(): number;
+    TurnsPerSecond(): number;
+    Validate(): void;
+}
+
+interface Translation {
+    /**
+     * Specify the default game language (fallback)
+     */
+    DefaultLanguage: string;
+    /**
+     * Specify the game language
+     */
+    Language: string;
+    /**
+     * Specify the game language file paths
+     */
+    LanguagePaths: string[];
+    Validate(): void;
+}
+
+interface Validation {
+    NameSizeMin: number;
+    NameSizeMax: number;
+    PasswordSizeMin: number;
+    PasswordSizeMax: number;
+    NameRejectRegex: string;
+    NameRejectReason: string;
+    EmailOnJoin: string;
+    /**
+     * List of names that are not allowed to be used
+     */
+    BannedNames: string[];
+    Validate(): void;
+}
+
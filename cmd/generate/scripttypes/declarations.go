@@ -0,0 +1,398 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dop251/goja"
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	rawFuncType = reflect.TypeOf(func(goja.FunctionCall) goja.Value { return nil })
+
+	// goja also passes these the runtime that's calling them
+	rawRuntimeFuncType = reflect.TypeOf(func(goja.FunctionCall, *goja.Runtime) goja.Value { return nil })
+
+	// Functions that take their arguments straight from goja, so there's nothing to reflect on.
+	// Methods are found as "Interface.Method", or by the method name alone.
+	rawFuncSignatures = map[string]string{
+		`require`:                    `(name: string): any`,
+		`SetTimeout`:                 `(callback: Function | string, rounds: number): number`,
+		`SetInterval`:                `(callback: Function | string, rounds: number): number`,
+		`SetTurnTimeout`:             `(callback: Function | string, turns: number): number`,
+		`SetTurnInterval`:            `(callback: Function | string, turns: number): number`,
+		`ScriptRoom.SetTimeout`:      `(callback: Function | string, rounds: number, persistent?: boolean): number`,
+		`ScriptRoom.SetInterval`:     `(callback: Function | string, rounds: number, persistent?: boolean): number`,
+		`ScriptRoom.SetTurnTimeout`:  `(callback: Function | string, turns: number, persistent?: boolean): number`,
+		`ScriptRoom.SetTurnInterval`: `(callback: Function | string, turns: number, persistent?: boolean): number`,
+	}
+
+	// Go parameter names that can't be used in TypeScript
+	reservedWords = map[string]bool{
+		`break`: true, `case`: true, `catch`: true, `class`: true, `const`: true, `continue`: true,
+		`debugger`: true, `default`: true, `delete`: true, `do`: true, `else`: true, `enum`: true,
+		`export`: true, `extends`: true, `false`: true, `finally`: true, `for`: true, `function`: true,
+		`if`: true, `import`: true, `in`: true, `instanceof`: true, `let`: true, `new`: true,
+		`null`: true, `return`: true, `static`: true, `super`: true, `switch`: true, `this`: true,
+		`throw`: true, `true`: true, `try`: true, `typeof`: true, `var`: true, `void`: true,
+		`while`: true, `with`: true, `yield`: true,
+	}
+)
+
+// Turns Go values and types into TypeScript declarations.
+// Structs from this module become interfaces, which are written out at the end.
+type Declarations struct {
+	modulePath string
+	docs       Docs
+	names      map[reflect.Type]string
+	taken      map[string]bool
+	pending    []reflect.Type
+	written    []string
+}
+
+func NewDeclarations(modulePath string, docs Docs) *Declarations {
+	return &Declarations{
+		modulePath: modulePath,
+		docs:       docs,
+		names:      map[reflect.Type]string{},
+		taken:      map[string]bool{},
+	}
+}
+
+// Declares every global, then every interface they use, in name order
+func (d *Declarations) String(globals map[string]any) string {
+
+	out := strings.Builder{}
+
+	names := make([]string, 0, len(globals))
+	for name := range globals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		out.WriteString(d.global(name, globals[name]))
+		out.WriteString("\n")
+	}
+
+	for len(d.pending) > 0 {
+		t := d.pending[0]
+		d.pending = d.pending[1:]
+		d.written = append(d.written, d.iface(t))
+	}
+
+	sort.Strings(d.written)
+	for _, iface := range d.written {
+		out.WriteString(iface)
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
+
+func (d *Declarations) global(name string, value any) string {
+
+	v := reflect.ValueOf(value)
+
+	if v.Kind() == reflect.Func {
+		doc := d.funcDoc(v)
+		return comment(doc.Text, ``) + `declare function ` + name + d.signature(name, v.Type(), doc.Params, false) + ";\n"
+	}
+
+	return `declare const ` + name + `: ` + d.valueType(v, ``) + ";\n"
+}
+
+// Object literal types for maps with the keys they actually have, so e.g. EventFlags.CmdSecretly autocompletes
+func (d *Declarations) valueType(v reflect.Value, indent string) string {
+
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return d.TypeOf(v.Type())
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	out := strings.Builder{}
+	out.WriteString("{\n")
+
+	for _, key := range keys {
+		name := key.String()
+		val := v.MapIndex(key)
+		for val.Kind() == reflect.Interface && !val.IsNil() {
+			val = val.Elem()
+		}
+
+		if val.Kind() == reflect.Func {
+			doc := d.funcDoc(val)
+			out.WriteString(comment(doc.Text, indent+`    `))
+			out.WriteString(indent + `    ` + propertyName(name) + d.signature(name, val.Type(), doc.Params, false) + ";\n")
+			continue
+		}
+
+		out.WriteString(indent + `    ` + propertyName(name) + `: ` + d.valueType(val, indent+`    `) + ";\n")
+	}
+
+	out.WriteString(indent + `}`)
+	return out.String()
+}
+
+func (d *Declarations) iface(t reflect.Type) string {
+
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "interface %s {\n", d.names[t])
+
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		doc := d.docs[t.PkgPath()+`.`+t.Name()+`.`+field.Name]
+		if owner := ownerOf(t, field); owner != nil {
+			doc = d.docs[owner.PkgPath()+`.`+owner.Name()+`.`+field.Name]
+		}
+		out.WriteString(comment(doc.Text, `    `))
+		fmt.Fprintf(&out, "    %s: %s;\n", field.Name, d.TypeOf(field.Type))
+	}
+
+	// goja gives scripts the pointer's methods, which includes the value's
+	ptr := reflect.PointerTo(t)
+	for i := 0; i < ptr.NumMethod(); i++ {
+		method := ptr.Method(i)
+		doc := d.docs[t.PkgPath()+`.`+t.Name()+`.`+method.Name]
+		out.WriteString(comment(doc.Text, `    `))
+		fmt.Fprintf(&out, "    %s%s;\n", method.Name, d.signature(d.names[t]+`.`+method.Name, method.Type, doc.Params, true))
+	}
+
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// The struct a promoted field really belongs to, or nil if it's t's own
+func ownerOf(t reflect.Type, field reflect.StructField) reflect.Type {
+	if len(field.Index) < 2 {
+		return nil
+	}
+	for _, i := range field.Index[:len(field.Index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	return t
+}
+
+// e.g. "(mobId: number, ...createIfMissing: boolean[]): ScriptActor"
+func (d *Declarations) signature(name string, t reflect.Type, paramNames []string, isMethod bool) string {
+
+	if isRawFunc(t, isMethod) {
+		if sig, ok := rawFuncSignatures[name]; ok {
+			return sig
+		}
+		if _, method, ok := strings.Cut(name, `.`); ok {
+			if sig, ok := rawFuncSignatures[method]; ok {
+				return sig
+			}
+		}
+		return `(...args: any[]): any`
+	}
+
+	params, result := d.params(name, t, paramNames, isMethod)
+	return `(` + params + `): ` + result
+}
+
+// Whether goja hands a function its arguments as they are, leaving nothing to reflect on
+func isRawFunc(t reflect.Type, isMethod bool) bool {
+
+	if isMethod {
+		// Compare it without the receiver
+		ins := []reflect.Type{}
+		for i := 1; i < t.NumIn(); i++ {
+			ins = append(ins, t.In(i))
+		}
+		outs := []reflect.Type{}
+		for i := 0; i < t.NumOut(); i++ {
+			outs = append(outs, t.Out(i))
+		}
+		t = reflect.FuncOf(ins, outs, t.IsVariadic())
+	}
+
+	return t == rawFuncType || t == rawRuntimeFuncType
+}
+
+func (d *Declarations) params(name string, t reflect.Type, paramNames []string, isMethod bool) (string, string) {
+
+	first := 0
+	if isMethod {
+		first = 1 // The receiver
+	}
+
+	params := []string{}
+	for i := first; i < t.NumIn(); i++ {
+
+		paramName := fmt.Sprintf(`arg%d`, i-first)
+		if i-first < len(paramNames) && paramNames[i-first] != `` && paramNames[i-first] != `_` {
+			paramName = paramNames[i-first]
+		}
+		if reservedWords[paramName] {
+			paramName += `_`
+		}
+
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			paramName = `...` + paramName
+		}
+
+		params = append(params, paramName+`: `+d.TypeOf(t.In(i)))
+	}
+
+	return strings.Join(params, `, `), d.returnType(t)
+}
+
+// goja throws any error a function returns, and gives back an array when there's more than one value
+func (d *Declarations) returnType(t reflect.Type) string {
+
+	results := []string{}
+	for i := 0; i < t.NumOut(); i++ {
+		if i == t.NumOut()-1 && t.Out(i) == errorType {
+			break
+		}
+		results = append(results, d.TypeOf(t.Out(i)))
+	}
+
+	switch len(results) {
+	case 0:
+		return `void`
+	case 1:
+		return results[0]
+	}
+	return `[` + strings.Join(results, `, `) + `]`
+}
+
+// The TypeScript type scripts see a Go type as
+func (d *Declarations) TypeOf(t reflect.Type) string {
+
+	if t == nil || t.PkgPath() == `github.com/dop251/goja` {
+		return `any`
+	}
+
+	switch t.Kind() {
+
+	case reflect.Bool:
+		return `boolean`
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return `number`
+
+	case reflect.String:
+		return `string`
+
+	case reflect.Pointer:
+		return d.TypeOf(t.Elem())
+
+	case reflect.Slice, reflect.Array:
+		// Function types are already in brackets
+		return d.TypeOf(t.Elem()) + `[]`
+
+	case reflect.Map:
+		key := `string`
+		if d.TypeOf(t.Key()) == `number` {
+			key = `number`
+		}
+		return `{ [key: ` + key + `]: ` + d.TypeOf(t.Elem()) + ` }`
+
+	case reflect.Func:
+		params, result := d.params(``, t, nil, false)
+		return `((` + params + `) => ` + result + `)`
+
+	case reflect.Struct:
+		return d.structType(t)
+	}
+
+	return `any`
+}
+
+func (d *Declarations) structType(t reflect.Type) string {
+
+	if name, ok := d.names[t]; ok {
+		return name
+	}
+
+	// Nothing is known about the standard library's structs, e.g. time.Time
+	if t.Name() != `` && !strings.HasPrefix(t.PkgPath(), d.modulePath) {
+		return `any`
+	}
+
+	if t.Name() == `` {
+		fields := []string{}
+		for _, field := range reflect.VisibleFields(t) {
+			if !field.Anonymous && field.IsExported() {
+				fields = append(fields, field.Name+`: `+d.TypeOf(field.Type))
+			}
+		}
+		return `{ ` + strings.Join(fields, `; `) + ` }`
+	}
+
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i != -1 {
+		name = name[:i] // Generic types
+	}
+	if d.taken[name] {
+		// The same name in another package
+		pkg := t.PkgPath()[strings.LastIndexByte(t.PkgPath(), '/')+1:]
+		name = string(unicode.ToUpper(rune(pkg[0]))) + pkg[1:] + name
+	}
+
+	d.names[t] = name
+	d.taken[name] = true
+	d.pending = append(d.pending, t)
+
+	return name
+}
+
+// The doc of the function a Go func value was made from
+func (d *Declarations) funcDoc(v reflect.Value) Doc {
+
+	name := runtime.FuncForPC(v.Pointer()).Name()
+
+	// Method values end in -fm, and methods look like pkg.(*Type).Method
+	name = strings.TrimSuffix(name, `-fm`)
+	slash := strings.LastIndexByte(name, '/')
+	dot := slash + 1 + strings.IndexByte(name[slash+1:], '.')
+	key := name[:dot] + `.` + strings.NewReplacer(`(*`, ``, `(`, ``, `)`, ``).Replace(name[dot+1:])
+
+	return d.docs[key]
+}
+
+func comment(text string, indent string) string {
+
+	if text == `` {
+		return ``
+	}
+
+	out := strings.Builder{}
+	out.WriteString(indent + "/**\n")
+	for _, line := range strings.Split(strings.ReplaceAll(text, `*/`, `*\/`), "\n") {
+		out.WriteString(strings.TrimRight(indent+` * `+line, ` `) + "\n")
+	}
+	out.WriteString(indent + " */\n")
+
+	return out.String()
+}
+
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && unicode.IsDigit(r))) {
+			return fmt.Sprintf(`%q`, name)
+		}
+	}
+	return name
+}
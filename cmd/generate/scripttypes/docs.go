@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// What's known about a function, method or field from the source and the guides
+type Doc struct {
+	Params []string // Parameter names, in order (functions and methods only)
+	Text   string
+}
+
+// Keyed by package path, then the type name (for methods and fields) and name, e.g.
+// "github.com/GoMudEngine/GoMud/internal/scripting.ScriptActor.GetRace"
+type Docs map[string]Doc
+
+// The guides call the script objects something else
+var guideObjectNames = map[string]string{
+	`ActorObject`:  `ScriptActor`,
+	`RoomObject`:   `ScriptRoom`,
+	`ItemObject`:   `ScriptItem`,
+	`AttackObject`: `ScriptAttack`,
+}
+
+// e.g. "## [ActorObject.GetRace() string](/internal/scripting/actor_func.go)"
+var guideHeading = regexp.MustCompile(`^## \[(?:(\w+)\.)?(\w+)\(`)
+
+// Reads the parameter names and doc comments of everything in the Go packages under dirs
+func LoadGoDocs(root string, modulePath string, dirs ...string) (Docs, error) {
+
+	docs := Docs{}
+	fset := token.NewFileSet()
+
+	for _, dir := range dirs {
+
+		err := filepath.WalkDir(filepath.Join(root, dir), func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(filePath, `.go`) || strings.HasSuffix(filePath, `_test.go`) {
+				return nil
+			}
+
+			file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+			if err != nil {
+				return err
+			}
+
+			relDir, err := filepath.Rel(root, filepath.Dir(filePath))
+			if err != nil {
+				return err
+			}
+			docs.addFile(path.Join(modulePath, filepath.ToSlash(relDir)), file)
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+func (docs Docs) addFile(pkgPath string, file *ast.File) {
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+
+		case *ast.FuncDecl:
+			key := pkgPath + `.` + decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				key = pkgPath + `.` + receiverName(decl.Recv.List[0].Type) + `.` + decl.Name.Name
+			}
+
+			doc := Doc{Text: docText(decl.Doc)}
+			for _, field := range decl.Type.Params.List {
+				if len(field.Names) == 0 {
+					doc.Params = append(doc.Params, ``)
+				}
+				for _, name := range field.Names {
+					doc.Params = append(doc.Params, name.Name)
+				}
+			}
+			docs[key] = doc
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					text := docText(field.Doc)
+					if text == `` {
+						text = docText(field.Comment)
+					}
+					for _, name := range field.Names {
+						docs[pkgPath+`.`+typeSpec.Name.Name+`.`+name.Name] = Doc{Text: text}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Reads the descriptions from the scripting guides, for whatever has no doc comment.
+// Everything in them is in pkgPath (the scripting package).
+func (docs Docs) AddGuides(dir string, pkgPath string) error {
+
+	guides, err := filepath.Glob(filepath.Join(dir, `FUNCTIONS_*.md`))
+	if err != nil {
+		return err
+	}
+
+	for _, guide := range guides {
+
+		f, err := os.Open(guide)
+		if err != nil {
+			return err
+		}
+
+		key := ``
+		text := []string{}
+
+		finish := func() {
+			if key != `` {
+				doc := docs[key]
+				if doc.Text == `` {
+					doc.Text = strings.TrimSpace(strings.Join(text, "\n"))
+					docs[key] = doc
+				}
+			}
+			key = ``
+			text = text[:0]
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if strings.HasPrefix(line, `#`) {
+				finish()
+				if m := guideHeading.FindStringSubmatch(line); m != nil {
+					key = pkgPath + `.` + m[2]
+					if m[1] != `` {
+						typeName := m[1]
+						if name, ok := guideObjectNames[typeName]; ok {
+							typeName = name
+						}
+						key = pkgPath + `.` + typeName + `.` + m[2]
+					}
+				}
+				continue
+			}
+
+			if key != `` {
+				text = append(text, line)
+			}
+		}
+		finish()
+
+		f.Close()
+
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Section banners like "// ////////" aren't about the function that happens to come next
+func docText(comments *ast.CommentGroup) string {
+	text := strings.TrimSpace(comments.Text())
+	if strings.Contains(text, `//////////`) {
+		return ``
+	}
+	return text
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ``
+}
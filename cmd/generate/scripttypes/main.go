@@ -0,0 +1,93 @@
+// ///////////////////////////////////////////////////////////////
+// NOTE: This is intended to be run by a "go generate" command
+//
+//	off of the project root.
+//
+// It writes TypeScript declarations for everything world scripts are
+// given (the functions setAllScriptingFunctions adds, the objects they
+// return and the modules' scripting functions) to
+// _datafiles/world/gomud.d.ts, so editors can autocomplete them.
+//
+// ///////////////////////////////////////////////////////////////
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/GoMudEngine/GoMud/internal/plugins"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	_ "github.com/GoMudEngine/GoMud/modules"
+)
+
+const (
+	modulePath = `github.com/GoMudEngine/GoMud`
+	outputPath = `_datafiles/world/gomud.d.ts`
+)
+
+// Only handed to scripts as event arguments, so no global leads to them
+var eventObjects = []any{
+	&scripting.ScriptAttack{},
+}
+
+const header = `// Code generated by go generate; DO NOT EDIT.
+//
+// Everything world scripts are given, for editors to autocomplete.
+// Start a script with // @ts-check to have it type checked as well.
+//
+// Regenerate with: go generate (from the project root)
+
+`
+
+func main() {
+
+	root := ``
+	output := ``
+
+	flag.StringVar(&root, "root", ".", "The project root")
+	flag.StringVar(&output, "o", "", "Where to write the declarations (default: "+outputPath+" under the root)")
+	flag.Parse()
+
+	if output == `` {
+		output = filepath.Join(root, outputPath)
+	}
+
+	declarations, err := Generate(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(output, []byte(declarations), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Builds the declarations from what's compiled in, with docs from the source under root
+func Generate(root string) (string, error) {
+
+	docs, err := LoadGoDocs(root, modulePath, `internal`, `modules`)
+	if err != nil {
+		return ``, err
+	}
+
+	if err := docs.AddGuides(filepath.Join(root, `_datafiles`, `guides`, `building`, `scripting`), modulePath+`/internal/scripting`); err != nil {
+		return ``, err
+	}
+
+	// Normally the modules are only handed to scripting when the server starts
+	for nameSpace, funcMap := range plugins.GetPluginRegistry().ScriptingFunctions() {
+		for name, funcRef := range funcMap {
+			scripting.AddModlueFunction(nameSpace, name, funcRef)
+		}
+	}
+
+	declarations := NewDeclarations(modulePath, docs)
+	for _, obj := range eventObjects {
+		declarations.TypeOf(reflect.TypeOf(obj))
+	}
+
+	return header + declarations.String(scripting.Globals()), nil
+}
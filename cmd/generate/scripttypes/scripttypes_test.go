@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRoot = `../../..`
+
+type testThing struct {
+	Name   string // What it's called
+	Counts map[string]int
+	hidden int
+}
+
+func (t testThing) Describe(verbose bool) string            { return `` }
+func (t *testThing) Rename(new string, also ...string)      {}
+func (t testThing) Lookup(key string) (int, bool, error)    { return 0, false, nil }
+func (t testThing) Each(fn func(int) bool) error            { return errors.New(`x`) }
+func (t testThing) Children() []*testThing                  { return nil }
+func (t testThing) unexported()                             {}
+func testGlobal(things []testThing, extra ...int) testThing { return testThing{} }
+
+// goja passes these their arguments as they are, and the runtime calling them
+func (t testThing) Later(call goja.FunctionCall, vm *goja.Runtime) goja.Value  { return nil }
+func (t testThing) Sooner(call goja.FunctionCall, vm *goja.Runtime) goja.Value { return nil }
+
+func TestDeclarations_TypeOf(t *testing.T) {
+
+	d := NewDeclarations(modulePath, Docs{})
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{true, `boolean`},
+		{uint64(1), `number`},
+		{``, `string`},
+		{[]string{}, `string[]`},
+		{map[string]any{}, `{ [key: string]: any }`},
+		{map[int]int{}, `{ [key: number]: number }`},
+		{func(int) error { return nil }, `((arg0: number) => void)`},
+		{struct{ A int }{}, `{ A: number }`},
+		{&testThing{}, `testThing`},
+		{[]testThing{}, `testThing[]`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, d.TypeOf(reflect.TypeOf(tt.value)))
+	}
+}
+
+func TestDeclarations_String(t *testing.T) {
+
+	pkgPath := modulePath + `/cmd/generate/scripttypes`
+	// LoadGoDocs leaves out tests, and testThing is in this one
+	file, err := parser.ParseFile(token.NewFileSet(), `scripttypes_test.go`, nil, parser.ParseComments)
+	require.NoError(t, err)
+
+	docs := Docs{}
+	docs.addFile(pkgPath, file)
+
+	rawFuncSignatures[`testThing.Later`] = `(fn: Function, delay: number): number`
+	t.Cleanup(func() { delete(rawFuncSignatures, `testThing.Later`) })
+
+	docs[pkgPath+`.testGlobal`] = Doc{Params: docs[pkgPath+`.testGlobal`].Params, Text: "Does a thing.\nTwice."}
+
+	out := NewDeclarations(modulePath, docs).String(map[string]any{
+		`testGlobal`: testGlobal,
+		`Flags`:      map[string]uint64{`B`: 2, `A`: 1},
+	})
+
+	for _, want := range []string{
+		"declare const Flags: {\n    A: number;\n    B: number;\n};\n",
+		"/**\n * Does a thing.\n * Twice.\n */\ndeclare function testGlobal(things: testThing[], ...extra: number[]): testThing;\n",
+		"interface testThing {\n    /**\n     * What it's called\n     */\n    Name: string;\n    Counts: { [key: string]: number };\n",
+		"    Children(): testThing[];\n",
+		"    Describe(verbose: boolean): string;\n",
+		"    Each(fn: ((arg0: number) => boolean)): void;\n",
+		"    Later(fn: Function, delay: number): number;\n",
+		"    Lookup(key: string): [number, boolean];\n",
+		"    Rename(new_: string, ...also: string[]): void;\n",
+		"    Sooner(...args: any[]): any;\n",
+	} {
+		assert.Contains(t, out, want)
+	}
+
+	assert.NotContains(t, out, `hidden`)
+	assert.NotContains(t, out, `unexported`)
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+
+	want, err := Generate(testRoot)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(testRoot, outputPath))
+	require.NoError(t, err)
+
+	assert.Contains(t, want, `interface ScriptActor {`)
+	assert.Contains(t, want, `GetFollowers(targetActor: ScriptActor): ScriptActor[];`)
+	assert.Equal(t, want, string(got), `%s is out of date, run "go generate" from the project root`, outputPath)
+}
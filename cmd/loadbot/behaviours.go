@@ -0,0 +1,285 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed behaviours.yaml
+var defaultBehaviours []byte
+
+type Config struct {
+	Prompt     string               `yaml:"prompt"`
+	Timeout    time.Duration        `yaml:"timeout"`
+	Triggers   []Trigger            `yaml:"triggers"`
+	Behaviours map[string]Behaviour `yaml:"behaviours"`
+
+	promptRegex *regexp.Regexp
+	names       []string // Behaviour names, sorted, so that picking is repeatable
+}
+
+// Steps to run whenever some text is seen
+type Trigger struct {
+	Match string `yaml:"match"`
+	Steps []Step `yaml:"steps"`
+
+	matchRegex *regexp.Regexp
+}
+
+type Step struct {
+	Command string        `yaml:"command"`
+	Expect  string        `yaml:"expect"`  // What a response looks like. Defaults to the prompt.
+	Timeout time.Duration `yaml:"timeout"` // Defaults to Config.Timeout
+	Pause   time.Duration `yaml:"pause"`   // How long to wait afterwards
+
+	expectRegex *regexp.Regexp
+}
+
+type Behaviour struct {
+	Weight  int             `yaml:"weight"`
+	Delay   []time.Duration `yaml:"delay"` // Random wait between actions, [from, to]
+	Actions []Action        `yaml:"actions"`
+}
+
+type Action struct {
+	Step    `yaml:",inline"`
+	Weight  int      `yaml:"weight"`
+	Targets []string `yaml:"targets"` // Only done when one of these is in the room
+	Words   []string `yaml:"words"`
+}
+
+// What a bot knows about its surroundings, for filling in placeholders
+type Surroundings struct {
+	Username string
+	Exits    []string
+	HereLine string // Lowercased "Also here:" line
+
+	alsoHere string // Seen, but the room's exits haven't arrived yet
+}
+
+func LoadConfig(path string) (*Config, error) {
+
+	data := defaultBehaviours
+	if path != `` {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+
+	return c, c.Validate()
+}
+
+func (c *Config) Validate() error {
+
+	if c.Prompt == `` {
+		return errors.New("prompt is required")
+	}
+
+	var err error
+	if c.promptRegex, err = regexp.Compile(c.Prompt); err != nil {
+		return fmt.Errorf("prompt: %w", err)
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+
+	for i := range c.Triggers {
+		t := &c.Triggers[i]
+		if t.matchRegex, err = regexp.Compile(t.Match); err != nil {
+			return fmt.Errorf("trigger %q: %w", t.Match, err)
+		}
+		for j := range t.Steps {
+			if err := c.validateStep(&t.Steps[j]); err != nil {
+				return fmt.Errorf("trigger %q: %w", t.Match, err)
+			}
+		}
+	}
+
+	if len(c.Behaviours) == 0 {
+		return errors.New("no behaviours")
+	}
+
+	c.names = c.names[:0]
+	for name, b := range c.Behaviours {
+
+		if b.Weight < 0 {
+			return fmt.Errorf("behaviour %s: weight can't be negative", name)
+		}
+		if len(b.Delay) != 2 || b.Delay[0] <= 0 || b.Delay[1] < b.Delay[0] {
+			return fmt.Errorf("behaviour %s: delay must be [from, to]", name)
+		}
+		if len(b.Actions) == 0 {
+			return fmt.Errorf("behaviour %s: no actions", name)
+		}
+
+		for i := range b.Actions {
+			a := &b.Actions[i]
+			if a.Weight < 1 {
+				a.Weight = 1
+			}
+			if err := c.validateStep(&a.Step); err != nil {
+				return fmt.Errorf("behaviour %s: %w", name, err)
+			}
+			if strings.Contains(a.Command, `{target}`) && len(a.Targets) == 0 {
+				return fmt.Errorf("behaviour %s: %q has no targets", name, a.Command)
+			}
+			if strings.Contains(a.Command, `{word}`) && len(a.Words) == 0 {
+				return fmt.Errorf("behaviour %s: %q has no words", name, a.Command)
+			}
+		}
+
+		c.names = append(c.names, name)
+	}
+
+	sort.Strings(c.names)
+
+	return nil
+}
+
+func (c *Config) validateStep(s *Step) error {
+
+	if s.Command == `` {
+		return errors.New("a step has no command")
+	}
+
+	if s.Timeout <= 0 {
+		s.Timeout = c.Timeout
+	}
+
+	expect := s.Expect
+	if expect == `` {
+		expect = c.Prompt
+	}
+
+	var err error
+	if s.expectRegex, err = regexp.Compile(expect); err != nil {
+		return fmt.Errorf("%q expect: %w", s.Command, err)
+	}
+
+	return nil
+}
+
+// Picks a behaviour by weight. If a name is given, that one is always used.
+func (c *Config) PickBehaviour(r *rand.Rand, name string) (string, error) {
+
+	if name != `` {
+		if _, ok := c.Behaviours[name]; !ok {
+			return ``, fmt.Errorf("no behaviour named %q", name)
+		}
+		return name, nil
+	}
+
+	total := 0
+	for _, n := range c.names {
+		total += c.Behaviours[n].Weight
+	}
+
+	if total == 0 {
+		return c.names[r.Intn(len(c.names))], nil
+	}
+
+	pick := r.Intn(total)
+	for _, n := range c.names {
+		if pick < c.Behaviours[n].Weight {
+			return n, nil
+		}
+		pick -= c.Behaviours[n].Weight
+	}
+
+	return c.names[len(c.names)-1], nil
+}
+
+func (b Behaviour) NextDelay(r *rand.Rand) time.Duration {
+	spread := b.Delay[1] - b.Delay[0]
+	if spread <= 0 {
+		return b.Delay[0]
+	}
+	return b.Delay[0] + time.Duration(r.Int63n(int64(spread)))
+}
+
+// Picks an action that can be done here by weight, and fills in its command.
+// Returns false if none can be done.
+func (b Behaviour) NextAction(r *rand.Rand, s Surroundings) (Action, string, bool) {
+
+	type option struct {
+		action  Action
+		command string
+	}
+
+	options := []option{}
+	total := 0
+
+	for _, a := range b.Actions {
+		if cmd, ok := a.Fill(r, s); ok {
+			options = append(options, option{a, cmd})
+			total += a.Weight
+		}
+	}
+
+	if len(options) == 0 {
+		return Action{}, ``, false
+	}
+
+	pick := r.Intn(total)
+	for _, o := range options {
+		if pick < o.action.Weight {
+			return o.action, o.command, true
+		}
+		pick -= o.action.Weight
+	}
+
+	return Action{}, ``, false
+}
+
+// Fills in the placeholders, or returns false if the action can't be done here
+func (a Action) Fill(r *rand.Rand, s Surroundings) (string, bool) {
+
+	cmd := strings.ReplaceAll(a.Command, `{username}`, s.Username)
+
+	if strings.Contains(cmd, `{exit}`) {
+		if len(s.Exits) == 0 {
+			return ``, false
+		}
+		cmd = strings.ReplaceAll(cmd, `{exit}`, s.Exits[r.Intn(len(s.Exits))])
+	}
+
+	if len(a.Targets) > 0 {
+		present := []string{}
+		for _, t := range a.Targets {
+			if strings.Contains(s.HereLine, strings.ToLower(t)) {
+				present = append(present, t)
+			}
+		}
+		if len(present) == 0 {
+			return ``, false
+		}
+		cmd = strings.ReplaceAll(cmd, `{target}`, present[r.Intn(len(present))])
+	}
+
+	if len(a.Words) > 0 {
+		cmd = strings.ReplaceAll(cmd, `{word}`, a.Words[r.Intn(len(a.Words))])
+	}
+
+	return cmd, true
+}
+
+// Fills in {username}, the only placeholder trigger steps get
+func (s Step) FillUsername(username string) string {
+	return strings.ReplaceAll(s.Command, `{username}`, username)
+}
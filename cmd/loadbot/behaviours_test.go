@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Default(t *testing.T) {
+
+	c, err := LoadConfig(``)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, c.Triggers)
+	for _, name := range []string{`wander`, `fight`, `chat`, `shop`} {
+		assert.Contains(t, c.Behaviours, name)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{`no prompt`, `behaviours: {a: {delay: [1s, 2s], actions: [{command: look}]}}`},
+		{`bad prompt`, `{prompt: '(', behaviours: {a: {delay: [1s, 2s], actions: [{command: look}]}}}`},
+		{`no behaviours`, `prompt: ':'`},
+		{`no delay`, `{prompt: ':', behaviours: {a: {actions: [{command: look}]}}}`},
+		{`backwards delay`, `{prompt: ':', behaviours: {a: {delay: [2s, 1s], actions: [{command: look}]}}}`},
+		{`no actions`, `{prompt: ':', behaviours: {a: {delay: [1s, 2s]}}}`},
+		{`no command`, `{prompt: ':', behaviours: {a: {delay: [1s, 2s], actions: [{expect: x}]}}}`},
+		{`no targets`, `{prompt: ':', behaviours: {a: {delay: [1s, 2s], actions: [{command: 'attack {target}'}]}}}`},
+		{`no words`, `{prompt: ':', behaviours: {a: {delay: [1s, 2s], actions: [{command: 'say {word}'}]}}}`},
+		{`bad trigger`, `{prompt: ':', triggers: [{match: '(', steps: [{command: x}]}], behaviours: {a: {delay: [1s, 2s], actions: [{command: look}]}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + `/behaviours.yaml`
+			require.NoError(t, os.WriteFile(path, []byte(tt.yaml), 0644))
+
+			_, err := LoadConfig(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAction_Fill(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+	here := Surroundings{Username: `bot1`, Exits: []string{`north`}, HereLine: ` a rat, guard (patrolling)`}
+	empty := Surroundings{Username: `bot1`}
+
+	tests := []struct {
+		name   string
+		action Action
+		s      Surroundings
+		want   string
+		wantOk bool
+	}{
+		{`plain`, Action{Step: Step{Command: `look`}}, empty, `look`, true},
+		{`username`, Action{Step: Step{Command: `say I am {username}`}}, empty, `say I am bot1`, true},
+		{`exit`, Action{Step: Step{Command: `{exit}`}}, here, `north`, true},
+		{`no exits`, Action{Step: Step{Command: `{exit}`}}, empty, ``, false},
+		{`target here`, Action{Step: Step{Command: `attack {target}`}, Targets: []string{`Rat`, `dragon`}}, here, `attack Rat`, true},
+		{`target missing`, Action{Step: Step{Command: `attack {target}`}, Targets: []string{`dragon`}}, here, ``, false},
+		{`needs someone`, Action{Step: Step{Command: `list`}, Targets: []string{`guard`}}, here, `list`, true},
+		{`word`, Action{Step: Step{Command: `say {word}`}, Words: []string{`hi`}}, empty, `say hi`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.action.Fill(r, tt.s)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBehaviour_NextAction(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+	b := Behaviour{
+		Delay: []time.Duration{time.Second, 2 * time.Second},
+		Actions: []Action{
+			{Step: Step{Command: `{exit}`}, Weight: 100},
+			{Step: Step{Command: `look`}, Weight: 1},
+		},
+	}
+
+	// Without any exits, look is all that's left
+	for i := 0; i < 10; i++ {
+		_, cmd, ok := b.NextAction(r, Surroundings{})
+		assert.True(t, ok)
+		assert.Equal(t, `look`, cmd)
+	}
+
+	b.Actions = b.Actions[:1]
+	_, _, ok := b.NextAction(r, Surroundings{})
+	assert.False(t, ok)
+
+	for i := 0; i < 10; i++ {
+		d := b.NextDelay(r)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.Less(t, d, 2*time.Second)
+	}
+}
+
+func TestConfig_PickBehaviour(t *testing.T) {
+
+	c, err := LoadConfig(``)
+	require.NoError(t, err)
+
+	name, err := c.PickBehaviour(rand.New(rand.NewSource(1)), `shop`)
+	require.NoError(t, err)
+	assert.Equal(t, `shop`, name)
+
+	_, err = c.PickBehaviour(rand.New(rand.NewSource(1)), `nope`)
+	assert.Error(t, err)
+
+	// The same seed picks the same behaviours
+	picks := func() []string {
+		r := rand.New(rand.NewSource(42))
+		names := []string{}
+		for i := 0; i < 20; i++ {
+			name, _ := c.PickBehaviour(r, ``)
+			names = append(names, name)
+		}
+		return names
+	}
+	assert.Equal(t, picks(), picks())
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	maxPending     = 8192 // Text kept around for matching, most recent last
+	reconnectDelay = 5 * time.Second
+)
+
+var (
+	usernamePrompt = regexp.MustCompile(`username \(or "new"\):`)
+	passwordPrompt = regexp.MustCompile(`password:`)
+	kickPrompt     = regexp.MustCompile(`Kick them\?.*\]:`)
+	invalidLogin   = regexp.MustCompile(`Invalid login`)
+	loginRefused   = regexp.MustCompile(`Nope\. Bye!`)
+
+	errDisconnected = errors.New("disconnected")
+	errTimeout      = errors.New("timed out")
+	errNoAccount    = errors.New("no such account")
+)
+
+// What all the bots share
+type BotOptions struct {
+	Address  string
+	Password string
+	Create   bool // Make the account if it doesn't exist
+	Verbose  bool // Log every command
+	Trace    bool // Log everything sent and received
+	Config   *Config
+	Stats    *Stats
+}
+
+// One connection, logged in as one account, doing one behaviour
+type Bot struct {
+	BotOptions
+
+	username  string
+	behaviour Behaviour
+	rnd       *rand.Rand
+
+	conn     Conn
+	pending  string // Text that hasn't been matched yet
+	unseen   string // Text the triggers haven't been run on
+	partial  string // The last line, until its newline arrives
+	surround Surroundings
+}
+
+func NewBot(username string, behaviour Behaviour, rnd *rand.Rand, opts BotOptions) *Bot {
+	return &Bot{
+		BotOptions: opts,
+		username:   username,
+		behaviour:  behaviour,
+		rnd:        rnd,
+		surround:   Surroundings{Username: username},
+	}
+}
+
+// Connects, logs in and plays until ctx is done, reconnecting whenever the server drops it
+func (b *Bot) Run(ctx context.Context) {
+
+	for ctx.Err() == nil {
+
+		err := b.session(ctx)
+		b.disconnect()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if errors.Is(err, errDisconnected) {
+			b.Stats.Disconnected()
+		}
+		b.logf("%s, reconnecting in %s", err, reconnectDelay)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Logs in (creating the account if needed) and plays until there's an error
+func (b *Bot) session(ctx context.Context) error {
+
+	start := time.Now()
+
+	err := b.connect()
+	if err == nil {
+		err = b.login(ctx)
+	}
+
+	if errors.Is(err, errNoAccount) && b.Create {
+		b.disconnect()
+		if err = b.connect(); err == nil {
+			err = b.createAccount(ctx)
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() == nil && !errors.Is(err, errDisconnected) {
+			b.Stats.LoginFailed()
+		}
+		return err
+	}
+
+	b.Stats.LoggedIn(time.Since(start))
+
+	return b.play(ctx)
+}
+
+func (b *Bot) connect() error {
+
+	conn, err := Dial(b.Address)
+	if err != nil {
+		b.Stats.ConnectFailed()
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	b.Stats.Connected()
+	b.conn = conn
+	b.pending = ``
+	b.unseen = ``
+	b.partial = ``
+	b.surround = Surroundings{Username: b.username}
+
+	return nil
+}
+
+func (b *Bot) disconnect() {
+	if b.conn == nil {
+		return
+	}
+	b.conn.Close()
+	// Let the reader finish up, it closes the channel when it's done
+	for range b.conn.Text() {
+	}
+	b.conn = nil
+}
+
+func (b *Bot) login(ctx context.Context) error {
+
+	if _, err := b.waitFor(ctx, b.Config.Timeout, usernamePrompt); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	if err := b.send(b.username); err != nil {
+		return err
+	}
+
+	if _, err := b.waitFor(ctx, b.Config.Timeout, passwordPrompt); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	if err := b.send(b.Password); err != nil {
+		return err
+	}
+
+	for {
+		// The kick question ends like a prompt does, so it goes first
+		i, err := b.waitFor(ctx, b.Config.Timeout, kickPrompt, invalidLogin, loginRefused, b.Config.promptRegex)
+		if err != nil {
+			return fmt.Errorf("login: %w", err)
+		}
+
+		switch i {
+		case 0:
+			// Left over from an earlier run, or a bot that was dropped
+			if err := b.send(`y`); err != nil {
+				return err
+			}
+		case 1:
+			return errNoAccount
+		case 2:
+			return errors.New("login: wrong password")
+		case 3:
+			return nil
+		}
+	}
+}
+
+func (b *Bot) createAccount(ctx context.Context) error {
+
+	steps := []struct {
+		prompt *regexp.Regexp
+		answer string
+	}{
+		{usernamePrompt, `new`},
+		{regexp.MustCompile(`Choose your username`), b.username},
+		{regexp.MustCompile(`Choose your password`), b.Password},
+		{regexp.MustCompile(`Repeat your password`), b.Password},
+	}
+
+	for _, s := range steps {
+		if _, err := b.waitFor(ctx, b.Config.Timeout, s.prompt); err != nil {
+			return fmt.Errorf("create: %w", err)
+		}
+		if err := b.send(s.answer); err != nil {
+			return err
+		}
+	}
+
+	// Email and screen reader questions depend on the server's config
+	emailPrompt := regexp.MustCompile(`Email Address \(optional\):`)
+	emailRequired := regexp.MustCompile(`Email Address:`)
+	readerPrompt := regexp.MustCompile(`screen reader\?.*\]:`)
+	confirmPrompt := regexp.MustCompile(`create a new user.*\]:`)
+
+	for {
+		i, err := b.waitFor(ctx, b.Config.Timeout, emailPrompt, emailRequired, readerPrompt, confirmPrompt, b.Config.promptRegex)
+		if err != nil {
+			return fmt.Errorf("create: %w", err)
+		}
+
+		answer := ``
+		switch i {
+		case 0:
+			answer = ``
+		case 1:
+			return errors.New("create: the server requires an email address")
+		case 2:
+			answer = `n`
+		case 3:
+			answer = `y`
+		case 4:
+			b.Stats.Created()
+			return nil
+		}
+
+		if err := b.send(answer); err != nil {
+			return err
+		}
+	}
+}
+
+// Does the behaviour's actions until there's an error, running triggers on anything that arrives in between
+func (b *Bot) play(ctx context.Context) error {
+
+	for {
+		if err := b.idle(ctx, b.behaviour.NextDelay(b.rnd)); err != nil {
+			return err
+		}
+
+		action, command, ok := b.behaviour.NextAction(b.rnd, b.surround)
+		if !ok {
+			// Nothing to do here, have a look around instead
+			action, command = Action{Step: Step{Command: `look`, expectRegex: b.Config.promptRegex, Timeout: b.Config.Timeout}}, `look`
+		}
+
+		b.pending = ``
+		sent := time.Now()
+
+		if err := b.send(command); err != nil {
+			return err
+		}
+
+		_, err := b.waitFor(ctx, action.Timeout, action.expectRegex)
+		if errors.Is(err, errTimeout) {
+			b.Stats.CommandTimeout()
+			b.logf("%q: no response in %s", command, action.Timeout)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		latency := time.Since(sent)
+		b.Stats.CommandAnswered(latency)
+		if b.Verbose {
+			b.logf("%q: %s", command, roundDuration(latency))
+		}
+	}
+}
+
+// Waits for a while, running any triggers whose text shows up
+func (b *Bot) idle(ctx context.Context, d time.Duration) error {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		ran, err := b.runTriggers(ctx)
+		if err != nil {
+			return err
+		}
+		if ran {
+			// Give it a moment after a trigger, the same as after an action
+			timer.Reset(d)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case text, ok := <-b.conn.Text():
+			if !ok {
+				return errDisconnected
+			}
+			b.received(text)
+		}
+	}
+}
+
+// Runs the triggers whose text has arrived, and returns whether any did
+func (b *Bot) runTriggers(ctx context.Context) (bool, error) {
+
+	ran := false
+
+	for _, t := range b.Config.Triggers {
+
+		if !t.matchRegex.MatchString(b.unseen) {
+			continue
+		}
+
+		b.logf("running trigger %q", t.Match)
+		ran = true
+
+		for _, s := range t.Steps {
+
+			b.pending = ``
+			if err := b.send(s.FillUsername(b.username)); err != nil {
+				return ran, err
+			}
+
+			if _, err := b.waitFor(ctx, s.Timeout, s.expectRegex); err != nil {
+				if errors.Is(err, errTimeout) {
+					// Carry on with the behaviour, the trigger may have been half done already
+					b.logf("trigger %q: %q got no response in %s", t.Match, s.Command, s.Timeout)
+					break
+				}
+				return ran, err
+			}
+
+			if err := b.pause(ctx, s.Pause); err != nil {
+				return ran, err
+			}
+		}
+
+		// What the steps got back isn't something to trigger on again
+		b.unseen = ``
+	}
+
+	return ran, nil
+}
+
+// Waits without running triggers, still taking in whatever arrives
+func (b *Bot) pause(ctx context.Context, d time.Duration) error {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case text, ok := <-b.conn.Text():
+			if !ok {
+				return errDisconnected
+			}
+			b.received(text)
+		}
+	}
+}
+
+// Waits until one of the patterns matches what's arrived, and returns which one.
+// Everything that's arrived is used up, the rest of it is part of the same response.
+func (b *Bot) waitFor(ctx context.Context, timeout time.Duration, patterns ...*regexp.Regexp) (int, error) {
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		for i, p := range patterns {
+			if p.MatchString(b.pending) {
+				b.pending = ``
+				return i, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-timer.C:
+			return -1, errTimeout
+		case text, ok := <-b.conn.Text():
+			if !ok {
+				return -1, errDisconnected
+			}
+			b.received(text)
+		}
+	}
+}
+
+func (b *Bot) received(text string) {
+
+	if b.Trace {
+		b.logf("<< %q", text)
+	}
+
+	b.pending = keepTail(b.pending + text)
+	b.unseen = keepTail(b.unseen + text)
+
+	// Only whole lines are looked at for what's around
+	b.partial += text
+	lines := strings.Split(b.partial, "\n")
+	b.partial = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		b.surround.Update(line)
+	}
+}
+
+func keepTail(s string) string {
+	if len(s) > maxPending {
+		return s[len(s)-maxPending:]
+	}
+	return s
+}
+
+func (b *Bot) send(line string) error {
+	if b.Trace {
+		b.logf(">> %q", line)
+	}
+	if err := b.conn.Send(line); err != nil {
+		return fmt.Errorf("%w: %s", errDisconnected, err)
+	}
+	return nil
+}
+
+func (b *Bot) logf(format string, args ...any) {
+	log.Printf("%s: %s", b.username, fmt.Sprintf(format, args...))
+}
+
+// Picks up the exits and who's around from a line of room description.
+// "Also here:" comes before "Exits:", and a room with nobody in it has no "Also here:" at all,
+// so it only counts once the exits for the same room turn up.
+func (s *Surroundings) Update(line string) {
+
+	line = strings.TrimSpace(line)
+
+	if rest, ok := strings.CutPrefix(line, `Also here:`); ok {
+		s.alsoHere = strings.ToLower(rest)
+		return
+	}
+
+	rest, ok := strings.CutPrefix(line, `Exits:`)
+	if !ok {
+		return
+	}
+
+	s.Exits = s.Exits[:0]
+	for _, exit := range strings.Split(rest, `,`) {
+		// e.g. "north (locked)", and secret exits are all in brackets
+		if i := strings.IndexByte(exit, '('); i != -1 {
+			exit = exit[:i]
+		}
+		exit = strings.TrimSpace(exit)
+		if exit != `` && !strings.EqualFold(exit, `none`) {
+			s.Exits = append(s.Exits, exit)
+		}
+	}
+
+	s.HereLine = s.alsoHere
+	s.alsoHere = ``
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSurroundings_Update(t *testing.T) {
+
+	s := Surroundings{}
+
+	for _, line := range []string{
+		`.: [•] Beggars lane [Frostfang]`,
+		`Also here: Rat, guard (patrolling)`,
+		``,
+		`Exits: north, south (locked), (hidden)`,
+	} {
+		s.Update(line)
+	}
+
+	assert.Equal(t, []string{`north`, `south`}, s.Exits)
+	assert.Equal(t, ` rat, guard (patrolling)`, s.HereLine)
+
+	// The next room has nobody in it
+	s.Update(`Exits: None`)
+	assert.Empty(t, s.Exits)
+	assert.Empty(t, s.HereLine)
+}
+
+func TestCleanText(t *testing.T) {
+	assert.Equal(t, "Hello there\n", cleanText("\x1b[38;5;11m\x1b[49mHello\x1b[0m there!!SOUND(static/audio/hit.mp3 V=100)\r\n"))
+}
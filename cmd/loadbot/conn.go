@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// A connection to the server, over telnet or the web client's websocket
+type Conn interface {
+	// Cleaned up text as it arrives. Closed when the connection drops.
+	Text() <-chan string
+	Send(line string) error
+	Close() error
+}
+
+const (
+	telnetIAC = 255
+	telnetSB  = 250
+	telnetSE  = 240
+
+	dialTimeout = 10 * time.Second
+)
+
+var (
+	// Colors and cursor movement
+	escapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	// Sound and music for the web client, e.g. !!SOUND(static/audio/hit.mp3 V=100)
+	mediaRegex = regexp.MustCompile(`!!(SOUND|MUSIC)\([^)]*\)`)
+)
+
+func cleanText(s string) string {
+	s = escapeRegex.ReplaceAllString(s, ``)
+	s = mediaRegex.ReplaceAllString(s, ``)
+	return strings.ReplaceAll(s, "\r", ``)
+}
+
+func Dial(address string) (Conn, error) {
+	if strings.HasPrefix(address, `ws://`) || strings.HasPrefix(address, `wss://`) {
+		return dialWebsocket(address)
+	}
+	return dialTelnet(address)
+}
+
+type telnetConn struct {
+	conn net.Conn
+	text chan string
+}
+
+func dialTelnet(address string) (*telnetConn, error) {
+
+	conn, err := net.DialTimeout(`tcp`, address, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &telnetConn{conn: conn, text: make(chan string, 64)}
+	go c.read()
+
+	return c, nil
+}
+
+// Telnet negotiation is skipped over and never answered, the server doesn't need it to play
+func (c *telnetConn) read() {
+
+	defer close(c.text)
+
+	r := bufio.NewReader(c.conn)
+	buf := make([]byte, 0, 4096)
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if len(buf) > 0 {
+				c.text <- cleanText(string(buf))
+			}
+			return
+		}
+
+		if b == telnetIAC {
+			if err := skipTelnetCommand(r); err != nil {
+				return
+			}
+			continue
+		}
+
+		buf = append(buf, b)
+
+		// Send whatever has arrived once there's a pause, so prompts without a newline get through
+		if b == '\n' || r.Buffered() == 0 {
+			c.text <- cleanText(string(buf))
+			buf = buf[:0]
+		}
+	}
+}
+
+func skipTelnetCommand(r *bufio.Reader) error {
+
+	cmd, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd == telnetIAC: // An escaped 255, not text anyone cares about
+		return nil
+	case cmd == telnetSB: // Subnegotiation runs until IAC SE
+		prev := byte(0)
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if prev == telnetIAC && b == telnetSE {
+				return nil
+			}
+			prev = b
+		}
+	case cmd >= 251: // WILL, WONT, DO, DONT and an option
+		_, err = r.ReadByte()
+		return err
+	}
+
+	return nil
+}
+
+func (c *telnetConn) Text() <-chan string {
+	return c.text
+}
+
+func (c *telnetConn) Send(line string) error {
+	c.conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	_, err := c.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (c *telnetConn) Close() error {
+	return c.conn.Close()
+}
+
+type websocketConn struct {
+	conn *websocket.Conn
+	text chan string
+}
+
+func dialWebsocket(address string) (*websocketConn, error) {
+
+	dialer := websocket.Dialer{HandshakeTimeout: dialTimeout, Proxy: http.ProxyFromEnvironment}
+
+	conn, _, err := dialer.Dial(address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &websocketConn{conn: conn, text: make(chan string, 64)}
+	go c.read()
+
+	return c, nil
+}
+
+func (c *websocketConn) read() {
+
+	defer close(c.text)
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.text <- cleanText(string(message))
+	}
+}
+
+func (c *websocketConn) Text() <-chan string {
+	return c.text
+}
+
+func (c *websocketConn) Send(line string) error {
+	c.conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(line))
+}
+
+func (c *websocketConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,172 @@
+// Loadbot connects a crowd of scripted players to a running server, to see how it holds up.
+//
+// Each bot logs in (creating its account and character the first time), then does one of the
+// behaviours in behaviours.yaml on random timers until it's stopped:
+//
+//	go run ./cmd/loadbot -bots 200 -ramp 1m -duration 10m -metrics http://127.0.0.1/metrics
+//
+// Every -report it prints how long commands took to get a response, logins, timeouts and dropped
+// connections, and when -metrics is set, how far the server's rounds have fallen behind.
+// Use -addr ws://host:port/ws to connect through the web client's websocket instead of telnet.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+func main() {
+
+	var (
+		address       string
+		botCount      int
+		prefix        string
+		password      string
+		create        bool
+		ramp          time.Duration
+		duration      time.Duration
+		behavioursPth string
+		behaviourName string
+		reportEvery   time.Duration
+		metricsUrl    string
+		metricsToken  string
+		roundSeconds  float64
+		seed          int64
+		verbose       bool
+		trace         bool
+	)
+
+	flag.StringVar(&address, "addr", "127.0.0.1:33333", "Telnet host:port, or ws://host:port/ws for the websocket")
+	flag.IntVar(&botCount, "bots", 10, "How many bots to connect")
+	flag.StringVar(&prefix, "prefix", "loadbot", "Bot usernames are the prefix and a number: loadbot1, loadbot2...")
+	flag.StringVar(&password, "password", "loadbot", "Password for every bot account")
+	flag.BoolVar(&create, "create", true, "Create bot accounts that don't exist yet")
+	flag.DurationVar(&ramp, "ramp", 10*time.Second, "Spread the bots' first connections out over this long")
+	flag.DurationVar(&duration, "duration", 0, "Stop after this long (default: until interrupted)")
+	flag.StringVar(&behavioursPth, "behaviours", "", "Behaviours file (default: the built in behaviours.yaml)")
+	flag.StringVar(&behaviourName, "behaviour", "", "Give every bot this behaviour instead of picking by weight")
+	flag.DurationVar(&reportEvery, "report", 10*time.Second, "How often to print a report")
+	flag.StringVar(&metricsUrl, "metrics", "", "The server's /metrics url, to report round lag (needs Network.MetricsEnabled)")
+	flag.StringVar(&metricsToken, "metrics-token", "", "Network.MetricsToken, if the server has one")
+	flag.Float64Var(&roundSeconds, "round-seconds", 4, "The server's Timing.RoundSeconds")
+	flag.Int64Var(&seed, "seed", 0, "Random seed, so runs pick the same behaviours and actions (default: the time)")
+	flag.BoolVar(&verbose, "v", false, "Log every command and how long it took")
+	flag.BoolVar(&trace, "trace", false, "Log everything the bots send and receive, for writing behaviours (best with -bots 1)")
+
+	flag.Parse()
+
+	config, err := LoadConfig(behavioursPth)
+	if err != nil {
+		log.Fatalf("behaviours: %s", err)
+	}
+
+	if botCount < 1 {
+		log.Fatal("-bots must be at least 1")
+	}
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	var metrics *MetricsScraper
+	if metricsUrl != `` {
+		metrics = NewMetricsScraper(metricsUrl, metricsToken, roundSeconds)
+		if _, _, err := metrics.Scrape(); err != nil {
+			log.Fatalf("metrics: %s", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	stats := NewStats()
+	opts := BotOptions{
+		Address:  address,
+		Password: password,
+		Create:   create,
+		Verbose:  verbose,
+		Trace:    trace,
+		Config:   config,
+		Stats:    stats,
+	}
+
+	log.Printf("starting %d bots on %s (seed %d)", botCount, address, seed)
+
+	wg := sync.WaitGroup{}
+	picked := map[string]int{}
+
+	for i := 0; i < botCount; i++ {
+
+		rnd := rand.New(rand.NewSource(seed + int64(i)))
+
+		name, err := config.PickBehaviour(rnd, behaviourName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		picked[name]++
+
+		bot := NewBot(fmt.Sprintf(`%s%d`, prefix, i+1), config.Behaviours[name], rnd, opts)
+		startAfter := ramp * time.Duration(i) / time.Duration(botCount)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(startAfter):
+			}
+
+			bot.Run(ctx)
+		}()
+	}
+
+	log.Printf("behaviours: %v", picked)
+
+	started := time.Now()
+	ticker := time.NewTicker(reportEvery)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+			report := fmt.Sprintf("--- %s ---\n%s", time.Since(started).Round(time.Second), stats.Interval())
+			if metrics != nil {
+				if lag, ok, err := metrics.Scrape(); err != nil {
+					report += fmt.Sprintf("  server     %s\n", err)
+				} else if ok {
+					report += lag.String()
+				}
+			}
+			fmt.Print(report)
+		}
+	}
+
+	log.Print("stopping")
+	wg.Wait()
+
+	fmt.Printf("=== total, %s ===\n%s", time.Since(started).Round(time.Second), stats.Total())
+	if metrics != nil {
+		// One last reading, so the total runs right up to now
+		metrics.Scrape()
+		if lag, ok := metrics.Total(); ok {
+			fmt.Print(lag.String())
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Reads the server's /metrics (Network.MetricsEnabled) to see how well it's keeping up.
+// Rounds are meant to happen every RoundSeconds. When turns take too long they happen less often,
+// and the time the server has fallen behind is the round lag.
+//
+
+type MetricsScraper struct {
+	url          string
+	token        string
+	roundSeconds float64
+	client       *http.Client
+
+	first *scrape
+	last  *scrape
+}
+
+type scrape struct {
+	at     time.Time
+	values map[string]float64
+}
+
+type ServerLag struct {
+	Elapsed      time.Duration
+	Rounds       float64 // Rounds that happened
+	RoundLag     time.Duration
+	AvgTurn      time.Duration // Time spent in NewTurn listeners
+	AvgRound     time.Duration // Time spent in NewRound listeners
+	UsersOnline  float64
+	EventBacklog float64
+}
+
+func NewMetricsScraper(url string, token string, roundSeconds float64) *MetricsScraper {
+	return &MetricsScraper{
+		url:          url,
+		token:        token,
+		roundSeconds: roundSeconds,
+		client:       &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Scrapes the metrics and returns the lag since the last scrape (the first call only takes a reading)
+func (m *MetricsScraper) Scrape() (ServerLag, bool, error) {
+
+	s, err := m.get()
+	if err != nil {
+		return ServerLag{}, false, err
+	}
+
+	prev := m.last
+	m.last = s
+	if m.first == nil {
+		m.first = s
+		return ServerLag{}, false, nil
+	}
+
+	return m.lag(prev, s), true, nil
+}
+
+// Lag over the whole run
+func (m *MetricsScraper) Total() (ServerLag, bool) {
+	if m.first == nil || m.last == m.first {
+		return ServerLag{}, false
+	}
+	return m.lag(m.first, m.last), true
+}
+
+func (m *MetricsScraper) lag(from *scrape, to *scrape) ServerLag {
+
+	delta := func(name string) float64 {
+		return to.values[name] - from.values[name]
+	}
+
+	average := func(name string) time.Duration {
+		ct := delta(name + `_count`)
+		if ct <= 0 {
+			return 0
+		}
+		return time.Duration(delta(name+`_sum`) / ct * float64(time.Second))
+	}
+
+	l := ServerLag{
+		Elapsed:      to.at.Sub(from.at),
+		Rounds:       delta(`gomud_round_duration_seconds_count`),
+		AvgTurn:      average(`gomud_turn_duration_seconds`),
+		AvgRound:     average(`gomud_round_duration_seconds`),
+		UsersOnline:  to.values[`gomud_users_online`],
+		EventBacklog: to.values[`gomud_event_queue_depth`],
+	}
+
+	// Scrapes don't line up with rounds, so up to a round either way is noise
+	l.RoundLag = l.Elapsed - time.Duration((l.Rounds+1)*m.roundSeconds*float64(time.Second))
+	if l.RoundLag < 0 {
+		l.RoundLag = 0
+	}
+
+	return l
+}
+
+func (m *MetricsScraper) get() (*scrape, error) {
+
+	req, err := http.NewRequest(http.MethodGet, m.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if m.token != `` {
+		req.Header.Set(`Authorization`, `Bearer `+m.token)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", m.url, resp.Status)
+	}
+
+	s := &scrape{at: time.Now(), values: map[string]float64{}}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		name, value, ok := parseMetricLine(scanner.Text())
+		if ok {
+			// Labelled metrics are added up, e.g. connections of every kind
+			s.values[name] += value
+		}
+	}
+
+	return s, scanner.Err()
+}
+
+// Reads "name{labels} value" and drops the labels
+func parseMetricLine(line string) (string, float64, bool) {
+
+	if line == `` || line[0] == '#' {
+		return ``, 0, false
+	}
+
+	// Label values can have spaces in them, so the value is always the last field
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ``, 0, false
+	}
+
+	name := fields[0]
+	if i := strings.IndexByte(name, '{'); i != -1 {
+		name = name[:i]
+	}
+
+	value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil {
+		return ``, 0, false
+	}
+
+	return name, value, true
+}
+
+func (l ServerLag) String() string {
+	return fmt.Sprintf("  server     %.0f online, %.0f events queued, %.1f rounds in %s, round lag %s, avg turn %s, avg round %s\n",
+		l.UsersOnline, l.EventBacklog, l.Rounds, roundDuration(l.Elapsed),
+		roundDuration(l.RoundLag), roundDuration(l.AvgTurn), roundDuration(l.AvgRound))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetricLine(t *testing.T) {
+
+	tests := []struct {
+		line   string
+		name   string
+		value  float64
+		wantOk bool
+	}{
+		{`# TYPE gomud_users_online gauge`, ``, 0, false},
+		{``, ``, 0, false},
+		{`gomud_users_online 12`, `gomud_users_online`, 12, true},
+		{`gomud_connections{type="telnet"} 3`, `gomud_connections`, 3, true},
+		{`gomud_thing{label="a b"} 1.5e-3`, `gomud_thing`, 0.0015, true},
+		{`gomud_broken`, ``, 0, false},
+		{`gomud_broken abc`, ``, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			name, value, ok := parseMetricLine(tt.line)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.name, name)
+			assert.InDelta(t, tt.value, value, 1e-9)
+		})
+	}
+}
+
+func TestMetricsScraper_Scrape(t *testing.T) {
+
+	rounds := atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(`Authorization`) != `Bearer s3cret` {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, "gomud_round_duration_seconds_sum %f\n", float64(rounds.Load())*0.002)
+		fmt.Fprintf(w, "gomud_round_duration_seconds_count %d\n", rounds.Load())
+		fmt.Fprintf(w, "gomud_users_online{kind=\"telnet\"} 2\n")
+		fmt.Fprintf(w, "gomud_users_online{kind=\"websocket\"} 3\n")
+	}))
+	defer server.Close()
+
+	_, _, err := NewMetricsScraper(server.URL, `wrong`, 4).Scrape()
+	assert.Error(t, err)
+
+	m := NewMetricsScraper(server.URL, `s3cret`, 0.001)
+
+	_, ok, err := m.Scrape()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	time.Sleep(50 * time.Millisecond)
+	rounds.Store(10)
+
+	lag, ok, err := m.Scrape()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, float64(10), lag.Rounds)
+	assert.Equal(t, 2*time.Millisecond, lag.AvgRound)
+	assert.Equal(t, float64(5), lag.UsersOnline)
+	// 10 rounds should have taken 10ms, so at least ~39ms (less a round of noise) is lag
+	assert.Greater(t, lag.RoundLag, 30*time.Millisecond)
+
+	total, ok := m.Total()
+	assert.True(t, ok)
+	assert.Equal(t, lag.Rounds, total.Rounds)
+}
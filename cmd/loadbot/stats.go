@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Counts what the bots did, for the whole run and for the current report interval
+type Stats struct {
+	lock sync.Mutex
+
+	total    Counts
+	interval Counts
+}
+
+type Counts struct {
+	Connects    int
+	ConnectErrs int
+	Logins      int
+	LoginErrs   int
+	Creates     int
+	Disconnects int // Drops the bot didn't ask for
+	Commands    int
+	Timeouts    int
+
+	Latency      []time.Duration // Command sent to expected response
+	LoginLatency []time.Duration // Connected to in the world
+}
+
+type Percentiles struct {
+	Count              int
+	P50, P90, P99, Max time.Duration
+}
+
+func NewStats() *Stats {
+	return &Stats{}
+}
+
+func (s *Stats) add(f func(c *Counts)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f(&s.total)
+	f(&s.interval)
+}
+
+func (s *Stats) Connected()      { s.add(func(c *Counts) { c.Connects++ }) }
+func (s *Stats) ConnectFailed()  { s.add(func(c *Counts) { c.ConnectErrs++ }) }
+func (s *Stats) LoginFailed()    { s.add(func(c *Counts) { c.LoginErrs++ }) }
+func (s *Stats) Created()        { s.add(func(c *Counts) { c.Creates++ }) }
+func (s *Stats) Disconnected()   { s.add(func(c *Counts) { c.Disconnects++ }) }
+func (s *Stats) CommandTimeout() { s.add(func(c *Counts) { c.Commands++; c.Timeouts++ }) }
+
+func (s *Stats) LoggedIn(d time.Duration) {
+	s.add(func(c *Counts) {
+		c.Logins++
+		c.LoginLatency = append(c.LoginLatency, d)
+	})
+}
+
+func (s *Stats) CommandAnswered(d time.Duration) {
+	s.add(func(c *Counts) {
+		c.Commands++
+		c.Latency = append(c.Latency, d)
+	})
+}
+
+// Returns the counts since the last call, and starts a new interval
+func (s *Stats) Interval() Counts {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c := s.interval
+	s.interval = Counts{}
+
+	return c
+}
+
+func (s *Stats) Total() Counts {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c := s.total
+	c.Latency = append([]time.Duration{}, s.total.Latency...)
+	c.LoginLatency = append([]time.Duration{}, s.total.LoginLatency...)
+
+	return c
+}
+
+func GetPercentiles(durations []time.Duration) Percentiles {
+
+	p := Percentiles{Count: len(durations)}
+	if p.Count == 0 {
+		return p
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(pct int) time.Duration {
+		i := (len(sorted)*pct+99)/100 - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+
+	p.P50 = at(50)
+	p.P90 = at(90)
+	p.P99 = at(99)
+	p.Max = sorted[len(sorted)-1]
+
+	return p
+}
+
+func (p Percentiles) String() string {
+	if p.Count == 0 {
+		return `-`
+	}
+	return fmt.Sprintf(`p50 %s  p90 %s  p99 %s  max %s`, roundDuration(p.P50), roundDuration(p.P90), roundDuration(p.P99), roundDuration(p.Max))
+}
+
+func (c Counts) String() string {
+
+	sb := strings.Builder{}
+
+	fmt.Fprintf(&sb, "  commands   %d sent, %d timed out\n", c.Commands, c.Timeouts)
+	fmt.Fprintf(&sb, "  latency    %s\n", GetPercentiles(c.Latency))
+	fmt.Fprintf(&sb, "  logins     %d (%d created), %d failed: %s\n", c.Logins, c.Creates, c.LoginErrs, GetPercentiles(c.LoginLatency))
+	fmt.Fprintf(&sb, "  connects   %d, %d failed, %d dropped\n", c.Connects, c.ConnectErrs, c.Disconnects)
+
+	return sb.String()
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
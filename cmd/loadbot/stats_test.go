@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPercentiles(t *testing.T) {
+
+	assert.Equal(t, Percentiles{}, GetPercentiles(nil))
+
+	durations := []time.Duration{}
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	p := GetPercentiles(durations)
+	assert.Equal(t, 100, p.Count)
+	assert.Equal(t, 50*time.Millisecond, p.P50)
+	assert.Equal(t, 90*time.Millisecond, p.P90)
+	assert.Equal(t, 99*time.Millisecond, p.P99)
+	assert.Equal(t, 100*time.Millisecond, p.Max)
+
+	// Left as it was
+	assert.Equal(t, 100*time.Millisecond, durations[0])
+
+	one := GetPercentiles([]time.Duration{time.Second})
+	assert.Equal(t, time.Second, one.P50)
+	assert.Equal(t, time.Second, one.P99)
+}
+
+func TestStats_Interval(t *testing.T) {
+
+	s := NewStats()
+	s.CommandAnswered(time.Millisecond)
+	s.CommandTimeout()
+	s.Disconnected()
+
+	c := s.Interval()
+	assert.Equal(t, 2, c.Commands)
+	assert.Equal(t, 1, c.Timeouts)
+	assert.Equal(t, 1, c.Disconnects)
+	assert.Len(t, c.Latency, 1)
+
+	s.CommandAnswered(time.Millisecond)
+
+	assert.Equal(t, 1, s.Interval().Commands)
+	assert.Equal(t, 3, s.Total().Commands)
+	assert.Len(t, s.Total().Latency, 2)
+}
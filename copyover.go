@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/copyover"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/plugins"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
+)
+
+const (
+	copyoverStartText   = `<ansi fg="yellow-bold">*** Copyover in progress, hold tight... ***</ansi>`
+	copyoverDoneText    = `<ansi fg="yellow-bold">*** Copyover complete. ***</ansi>`
+	copyoverDropText    = `<ansi fg="red-bold">*** The server is restarting. Please reconnect in a moment. ***</ansi>`
+	copyoverRestoreFail = `<ansi fg="red-bold">*** Your session could not be restored. Please reconnect. ***</ansi>`
+)
+
+type restoredConnection struct {
+	details *connections.ConnectionDetails
+	state   copyover.ConnectionState
+}
+
+// Saves everything and replaces the running server with a fresh copy of the binary.
+// Logged in telnet users are handed over and stay connected. TLS and websocket connections
+// can't be handed over, so they are asked to reconnect.
+// Must be called with the mud locked. Only returns if the copyover failed.
+func copyoverServer(requestedBy int) error {
+
+	c := configs.GetConfig()
+
+	mudlog.Warn("Copyover", "requestedBy", requestedBy)
+
+	state := copyover.State{}
+
+	for port, l := range telnetListeners {
+		f, ok := l.(copyover.Filer)
+		if !ok {
+			return fmt.Errorf(`listener on port %d can't be handed over`, port)
+		}
+		if err := state.AddListener(port, f); err != nil {
+			return fmt.Errorf(`listener on port %d: %w`, port, err)
+		}
+	}
+
+	handedOver := []*connections.ConnectionDetails{}
+
+	for _, connId := range connections.GetAllConnectionIds() {
+
+		cd := connections.Get(connId)
+		if cd == nil {
+			continue
+		}
+
+		user := users.GetByConnectionId(connId)
+		if user == nil || cd.State() != connections.LoggedIn {
+			connections.SendTo([]byte(templates.AnsiParse(copyoverDropText+term.CRLFStr)), connId)
+			continue
+		}
+
+		cs := copyover.ConnectionState{
+			ConnectionId:   connId,
+			UserId:         user.UserId,
+			Username:       user.Username,
+			Compressed:     cd.IsCompressed(),
+			ClientSettings: connections.GetClientSettings(connId),
+			PluginData:     plugins.CopyoverSave(connId),
+		}
+
+		if remoteAddr := cd.RemoteAddr(); remoteAddr.String() != cd.PeerAddr().String() {
+			cs.RemoteAddr = remoteAddr.String()
+		}
+
+		if err := state.AddConnection(cs, cd); err != nil {
+			connections.SendTo([]byte(templates.AnsiParse(copyoverDropText+term.CRLFStr)), connId)
+			continue
+		}
+
+		connections.SendTo([]byte(templates.AnsiParse(term.CRLFStr+copyoverStartText+term.CRLFStr)), connId)
+		handedOver = append(handedOver, cd)
+	}
+
+	if err := rooms.SaveAllRooms(); err != nil {
+		mudlog.Error("rooms.SaveAllRooms()", "error", err.Error())
+	}
+	users.SaveAllUsers()
+	plugins.Save()
+	worldstate.SaveState()
+	util.SaveRoundCount(c.FilePaths.DataFiles.String() + `/` + util.RoundCountFilename)
+
+	// Finish any zlib streams cleanly, the new process starts fresh ones
+	for _, cd := range handedOver {
+		cd.StopCompression()
+	}
+
+	mudlog.Warn("Copyover", "listeners", len(state.Listeners), "connections", len(state.Connections))
+
+	stateFilePath := filepath.Join(os.TempDir(), fmt.Sprintf(`gomud-copyover-%d.yaml`, os.Getpid()))
+
+	err := state.Exec(stateFilePath)
+
+	// Still here, so the exec failed. Carry on as if nothing happened.
+	for _, cs := range state.Connections {
+		if cs.Compressed {
+			connections.StartCompression(cs.ConnectionId)
+		}
+	}
+
+	return err
+}
+
+// Rebuilds the connections handed over by the previous process.
+// Called before any listeners are started, so connection ids can't collide.
+func restoreCopyoverConnections() []restoredConnection {
+
+	if copyoverState == nil {
+		return nil
+	}
+
+	restored := []restoredConnection{}
+
+	for _, cs := range copyoverState.Connections {
+
+		conn, err := cs.Conn()
+		if err != nil {
+			mudlog.Error("Copyover", "connectionId", cs.ConnectionId, "error", err)
+			continue
+		}
+
+		cd, err := connections.Restore(cs.ConnectionId, conn)
+		if err != nil {
+			mudlog.Error("Copyover", "connectionId", cs.ConnectionId, "error", err)
+			conn.Close()
+			continue
+		}
+
+		if addr := cs.ProxiedAddr(); addr != nil {
+			cd.SetRemoteAddr(addr)
+		}
+
+		connections.OverwriteClientSettings(cs.ConnectionId, cs.ClientSettings)
+		plugins.CopyoverRestore(cs.ConnectionId, cs.PluginData)
+
+		if cs.Compressed && bool(configs.GetNetworkConfig().MCCPEnabled) {
+			if err := cd.StartCompression(); err != nil {
+				mudlog.Warn("MCCP2", "connectionId", cs.ConnectionId, "error", err)
+			}
+		}
+
+		restored = append(restored, restoredConnection{details: cd, state: cs})
+	}
+
+	return restored
+}
+
+// Logs restored users back in and puts them back in the world, without asking for a password
+func restoreCopyoverUsers(restored []restoredConnection, wg *sync.WaitGroup) {
+
+	for _, rc := range restored {
+
+		connId := rc.details.ConnectionId()
+
+		util.LockMud()
+
+		user, err := users.LoadUser(rc.state.Username)
+		if err == nil {
+			user, _, err = users.LoginUser(user, connId)
+		}
+
+		if err != nil {
+			util.UnlockMud()
+
+			mudlog.Error("Copyover", "connectionId", connId, "username", rc.state.Username, "error", err)
+			connections.SendTo([]byte(templates.AnsiParse(copyoverRestoreFail+term.CRLFStr)), connId)
+			connections.Remove(connId)
+			continue
+		}
+
+		rc.details.SetState(connections.LoggedIn)
+		worldManager.EnterWorld(user.UserId, user.Character.RoomId, true)
+
+		util.UnlockMud()
+
+		connections.SendTo([]byte(templates.AnsiParse(copyoverDoneText+term.CRLFStr)), connId)
+
+		wg.Add(1)
+		go handleTelnetConnection(rc.details, user, wg)
+	}
+
+}
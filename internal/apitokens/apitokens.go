@@ -0,0 +1,269 @@
+package apitokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+type Kind string
+
+const (
+	KindUser Kind = `user` // Acts as a user account, with that user's current role
+	KindRole Kind = `role` // Acts as a role, not tied to any account
+
+	TokenFile = `api-tokens.yaml`
+
+	// Makes tokens easy to recognize in config files and logs
+	secretPrefix = `gmapi_`
+)
+
+var (
+	ErrInvalidKind    = errors.New("token kind must be user or role")
+	ErrInvalidSubject = errors.New("a username or role is required")
+	ErrTokenNotFound  = errors.New("token not found")
+	ErrAlreadyRevoked = errors.New("token has already been revoked")
+	ErrInvalidToken   = errors.New("invalid or expired token")
+
+	tokenLock = sync.RWMutex{}
+	tokenData = tokenFile{}
+)
+
+type tokenFile struct {
+	NextTokenId int
+	Tokens      []Token
+}
+
+// Only a hash of the secret is kept. The secret itself is shown once, when the token is created.
+type Token struct {
+	TokenId   int
+	Kind      Kind
+	Subject   string    // username (lowercase) or role name
+	Note      string    `yaml:"note,omitempty"` // What the token is for, e.g. "discord bot"
+	Hash      string    // sha256 of the secret, hex encoded
+	IssuedBy  string    // Username of the admin who created it
+	IssuedAt  time.Time //
+	ExpiresAt time.Time `yaml:"expiresat,omitempty"` // zero means it never expires
+	RevokedBy string    `yaml:"revokedby,omitempty"`
+	RevokedAt time.Time `yaml:"revokedat,omitempty"`
+}
+
+// A token works until it is revoked or it expires
+func (t Token) IsActive() bool {
+	if !t.RevokedAt.IsZero() {
+		return false
+	}
+	return t.ExpiresAt.IsZero() || time.Now().Before(t.ExpiresAt)
+}
+
+// active, expired, revoked
+func (t Token) Status() string {
+	if !t.RevokedAt.IsZero() {
+		return `revoked`
+	}
+	if !t.IsActive() {
+		return `expired`
+	}
+	return `active`
+}
+
+func (t Token) ExpiresString() string {
+	if t.ExpiresAt.IsZero() {
+		return `never`
+	}
+	return t.ExpiresAt.Format(time.DateTime)
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return ``, err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Creates a new token. A duration of zero means it never expires.
+// The returned secret is not stored anywhere, so it must be handed to the caller now.
+func Create(kind Kind, subject string, note string, issuedBy string, duration time.Duration) (Token, string, error) {
+
+	subject = strings.TrimSpace(subject)
+
+	if kind != KindUser && kind != KindRole {
+		return Token{}, ``, ErrInvalidKind
+	}
+
+	if subject == `` {
+		return Token{}, ``, ErrInvalidSubject
+	}
+
+	if kind == KindUser {
+		subject = strings.ToLower(subject)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return Token{}, ``, err
+	}
+
+	t := Token{
+		Kind:     kind,
+		Subject:  subject,
+		Note:     strings.TrimSpace(note),
+		Hash:     hashSecret(secret),
+		IssuedBy: issuedBy,
+		IssuedAt: time.Now(),
+	}
+
+	if duration > 0 {
+		t.ExpiresAt = t.IssuedAt.Add(duration)
+	}
+
+	tokenLock.Lock()
+	tokenData.NextTokenId++
+	t.TokenId = tokenData.NextTokenId
+	tokenData.Tokens = append(tokenData.Tokens, t)
+	tokenLock.Unlock()
+
+	mudlog.Warn("API Token Created", "tokenId", t.TokenId, "kind", t.Kind, "subject", t.Subject, "issuedBy", issuedBy, "expires", t.ExpiresString())
+
+	return t, secret, save()
+}
+
+// Revokes a token. It is kept for the record.
+func Revoke(tokenId int, revokedBy string) (Token, error) {
+
+	tokenLock.Lock()
+
+	idx := indexOf(tokenId)
+	if idx < 0 {
+		tokenLock.Unlock()
+		return Token{}, ErrTokenNotFound
+	}
+
+	if !tokenData.Tokens[idx].RevokedAt.IsZero() {
+		t := tokenData.Tokens[idx]
+		tokenLock.Unlock()
+		return t, ErrAlreadyRevoked
+	}
+
+	tokenData.Tokens[idx].RevokedBy = revokedBy
+	tokenData.Tokens[idx].RevokedAt = time.Now()
+	t := tokenData.Tokens[idx]
+
+	tokenLock.Unlock()
+
+	mudlog.Warn("API Token Revoked", "tokenId", t.TokenId, "kind", t.Kind, "subject", t.Subject, "revokedBy", revokedBy)
+
+	return t, save()
+}
+
+func Get(tokenId int) (Token, bool) {
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+
+	if idx := indexOf(tokenId); idx >= 0 {
+		return tokenData.Tokens[idx], true
+	}
+	return Token{}, false
+}
+
+// Returns tokens newest first. Expired and revoked tokens are only included if asked for.
+func GetAll(includeInactive bool) []Token {
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+
+	result := make([]Token, 0, len(tokenData.Tokens))
+	for _, t := range tokenData.Tokens {
+		if includeInactive || t.IsActive() {
+			result = append(result, t)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TokenId > result[j].TokenId
+	})
+
+	return result
+}
+
+// Finds the active token for a secret, as sent in an Authorization header
+func Check(secret string) (Token, error) {
+
+	secret = strings.TrimSpace(secret)
+	if !strings.HasPrefix(secret, secretPrefix) {
+		return Token{}, ErrInvalidToken
+	}
+
+	hash := hashSecret(secret)
+
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+
+	for _, t := range tokenData.Tokens {
+		if t.Hash == hash && t.IsActive() {
+			return t, nil
+		}
+	}
+	return Token{}, ErrInvalidToken
+}
+
+// Must be called with tokenLock held
+func indexOf(tokenId int) int {
+	for i, t := range tokenData.Tokens {
+		if t.TokenId == tokenId {
+			return i
+		}
+	}
+	return -1
+}
+
+func LoadTokens() {
+
+	loaded := tokenFile{}
+
+	if err := datafiles.LoadYaml(TokenFile, &loaded); err != nil {
+		mudlog.Error("LoadTokens", "error", err.Error())
+		return
+	}
+
+	activeCt := 0
+	for _, t := range loaded.Tokens {
+
+		if t.TokenId > loaded.NextTokenId {
+			loaded.NextTokenId = t.TokenId
+		}
+
+		if t.IsActive() {
+			activeCt++
+		}
+	}
+
+	tokenLock.Lock()
+	tokenData = loaded
+	tokenLock.Unlock()
+
+	mudlog.Info("LoadTokens", "total", len(loaded.Tokens), "active", activeCt)
+}
+
+func save() error {
+
+	if err := datafiles.SaveYaml(TokenFile, &tokenData, tokenLock.RLocker()); err != nil {
+		mudlog.Error("SaveTokens", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package apitokens
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func setupTokenFile(t *testing.T) string {
+	t.Helper()
+
+	dir := datafilestest.TempDir(t)
+
+	tokenLock.Lock()
+	tokenData = tokenFile{}
+	tokenLock.Unlock()
+
+	return filepath.Join(dir, TokenFile)
+}
+
+func TestCreate_Validation(t *testing.T) {
+	setupTokenFile(t)
+
+	if _, _, err := Create(`group`, `admins`, ``, `admin`, 0); !errors.Is(err, ErrInvalidKind) {
+		t.Errorf("bad kind: err = %v", err)
+	}
+	if _, _, err := Create(KindUser, `  `, ``, `admin`, 0); !errors.Is(err, ErrInvalidSubject) {
+		t.Errorf("empty subject: err = %v", err)
+	}
+
+	tok, secret, err := Create(KindUser, `Bob`, ` discord bot `, `admin`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Subject != `bob` || tok.Note != `discord bot` || tok.TokenId != 1 {
+		t.Errorf("token = %+v", tok)
+	}
+	if !strings.HasPrefix(secret, secretPrefix) || tok.Hash == secret {
+		t.Errorf("secret = %q, hash = %q", secret, tok.Hash)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	setupTokenFile(t)
+
+	tok, secret, _ := Create(KindRole, `builder`, ``, `admin`, 0)
+
+	got, err := Check(` ` + secret + ` `)
+	if err != nil || got.TokenId != tok.TokenId || got.Kind != KindRole {
+		t.Errorf("Check() = %+v, %v", got, err)
+	}
+
+	for _, bad := range []string{``, `nope`, secret[:len(secret)-1], secretPrefix} {
+		if _, err := Check(bad); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Check(%q) err = %v", bad, err)
+		}
+	}
+
+	// Expired tokens stop working without any cleanup
+	_, expiredSecret, _ := Create(KindRole, `builder`, ``, `admin`, time.Hour)
+	tokenLock.Lock()
+	tokenData.Tokens[len(tokenData.Tokens)-1].ExpiresAt = time.Now().Add(-time.Minute)
+	tokenLock.Unlock()
+
+	if _, err := Check(expiredSecret); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired token err = %v", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	setupTokenFile(t)
+
+	tok, secret, _ := Create(KindUser, `alice`, ``, `admin`, 0)
+
+	if _, err := Revoke(99, `admin`); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("unknown id: err = %v", err)
+	}
+
+	revoked, err := Revoke(tok.TokenId, `admin`)
+	if err != nil || revoked.Status() != `revoked` || revoked.RevokedBy != `admin` {
+		t.Errorf("Revoke() = %+v, %v", revoked, err)
+	}
+
+	if _, err := Revoke(tok.TokenId, `admin`); !errors.Is(err, ErrAlreadyRevoked) {
+		t.Errorf("second revoke: err = %v", err)
+	}
+
+	if _, err := Check(secret); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("revoked token still works: %v", err)
+	}
+
+	if len(GetAll(false)) != 0 || len(GetAll(true)) != 1 {
+		t.Errorf("GetAll() active=%d all=%d", len(GetAll(false)), len(GetAll(true)))
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := setupTokenFile(t)
+
+	_, secret, _ := Create(KindUser, `alice`, ``, `admin`, 0)
+	Create(KindRole, `helper`, ``, `admin`, 24*time.Hour)
+
+	// The secret is never written to disk
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), secret) {
+		t.Error("token file contains the secret")
+	}
+
+	tokenLock.Lock()
+	tokenData = tokenFile{}
+	tokenLock.Unlock()
+
+	LoadTokens()
+
+	if all := GetAll(true); len(all) != 2 || all[0].TokenId != 2 || all[1].Subject != `alice` {
+		t.Errorf("GetAll() after load = %+v", all)
+	}
+
+	if _, err := Check(secret); err != nil {
+		t.Errorf("Check() after load: %v", err)
+	}
+
+	// Ids keep counting up after a reload
+	if tok, _, _ := Create(KindRole, `helper`, ``, `admin`, 0); tok.TokenId != 3 {
+		t.Errorf("next token id = %d", tok.TokenId)
+	}
+}
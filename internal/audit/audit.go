@@ -0,0 +1,214 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+//
+// The audit log is an append-only file with one JSON entry per line.
+// Entries are never rewritten or removed by the server.
+//
+
+const (
+	AuditFile = `audit.jsonl`
+
+	OutcomeOK        = `ok`        // Command ran without error
+	OutcomeError     = `error`     // Command returned an error
+	OutcomeUnhandled = `unhandled` // Command didn't handle the input
+	OutcomeDenied    = `denied`    // User's role doesn't allow the command
+
+	DefaultLimit = 50
+)
+
+var (
+	auditLock = sync.Mutex{}
+)
+
+type Entry struct {
+	Time     time.Time `json:"time"`
+	UserId   int       `json:"userid"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	RoomId   int       `json:"roomid"`
+	Command  string    `json:"command"`
+	Args     string    `json:"args,omitempty"`
+	Outcome  string    `json:"outcome"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// The outcome of a command that was allowed to run
+func OutcomeOf(handled bool, err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+	if !handled {
+		return OutcomeUnhandled
+	}
+	return OutcomeOK
+}
+
+// The full command as typed, e.g. "room edit exits"
+func (e Entry) FullCommand() string {
+	if e.Args == `` {
+		return e.Command
+	}
+	return e.Command + ` ` + e.Args
+}
+
+type Filter struct {
+	Username string    // Case insensitive exact match
+	Command  string    // Case insensitive exact match on the command (not the arguments)
+	Since    time.Time // Inclusive, zero for no lower bound
+	Until    time.Time // Exclusive, zero for no upper bound
+	Limit    int       // Most recent n matches, DefaultLimit if zero
+}
+
+func (f Filter) Matches(e Entry) bool {
+	if f.Username != `` && !strings.EqualFold(f.Username, e.Username) {
+		return false
+	}
+	if f.Command != `` && !strings.EqualFold(f.Command, e.Command) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Appends an entry to the audit log
+func Record(e Entry) {
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		mudlog.Error("Audit", "error", err.Error())
+		return
+	}
+	line = append(line, '\n')
+
+	auditLock.Lock()
+	defer auditLock.Unlock()
+
+	f, err := os.OpenFile(datafiles.Path(AuditFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		mudlog.Error("Audit", "error", err.Error())
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(line); err != nil {
+		mudlog.Error("Audit", "error", err.Error())
+	}
+}
+
+// Returns the most recent entries matching the filter, newest first
+func Search(f Filter) ([]Entry, error) {
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultLimit
+	}
+
+	auditLock.Lock()
+	defer auditLock.Unlock()
+
+	file, err := os.Open(datafiles.Path(AuditFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Entry{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	// Keep only the last Limit matches while reading forward
+	matches := make([]Entry, 0, f.Limit)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			mudlog.Warn("Audit", "line", lineNum, "error", err.Error())
+			continue
+		}
+
+		if !f.Matches(e) {
+			continue
+		}
+
+		if len(matches) == f.Limit {
+			copy(matches, matches[1:])
+			matches = matches[:f.Limit-1]
+		}
+		matches = append(matches, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// The file is in the order things happened
+	slices.Reverse(matches)
+
+	return matches, nil
+}
+
+// Parses a point in time for filtering. Accepts dates ("2025-01-02", "2025-01-02 15:04")
+// or an amount of time ago ("30m", "12h", "3d", "2w").
+func ParseTime(s string, now time.Time) (time.Time, error) {
+
+	s = strings.TrimSpace(s)
+
+	for _, layout := range []string{time.DateTime, `2006-01-02 15:04`, time.DateOnly, time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	ago := time.Duration(0)
+	if len(s) > 1 {
+		unit := time.Duration(0)
+		switch s[len(s)-1] {
+		case 'd':
+			unit = 24 * time.Hour
+		case 'w':
+			unit = 7 * 24 * time.Hour
+		}
+
+		if unit > 0 {
+			if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+				ago = time.Duration(n) * unit
+			}
+		} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			ago = d
+		}
+	}
+
+	if ago == 0 {
+		return time.Time{}, fmt.Errorf(`invalid time: %q`, s)
+	}
+
+	return now.Add(-ago), nil
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func setupAuditFile(t *testing.T) string {
+	t.Helper()
+
+	dir := datafilestest.TempDir(t)
+
+	return filepath.Join(dir, AuditFile)
+}
+
+func TestOutcomeOf(t *testing.T) {
+	if got := OutcomeOf(true, nil); got != OutcomeOK {
+		t.Errorf("OutcomeOf(true, nil) = %q", got)
+	}
+	if got := OutcomeOf(false, nil); got != OutcomeUnhandled {
+		t.Errorf("OutcomeOf(false, nil) = %q", got)
+	}
+	if got := OutcomeOf(true, errors.New("boom")); got != OutcomeError {
+		t.Errorf("OutcomeOf(true, err) = %q", got)
+	}
+}
+
+func TestRecordAndSearch(t *testing.T) {
+	path := setupAuditFile(t)
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+
+	Record(Entry{Time: base, Username: `alice`, Role: `admin`, RoomId: 1, Command: `zap`, Args: `bob`, Outcome: OutcomeOK})
+	Record(Entry{Time: base.Add(time.Hour), Username: `bob`, Role: `builder`, RoomId: 2, Command: `grant`, Args: `gold 100`, Outcome: OutcomeDenied})
+	Record(Entry{Time: base.Add(2 * time.Hour), Username: `alice`, Role: `admin`, RoomId: 3, Command: `room`, Args: `edit exits`, Outcome: OutcomeError, Error: `no exits`})
+	Record(Entry{Time: base.Add(3 * time.Hour), Username: `Alice`, Role: `admin`, RoomId: 3, Command: `teleport`, Args: `1`, Outcome: OutcomeOK})
+
+	// Append only, one line per entry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Fatalf("audit file has %d lines; want 4", lines)
+	}
+
+	// Corrupt lines are skipped, not fatal
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0640)
+	f.WriteString("{not json\n")
+	f.Close()
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string // commands, newest first
+	}{
+		{`all`, Filter{}, []string{`teleport`, `room`, `grant`, `zap`}},
+		{`user`, Filter{Username: `ALICE`}, []string{`teleport`, `room`, `zap`}},
+		{`command`, Filter{Command: `Grant`}, []string{`grant`}},
+		{`since`, Filter{Since: base.Add(2 * time.Hour)}, []string{`teleport`, `room`}},
+		{`until`, Filter{Until: base.Add(2 * time.Hour)}, []string{`grant`, `zap`}},
+		{`range`, Filter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)}, []string{`room`, `grant`}},
+		{`limit`, Filter{Limit: 2}, []string{`teleport`, `room`}},
+		{`user and limit`, Filter{Username: `alice`, Limit: 2}, []string{`teleport`, `room`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Search(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, e := range entries {
+				got = append(got, e.Command)
+			}
+
+			if strings.Join(got, `,`) != strings.Join(tt.want, `,`) {
+				t.Errorf("Search() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+
+	entries, _ := Search(Filter{Command: `room`})
+	if len(entries) != 1 || entries[0].FullCommand() != `room edit exits` || entries[0].Error != `no exits` || entries[0].RoomId != 3 {
+		t.Errorf("room entry = %+v", entries)
+	}
+}
+
+func TestSearch_NoFile(t *testing.T) {
+	setupAuditFile(t)
+
+	entries, err := Search(Filter{})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Search() = %v, %v; want empty, nil", entries, err)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{`30m`, now.Add(-30 * time.Minute), false},
+		{`12h`, now.Add(-12 * time.Hour), false},
+		{`3d`, now.Add(-72 * time.Hour), false},
+		{`2w`, now.Add(-14 * 24 * time.Hour), false},
+		{`2025-01-02`, time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local), false},
+		{`2025-01-02 15:04`, time.Date(2025, 1, 2, 15, 4, 0, 0, time.Local), false},
+		{`2025-01-02 15:04:05`, time.Date(2025, 1, 2, 15, 4, 5, 0, time.Local), false},
+		{`0d`, time.Time{}, true},
+		{`-1h`, time.Time{}, true},
+		{`yesterday`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in, now)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, %v; want %v, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package bans
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+type BanType string
+
+const (
+	BanAccount BanType = `account`
+	BanIP      BanType = `ip`
+
+	BanFile = `bans.yaml`
+)
+
+var (
+	ErrInvalidBanType = errors.New("ban type must be account or ip")
+	ErrInvalidTarget  = errors.New("invalid ban target")
+	ErrNoReason       = errors.New("a reason is required")
+	ErrBanNotFound    = errors.New("ban not found")
+	ErrAlreadyLifted  = errors.New("ban has already been lifted")
+
+	banLock = sync.RWMutex{}
+	banData = banFile{}
+)
+
+type banFile struct {
+	NextBanId int
+	Bans      []Ban
+}
+
+type Ban struct {
+	BanId     int
+	Type      BanType
+	Target    string    // username (lowercase), IP address or CIDR range
+	Reason    string    // Shown to the banned user
+	IssuedBy  string    // Username of the admin who added it
+	IssuedAt  time.Time //
+	ExpiresAt time.Time `yaml:"expiresat,omitempty"` // zero means permanent
+	LiftedBy  string    `yaml:"liftedby,omitempty"`
+	LiftedAt  time.Time `yaml:"liftedat,omitempty"`
+
+	network *net.IPNet // parsed Target for ip bans
+}
+
+// A ban is active until it is lifted or it expires
+func (b Ban) IsActive() bool {
+	if !b.LiftedAt.IsZero() {
+		return false
+	}
+	return b.IsPermanent() || time.Now().Before(b.ExpiresAt)
+}
+
+func (b Ban) IsPermanent() bool {
+	return b.ExpiresAt.IsZero()
+}
+
+func (b Ban) IsExpired() bool {
+	return b.LiftedAt.IsZero() && !b.IsPermanent() && !time.Now().Before(b.ExpiresAt)
+}
+
+// active, expired, lifted
+func (b Ban) Status() string {
+	if !b.LiftedAt.IsZero() {
+		return `lifted`
+	}
+	if b.IsExpired() {
+		return `expired`
+	}
+	return `active`
+}
+
+// Human friendly expiry such as "permanent" or "2025-01-02 15:04 (3d 4h left)"
+func (b Ban) ExpiresString() string {
+	if b.IsPermanent() {
+		return `permanent`
+	}
+	if !b.IsActive() {
+		return b.ExpiresAt.Format(time.DateTime)
+	}
+	return fmt.Sprintf(`%s (%s left)`, b.ExpiresAt.Format(time.DateTime), FormatDuration(time.Until(b.ExpiresAt)))
+}
+
+// The message shown to someone turned away by this ban
+func (b Ban) Message() string {
+	msg := `You are banned from this server.`
+	if b.Type == BanAccount {
+		msg = `This account is banned.`
+	}
+	if b.Reason != `` {
+		msg += ` Reason: ` + b.Reason
+	}
+	if !b.IsPermanent() {
+		msg += ` The ban expires ` + b.ExpiresAt.Format(time.DateTime) + `.`
+	}
+	return msg
+}
+
+func (b *Ban) matchesIP(ip net.IP) bool {
+	if b.network == nil {
+		return false
+	}
+	return b.network.Contains(ip)
+}
+
+// Turns an IP or CIDR into a network. A plain IP is treated as a single address range.
+func parseNetwork(target string) (*net.IPNet, string, error) {
+
+	if strings.Contains(target, `/`) {
+		_, network, err := net.ParseCIDR(target)
+		if err != nil {
+			return nil, ``, fmt.Errorf(`%w: %s`, ErrInvalidTarget, err)
+		}
+		return network, network.String(), nil
+	}
+
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return nil, ``, fmt.Errorf(`%w: %q is not an IP address or CIDR range`, ErrInvalidTarget, target)
+	}
+
+	bits := 128
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 32
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, ip.String(), nil
+}
+
+// Parses ban lengths such as "30m", "12h", "7d", "2w" or "perm".
+// A zero duration means permanent.
+func ParseDuration(s string) (time.Duration, error) {
+
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	switch s {
+	case `perm`, `permanent`, `forever`, `0`:
+		return 0, nil
+	}
+
+	if len(s) > 1 {
+		unit := time.Duration(0)
+		switch s[len(s)-1] {
+		case 'd':
+			unit = 24 * time.Hour
+		case 'w':
+			unit = 7 * 24 * time.Hour
+		}
+		if unit > 0 {
+			n, err := strconv.Atoi(s[:len(s)-1])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf(`invalid duration: %q`, s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf(`invalid duration: %q`, s)
+	}
+
+	return d, nil
+}
+
+// Compact duration such as "3d 4h" or "25m"
+func FormatDuration(d time.Duration) string {
+
+	if d < time.Minute {
+		return `<1m`
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+
+	if days > 0 {
+		return fmt.Sprintf(`%dd %dh`, days, hours)
+	}
+	if hours > 0 {
+		return fmt.Sprintf(`%dh %dm`, hours, minutes)
+	}
+	return fmt.Sprintf(`%dm`, minutes)
+}
+
+// Adds a new ban. A duration of zero makes it permanent.
+func Add(banType BanType, target string, reason string, issuedBy string, duration time.Duration) (Ban, error) {
+
+	target = strings.TrimSpace(target)
+	reason = strings.TrimSpace(reason)
+
+	if reason == `` {
+		return Ban{}, ErrNoReason
+	}
+
+	b := Ban{
+		Type:     banType,
+		Reason:   reason,
+		IssuedBy: issuedBy,
+		IssuedAt: time.Now(),
+	}
+
+	if duration > 0 {
+		b.ExpiresAt = b.IssuedAt.Add(duration)
+	}
+
+	switch banType {
+	case BanAccount:
+		if target == `` {
+			return Ban{}, ErrInvalidTarget
+		}
+		b.Target = strings.ToLower(target)
+	case BanIP:
+		network, normalized, err := parseNetwork(target)
+		if err != nil {
+			return Ban{}, err
+		}
+		b.Target = normalized
+		b.network = network
+	default:
+		return Ban{}, ErrInvalidBanType
+	}
+
+	banLock.Lock()
+	banData.NextBanId++
+	b.BanId = banData.NextBanId
+	banData.Bans = append(banData.Bans, b)
+	banLock.Unlock()
+
+	mudlog.Warn("Ban Added", "banId", b.BanId, "type", b.Type, "target", b.Target, "issuedBy", issuedBy, "expires", b.ExpiresString(), "reason", reason)
+
+	return b, save()
+}
+
+// Lifts a ban early. The ban is kept for the record.
+func Lift(banId int, liftedBy string) (Ban, error) {
+
+	banLock.Lock()
+
+	idx := indexOf(banId)
+	if idx < 0 {
+		banLock.Unlock()
+		return Ban{}, ErrBanNotFound
+	}
+
+	if !banData.Bans[idx].LiftedAt.IsZero() {
+		b := banData.Bans[idx]
+		banLock.Unlock()
+		return b, ErrAlreadyLifted
+	}
+
+	banData.Bans[idx].LiftedBy = liftedBy
+	banData.Bans[idx].LiftedAt = time.Now()
+	b := banData.Bans[idx]
+
+	banLock.Unlock()
+
+	mudlog.Warn("Ban Lifted", "banId", b.BanId, "type", b.Type, "target", b.Target, "liftedBy", liftedBy)
+
+	return b, save()
+}
+
+func Get(banId int) (Ban, bool) {
+	banLock.RLock()
+	defer banLock.RUnlock()
+
+	if idx := indexOf(banId); idx >= 0 {
+		return banData.Bans[idx], true
+	}
+	return Ban{}, false
+}
+
+// Returns bans newest first. Expired and lifted bans are only included if asked for.
+func GetAll(includeInactive bool) []Ban {
+	banLock.RLock()
+	defer banLock.RUnlock()
+
+	result := make([]Ban, 0, len(banData.Bans))
+	for _, b := range banData.Bans {
+		if includeInactive || b.IsActive() {
+			result = append(result, b)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BanId > result[j].BanId
+	})
+
+	return result
+}
+
+// Finds an active ban on an account
+func CheckAccount(username string) (Ban, bool) {
+	username = strings.ToLower(username)
+
+	banLock.RLock()
+	defer banLock.RUnlock()
+
+	for _, b := range banData.Bans {
+		if b.Type == BanAccount && b.Target == username && b.IsActive() {
+			return b, true
+		}
+	}
+	return Ban{}, false
+}
+
+// Finds an active ban covering an address. Accepts anything with an IP in it,
+// such as a *net.TCPAddr or an "ip:port" string.
+func CheckIP(addr net.Addr) (Ban, bool) {
+	if addr == nil {
+		return Ban{}, false
+	}
+	return CheckIPString(addr.String())
+}
+
+func CheckIPString(addr string) (Ban, bool) {
+
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return Ban{}, false
+	}
+
+	banLock.RLock()
+	defer banLock.RUnlock()
+
+	for i := range banData.Bans {
+		if banData.Bans[i].Type == BanIP && banData.Bans[i].IsActive() && banData.Bans[i].matchesIP(ip) {
+			return banData.Bans[i], true
+		}
+	}
+	return Ban{}, false
+}
+
+// Must be called with banLock held
+func indexOf(banId int) int {
+	for i, b := range banData.Bans {
+		if b.BanId == banId {
+			return i
+		}
+	}
+	return -1
+}
+
+func LoadBans() {
+
+	loaded := banFile{}
+
+	if err := datafiles.LoadYaml(BanFile, &loaded); err != nil {
+		mudlog.Error("LoadBans", "error", err.Error())
+		return
+	}
+
+	activeCt := 0
+	for i := range loaded.Bans {
+
+		if loaded.Bans[i].Type == BanIP {
+			network, _, err := parseNetwork(loaded.Bans[i].Target)
+			if err != nil {
+				mudlog.Error("LoadBans", "banId", loaded.Bans[i].BanId, "error", err.Error())
+				continue
+			}
+			loaded.Bans[i].network = network
+		}
+
+		if loaded.Bans[i].BanId > loaded.NextBanId {
+			loaded.NextBanId = loaded.Bans[i].BanId
+		}
+
+		if loaded.Bans[i].IsActive() {
+			activeCt++
+		}
+	}
+
+	banLock.Lock()
+	banData = loaded
+	banLock.Unlock()
+
+	mudlog.Info("LoadBans", "total", len(loaded.Bans), "active", activeCt)
+}
+
+func save() error {
+
+	if err := datafiles.SaveYaml(BanFile, &banData, banLock.RLocker()); err != nil {
+		mudlog.Error("SaveBans", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
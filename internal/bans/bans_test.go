@@ -0,0 +1,185 @@
+package bans
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func setupBanFile(t *testing.T) string {
+	t.Helper()
+
+	dir := datafilestest.TempDir(t)
+
+	banLock.Lock()
+	banData = banFile{}
+	banLock.Unlock()
+
+	return filepath.Join(dir, BanFile)
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{`perm`, 0, false},
+		{`0`, 0, false},
+		{`30m`, 30 * time.Minute, false},
+		{`12h`, 12 * time.Hour, false},
+		{`7d`, 7 * 24 * time.Hour, false},
+		{`2w`, 14 * 24 * time.Hour, false},
+		{`0d`, 0, true},
+		{`-5m`, 0, true},
+		{`soon`, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAdd_Validation(t *testing.T) {
+	setupBanFile(t)
+
+	if _, err := Add(BanIP, `not-an-ip`, `spam`, `admin`, 0); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("bad ip: err = %v; want ErrInvalidTarget", err)
+	}
+	if _, err := Add(BanIP, `10.0.0.0/33`, `spam`, `admin`, 0); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("bad cidr: err = %v; want ErrInvalidTarget", err)
+	}
+	if _, err := Add(BanAccount, `bob`, ` `, `admin`, 0); !errors.Is(err, ErrNoReason) {
+		t.Errorf("no reason: err = %v; want ErrNoReason", err)
+	}
+	if _, err := Add(BanType(`planet`), `earth`, `spam`, `admin`, 0); !errors.Is(err, ErrInvalidBanType) {
+		t.Errorf("bad type: err = %v; want ErrInvalidBanType", err)
+	}
+
+	if len(GetAll(true)) != 0 {
+		t.Errorf("invalid bans were stored")
+	}
+}
+
+func TestCheckIP(t *testing.T) {
+	setupBanFile(t)
+
+	if _, err := Add(BanIP, `203.0.113.7`, `spam`, `admin`, 0); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Add(BanIP, `198.51.100.99/24`, `botnet`, `admin`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Target != `198.51.100.0/24` {
+		t.Errorf("Target = %q; want normalized CIDR", b.Target)
+	}
+
+	tests := []struct {
+		addr   string
+		banned bool
+	}{
+		{`203.0.113.7:4000`, true},
+		{`203.0.113.8:4000`, false},
+		{`198.51.100.1:1234`, true},
+		{`198.51.101.1:1234`, false},
+		{`[::ffff:198.51.100.200]:1234`, true},
+		{`garbage`, false},
+	}
+
+	for _, tt := range tests {
+		if _, banned := CheckIPString(tt.addr); banned != tt.banned {
+			t.Errorf("CheckIPString(%q) = %v; want %v", tt.addr, banned, tt.banned)
+		}
+	}
+
+	if _, banned := CheckIP(&net.TCPAddr{IP: net.ParseIP(`203.0.113.7`), Port: 1}); !banned {
+		t.Errorf("CheckIP(TCPAddr) not banned")
+	}
+}
+
+func TestCheckAccount_ExpiryAndLift(t *testing.T) {
+	setupBanFile(t)
+
+	b, err := Add(BanAccount, `Troll`, `rude`, `admin`, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, banned := CheckAccount(`troll`); !banned {
+		t.Fatalf("account not banned")
+	}
+
+	// Expire it
+	banLock.Lock()
+	banData.Bans[0].ExpiresAt = time.Now().Add(-time.Second)
+	banLock.Unlock()
+
+	if _, banned := CheckAccount(`troll`); banned {
+		t.Errorf("expired ban still active")
+	}
+	if got, _ := Get(b.BanId); got.Status() != `expired` {
+		t.Errorf("Status() = %q; want expired", got.Status())
+	}
+
+	b2, _ := Add(BanAccount, `troll`, `still rude`, `admin`, 0)
+	if _, err := Lift(b2.BanId, `admin2`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Lift(b2.BanId, `admin2`); !errors.Is(err, ErrAlreadyLifted) {
+		t.Errorf("second Lift() err = %v; want ErrAlreadyLifted", err)
+	}
+	if _, err := Lift(999, `admin2`); !errors.Is(err, ErrBanNotFound) {
+		t.Errorf("Lift(999) err = %v; want ErrBanNotFound", err)
+	}
+
+	if _, banned := CheckAccount(`troll`); banned {
+		t.Errorf("lifted ban still active")
+	}
+	if len(GetAll(false)) != 0 || len(GetAll(true)) != 2 {
+		t.Errorf("GetAll() = %d active, %d total; want 0, 2", len(GetAll(false)), len(GetAll(true)))
+	}
+}
+
+func TestLoadBans_RoundTrip(t *testing.T) {
+	path := setupBanFile(t)
+
+	Add(BanIP, `192.0.2.0/28`, `scanner`, `admin`, 0)
+	Add(BanAccount, `bob`, `cheating`, `admin`, 24*time.Hour)
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("ban file not written: %v", err)
+	}
+
+	banLock.Lock()
+	banData = banFile{}
+	banLock.Unlock()
+
+	LoadBans()
+
+	if _, banned := CheckIPString(`192.0.2.5`); !banned {
+		t.Errorf("ip ban not restored")
+	}
+	if b, banned := CheckAccount(`bob`); !banned || b.Reason != `cheating` || b.IsPermanent() {
+		t.Errorf("account ban not restored: %+v", b)
+	}
+
+	// Ids keep counting up after a reload
+	if b, _ := Add(BanAccount, `carol`, `spam`, `admin`, 0); b.BanId != 3 {
+		t.Errorf("BanId = %d; want 3", b.BanId)
+	}
+}
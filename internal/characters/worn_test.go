@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -254,3 +255,24 @@ func TestGetAllSlotTypes(t *testing.T) {
 	got := GetAllSlotTypes()
 	assert.Equal(t, expected, got, "GetAllSlotTypes should return all slot types in correct order")
 }
+
+func TestWorn_UpdateItem(t *testing.T) {
+
+	ring := items.Item{ItemId: 8, UUID: uuid.UUID{1}, Uses: 3}
+	w := Worn{
+		Weapon: items.Item{ItemId: 1, UUID: uuid.UUID{2}},
+		Ring:   ring,
+	}
+
+	updated := ring
+	updated.Uses = 2
+
+	assert.True(t, w.UpdateItem(ring, updated))
+	assert.Equal(t, 2, w.Ring.Uses)
+	assert.Equal(t, 1, w.Weapon.ItemId)
+
+	// Another ring of the same kind isn't the one being worn
+	other := items.Item{ItemId: 8, UUID: uuid.UUID{3}}
+	assert.False(t, w.UpdateItem(other, other))
+	assert.Equal(t, 2, w.Ring.Uses)
+}
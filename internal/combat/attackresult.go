@@ -14,6 +14,13 @@ type AttackResult struct {
 	MessagesToSourceRoom    []string
 	MessagesToTargetRoom    []string
 	MessagesToRoomOld       []string
+	Cancelled               bool // Set if something (such as a script) stopped the attack
+}
+
+// Stops the attack from doing anything.
+// Its damage, buffs and any messages queued so far are all dropped.
+func (a *AttackResult) Cancel() {
+	*a = AttackResult{Cancelled: true}
 }
 
 func (a *AttackResult) SendToSource(msg string) {
@@ -27,10 +27,27 @@ const (
 	Mob  SourceTarget = "mob"
 )
 
+// Gets each attack after it's worked out, but before any of its damage is done,
+// so it can change or cancel it. Only one of each userId/mobInstanceId pair is set.
+type AttackHandler func(sourceUserId int, sourceMobInstanceId int, targetUserId int, targetMobInstanceId int, attackResult *AttackResult)
+
+var attackHandler AttackHandler
+
+func SetAttackHandler(h AttackHandler) {
+	attackHandler = h
+}
+
+func handleAttack(sourceUserId int, sourceMobInstanceId int, targetUserId int, targetMobInstanceId int, attackResult *AttackResult) {
+	if attackHandler != nil {
+		attackHandler(sourceUserId, sourceMobInstanceId, targetUserId, targetMobInstanceId, attackResult)
+	}
+}
+
 // Performs a combat round from a player to a mob
 func AttackPlayerVsMob(user *users.UserRecord, mob *mobs.Mob) AttackResult {
 
 	attackResult := calculateCombat(*user.Character, mob.Character, User, Mob)
+	handleAttack(user.UserId, 0, 0, mob.InstanceId, &attackResult)
 
 	if attackResult.DamageToSource != 0 {
 		user.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
@@ -55,6 +72,7 @@ func AttackPlayerVsMob(user *users.UserRecord, mob *mobs.Mob) AttackResult {
 func AttackPlayerVsPlayer(userAtk *users.UserRecord, userDef *users.UserRecord) AttackResult {
 
 	attackResult := calculateCombat(*userAtk.Character, *userDef.Character, User, User)
+	handleAttack(userAtk.UserId, 0, userDef.UserId, 0, &attackResult)
 
 	if attackResult.DamageToSource != 0 {
 		userAtk.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
@@ -80,6 +98,7 @@ func AttackPlayerVsPlayer(userAtk *users.UserRecord, userDef *users.UserRecord)
 func AttackMobVsPlayer(mob *mobs.Mob, user *users.UserRecord) AttackResult {
 
 	attackResult := calculateCombat(mob.Character, *user.Character, Mob, User)
+	handleAttack(0, mob.InstanceId, user.UserId, 0, &attackResult)
 
 	mob.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 
@@ -99,6 +118,7 @@ func AttackMobVsPlayer(mob *mobs.Mob, user *users.UserRecord) AttackResult {
 func AttackMobVsMob(mobAtk *mobs.Mob, mobDef *mobs.Mob) AttackResult {
 
 	attackResult := calculateCombat(mobAtk.Character, mobDef.Character, Mob, User)
+	handleAttack(0, mobAtk.InstanceId, 0, mobDef.InstanceId, &attackResult)
 
 	mobAtk.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 	mobDef.Character.ApplyHealthChange(attackResult.DamageToTarget * -1)
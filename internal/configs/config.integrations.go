@@ -2,16 +2,26 @@ package configs
 
 type Integrations struct {
 	Discord IntegrationsDiscord `yaml:"Discord"`
+	MSSP    IntegrationsMSSP    `yaml:"MSSP"`
 }
 
 type IntegrationsDiscord struct {
 	WebhookUrl ConfigSecret `yaml:"WebhookUrl" env:"DISCORD_WEBHOOK_URL"` // Optional Discord URL to post updates to
 }
 
+type IntegrationsMSSP struct {
+	Enabled ConfigBool              `yaml:"Enabled"` // Whether to answer MSSP requests from MUD listing crawlers
+	Fields  map[string]ConfigString `yaml:"Fields"`  // Extra MSSP variables to report, such as CONTACT or WEBSITE
+}
+
 func (i *Integrations) Validate() {
 
 	// Ignore Discord
 
+	if i.MSSP.Fields == nil {
+		i.MSSP.Fields = map[string]ConfigString{}
+	}
+
 }
 
 func GetIntegrationsConfig() Integrations {
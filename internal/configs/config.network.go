@@ -3,6 +3,7 @@ package configs
 type Network struct {
 	MaxTelnetConnections ConfigInt         `yaml:"MaxTelnetConnections"` // Maximum number of telnet connections to accept
 	TelnetPort           ConfigSliceString `yaml:"TelnetPort"`           // One or more Ports used to accept telnet connections
+	TelnetTlsPort        ConfigInt         `yaml:"TelnetTlsPort"`        // Port used to accept telnet connections over TLS (uses HttpsCertFile/HttpsKeyFile)
 	LocalPort            ConfigInt         `yaml:"LocalPort"`            // Port used for admin connections, localhost only
 	HttpPort             ConfigInt         `yaml:"HttpPort"`             // Port used for web requests
 	HttpsPort            ConfigInt         `yaml:"HttpsPort"`            // Port used for web https requests
@@ -12,6 +13,14 @@ type Network struct {
 	TimeoutMods          ConfigBool        `yaml:"TimeoutMods"`          // Whether to kick admin/mods when idle too long.
 	ZombieSeconds        ConfigInt         `yaml:"ZombieSeconds"`        // How many seconds a player will be a zombie allowing them to reconnect.
 	LogoutRounds         ConfigInt         `yaml:"LogoutRounds"`         // How many rounds of uninterrupted meditation must be completed to log out.
+	MCCPEnabled          ConfigBool        `yaml:"MCCPEnabled"`          // Whether to offer MCCP2 (zlib) compression to telnet clients
+	MXPEnabled           ConfigBool        `yaml:"MXPEnabled"`           // Whether to offer MXP (clickable links) to telnet clients
+	ProxyProtocol        ConfigBool        `yaml:"ProxyProtocol"`        // Whether to read PROXY protocol v1/v2 headers on telnet ports (from TrustedProxies only)
+	TrustedProxies       ConfigSliceString `yaml:"TrustedProxies"`       // IPs or CIDR ranges of proxies/load balancers allowed to report the real client address
+	ApiRateLimit         ConfigInt         `yaml:"ApiRateLimit"`         // How many /api/v1 requests a token may make per minute
+	AdminSessionMinutes  ConfigInt         `yaml:"AdminSessionMinutes"`  // How long a web admin login lasts
+	MetricsEnabled       ConfigBool        `yaml:"MetricsEnabled"`       // Whether to serve Prometheus metrics at /metrics
+	MetricsToken         ConfigSecret      `yaml:"MetricsToken"`         // If set, /metrics requires "Authorization: Bearer <token>"
 }
 
 func (n *Network) Validate() {
@@ -19,11 +28,19 @@ func (n *Network) Validate() {
 	// Ignore TelnetPort
 	// Ignore LocalPort
 	// Ignore TimeoutMods
+	// Ignore MCCPEnabled
+	// Ignore MXPEnabled
+	// Ignore ProxyProtocol
+	// Ignore TrustedProxies
 
 	if n.MaxTelnetConnections < 1 {
 		n.MaxTelnetConnections = 50 // default
 	}
 
+	if n.TelnetTlsPort < 0 {
+		n.TelnetTlsPort = 0 // default
+	}
+
 	if n.HttpPort < 0 {
 		n.HttpPort = 0 // default
 	}
@@ -48,6 +65,14 @@ func (n *Network) Validate() {
 		n.LogoutRounds = 0 // default
 	}
 
+	if n.ApiRateLimit < 1 {
+		n.ApiRateLimit = 60 // default
+	}
+
+	if n.AdminSessionMinutes < 1 {
+		n.AdminSessionMinutes = 120 // default
+	}
+
 }
 
 func GetNetworkConfig() Network {
@@ -6,8 +6,9 @@ type Timing struct {
 	TurnMs            ConfigInt `yaml:"TurnMs"`
 	RoundSeconds      ConfigInt `yaml:"RoundSeconds"`
 	RoundsPerAutoSave ConfigInt `yaml:"RoundsPerAutoSave"`
-	RoundsPerDay      ConfigInt `yaml:"RoundsPerDay"` // How many rounds are in a day
-	NightHours        ConfigInt `yaml:"NightHours"`   // How many hours of night
+	RoundsPerDay      ConfigInt `yaml:"RoundsPerDay"`   // How many rounds are in a day
+	NightHours        ConfigInt `yaml:"NightHours"`     // How many hours of night
+	SlowListenerMs    ConfigInt `yaml:"SlowListenerMs"` // Log a warning when one event listener takes longer than this (0 = off)
 
 	// Protected values
 	turnsPerRound   int     // calculated and cached when data is validated.
@@ -34,6 +35,10 @@ func (e *Timing) Validate() {
 		e.RoundsPerDay = 20 // default of 24 hours worth of rounds
 	}
 
+	if e.SlowListenerMs < 0 {
+		e.SlowListenerMs = 0 // off
+	}
+
 	if e.NightHours < 0 {
 		e.NightHours = 0
 	} else if e.NightHours > 24 {
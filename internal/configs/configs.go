@@ -3,6 +3,7 @@ package configs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
 	"strings"
@@ -169,6 +170,27 @@ func (c *Config) buildDotPaths(v reflect.Value, prefix string, result map[string
 	}
 }
 
+// Copies the current config and overrides, and returns a function that puts them back.
+// Lets tests change settings without leaving them changed: t.Cleanup(configs.Snapshot())
+func Snapshot() (restore func()) {
+
+	configDataLock.RLock()
+	savedConfig := configData
+	savedOverrides := maps.Clone(overrides)
+	savedKeyLookups := maps.Clone(keyLookups)
+	savedTypeLookups := maps.Clone(typeLookups)
+	configDataLock.RUnlock()
+
+	return func() {
+		configDataLock.Lock()
+		configData = savedConfig
+		overrides = savedOverrides
+		keyLookups = savedKeyLookups
+		typeLookups = savedTypeLookups
+		configDataLock.Unlock()
+	}
+}
+
 func GetOverrides() map[string]any {
 	return overrides
 }
@@ -205,3 +205,27 @@ func TestConfigSliceStringString(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshot(t *testing.T) {
+
+	before := GetFilePathsConfig().DataFiles
+	_, hadOverride := GetOverrides()[`FilePaths.DataFiles`]
+
+	restore := Snapshot()
+
+	if err := AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: `/tmp/snapshot-test`}); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetFilePathsConfig().DataFiles; got != `/tmp/snapshot-test` {
+		t.Fatalf("DataFiles = %q after override", got)
+	}
+
+	restore()
+
+	if got := GetFilePathsConfig().DataFiles; got != before {
+		t.Errorf("DataFiles = %q after restore, want %q", got, before)
+	}
+	if _, ok := GetOverrides()[`FilePaths.DataFiles`]; ok != hadOverride {
+		t.Errorf("override still set after restore")
+	}
+}
@@ -10,14 +10,20 @@ const (
 type ClientSettings struct {
 	Display DisplaySettings
 	// Is MSP enabled?
-	MSPEnabled        bool // Do they accept sound in their client?
-	SendTelnetGoAhead bool // Defaults false, should we send a IAC GA after prompts?
+	MSPEnabled        bool   // Do they accept sound in their client?
+	MXPEnabled        bool   // Do they parse MXP tags (clickable links)?
+	SendTelnetGoAhead bool   // Defaults false, should we send a IAC GA after prompts?
+	Charset           string // Charset the client accepted via telnet CHARSET negotiation, if any
 }
 
 func (c ClientSettings) IsMsp() bool {
 	return c.MSPEnabled
 }
 
+func (c ClientSettings) IsMxp() bool {
+	return c.MXPEnabled
+}
+
 type DisplaySettings struct {
 	ScreenWidth  uint32
 	ScreenHeight uint32
@@ -1,8 +1,11 @@
 package connections
 
 import (
+	"compress/zlib"
+	"crypto/tls"
 	"errors"
 	"net"
+	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -13,6 +16,10 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+var (
+	ErrNotTransferable = errors.New("connection can't be handed to another process")
+)
+
 type ConnectState uint32
 
 const (
@@ -124,22 +131,21 @@ type ConnectionDetails struct {
 	inputDisabled     bool
 	clientSettings    ClientSettings
 	heartbeat         *heartbeatManager
+	mccpLock          sync.Mutex
+	mccp              *zlib.Writer // MCCP2 compressor, nil until the client agrees to compression
+	mccpStats         CompressionStats
+	remoteAddr        net.Addr // Real client address when behind a trusted proxy, nil otherwise
 }
 
 func (cd *ConnectionDetails) IsLocal() bool {
 
-	remoteAddrStr := ``
-
-	if cd.wsConn == nil {
-		// Unix sockets are always local
-		if _, ok := cd.conn.(*net.UnixConn); ok {
-			return true
-		}
-		remoteAddrStr = cd.conn.RemoteAddr().String()
-	} else {
-		remoteAddrStr = cd.wsConn.RemoteAddr().String()
+	// Unix sockets are always local
+	if _, ok := cd.conn.(*net.UnixConn); ok && cd.remoteAddr == nil {
+		return true
 	}
 
+	remoteAddrStr := cd.RemoteAddr().String()
+
 	host, _, err := net.SplitHostPort(remoteAddrStr)
 	if err != nil {
 		// e.g. not “host:port” syntax
@@ -153,6 +159,12 @@ func (cd *ConnectionDetails) IsLocal() bool {
 	return ip.IsLoopback()
 }
 
+// Whether this is a telnet connection over TLS
+func (cd *ConnectionDetails) IsTLS() bool {
+	_, ok := cd.conn.(*tls.Conn)
+	return ok
+}
+
 func (cd *ConnectionDetails) IsWebSocket() bool {
 	return cd.wsConn != nil
 }
@@ -241,6 +253,10 @@ func (cd *ConnectionDetails) Write(p []byte) (n int, err error) {
 		return len(p), nil
 	}
 
+	if n, handled, err := cd.writeCompressed(p); handled {
+		return n, err
+	}
+
 	return cd.conn.Write(p)
 }
 
@@ -268,16 +284,58 @@ func (cd *ConnectionDetails) Close() {
 		cd.wsConn.Close()
 		return
 	}
+
+	// Finish the zlib stream so the client sees a clean end of compression
+	cd.StopCompression()
+
 	cd.conn.Close()
 }
 
+// Returns the real client address, which is the proxy reported address
+// for connections that came through a trusted proxy.
 func (cd *ConnectionDetails) RemoteAddr() net.Addr {
+	if cd.remoteAddr != nil {
+		return cd.remoteAddr
+	}
+	if cd.wsConn != nil {
+		return cd.wsConn.RemoteAddr()
+	}
+	return cd.conn.RemoteAddr()
+}
+
+// Records the client address reported by a trusted proxy (PROXY protocol or X-Forwarded-For)
+func (cd *ConnectionDetails) SetRemoteAddr(addr net.Addr) {
+	cd.remoteAddr = addr
+}
+
+// Returns the address of the immediate peer, which is the proxy itself for proxied connections
+func (cd *ConnectionDetails) PeerAddr() net.Addr {
 	if cd.wsConn != nil {
 		return cd.wsConn.RemoteAddr()
 	}
 	return cd.conn.RemoteAddr()
 }
 
+// Returns a duplicate of the underlying socket so it can be handed to another process (copyover).
+// TLS and websocket connections carry state that can't be handed over.
+func (cd *ConnectionDetails) File() (*os.File, error) {
+
+	if cd.wsConn != nil {
+		return nil, ErrNotTransferable
+	}
+
+	conn := cd.conn
+	if p, ok := conn.(*proxiedConn); ok {
+		conn = p.Conn
+	}
+
+	if fc, ok := conn.(interface{ File() (*os.File, error) }); ok {
+		return fc.File()
+	}
+
+	return nil, ErrNotTransferable
+}
+
 // get for uniqueId
 func (cd *ConnectionDetails) ConnectionId() ConnectionId {
 	return ConnectionId(atomic.LoadUint64((*uint64)(&cd.connectionId)))
@@ -2,6 +2,7 @@ package connections
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"os"
 	"sync"
@@ -61,6 +62,34 @@ func Add(conn net.Conn, wsConn *websocket.Conn) *ConnectionDetails {
 	return connDetails
 }
 
+// Re-registers a connection inherited from a copyover under its original id,
+// so anything tracking connection ids (users, plugins) still lines up.
+// Refuses an id that's already in use rather than replacing that connection.
+func Restore(id ConnectionId, conn net.Conn) (*ConnectionDetails, error) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, ok := netConnections[id]; ok {
+		return nil, fmt.Errorf("connection id %d is already in use", id)
+	}
+
+	if id > connectCounter {
+		connectCounter = id
+	}
+
+	connDetails := NewConnectionDetails(
+		id,
+		conn,
+		nil,
+		nil,
+	)
+
+	netConnections[connDetails.ConnectionId()] = connDetails
+
+	return connDetails, nil
+}
+
 // Returns the total number of connections
 func Get(id ConnectionId) *ConnectionDetails {
 	lock.Lock()
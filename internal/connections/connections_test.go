@@ -0,0 +1,35 @@
+package connections
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestore(t *testing.T) {
+
+	first, firstClient := net.Pipe()
+	defer firstClient.Close()
+	second, secondClient := net.Pipe()
+	defer secondClient.Close()
+	defer second.Close()
+
+	cd, err := Restore(500, first)
+	require.NoError(t, err)
+	defer Remove(500)
+
+	assert.Equal(t, ConnectionId(500), cd.ConnectionId())
+	assert.Same(t, cd, Get(500))
+
+	// The id is taken, so the first connection is kept
+	_, err = Restore(500, second)
+	assert.Error(t, err)
+	assert.Same(t, cd, Get(500))
+
+	// New connections are numbered after it
+	lock.Lock()
+	assert.GreaterOrEqual(t, connectCounter, ConnectionId(500))
+	lock.Unlock()
+}
@@ -0,0 +1,158 @@
+package connections
+
+import (
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/GoMudEngine/GoMud/internal/term"
+)
+
+var (
+	ErrCompressionActive      = errors.New("compression already active")
+	ErrCompressionUnsupported = errors.New("compression is not supported on this connection")
+)
+
+// Compression stats for a single connection.
+type CompressionStats struct {
+	Enabled         bool   // Is MCCP2 currently active?
+	BytesIn         uint64 // Bytes handed to the compressor
+	BytesOut        uint64 // Bytes actually written to the socket after compression
+	CompressedSends uint64 // How many writes have gone through the compressor
+}
+
+// Returns the percentage of bytes saved by compression
+func (s CompressionStats) Savings() float64 {
+	if s.BytesIn == 0 || s.BytesOut >= s.BytesIn {
+		return 0
+	}
+	return 100 - (float64(s.BytesOut)/float64(s.BytesIn))*100
+}
+
+// Counts the bytes that make it to the underlying writer
+// Only ever written to while mccpLock is held
+type countingWriter struct {
+	w     io.Writer
+	count *uint64
+}
+
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.count += uint64(n)
+	return n, err
+}
+
+// Sends the MCCP2 start sequence (uncompressed) and then
+// switches all further output to a zlib stream.
+func (cd *ConnectionDetails) StartCompression() error {
+
+	if cd.wsConn != nil {
+		return ErrCompressionUnsupported
+	}
+
+	cd.mccpLock.Lock()
+	defer cd.mccpLock.Unlock()
+
+	if cd.mccp != nil {
+		return ErrCompressionActive
+	}
+
+	if _, err := cd.conn.Write(term.MccpStart.BytesWithPayload(nil)); err != nil {
+		return err
+	}
+
+	cd.mccp = zlib.NewWriter(countingWriter{w: cd.conn, count: &cd.mccpStats.BytesOut})
+	cd.mccpStats.Enabled = true
+
+	return nil
+}
+
+// Finishes the zlib stream, after which output is sent uncompressed again.
+func (cd *ConnectionDetails) StopCompression() error {
+
+	cd.mccpLock.Lock()
+	defer cd.mccpLock.Unlock()
+
+	if cd.mccp == nil {
+		return nil
+	}
+
+	err := cd.mccp.Close()
+	cd.mccp = nil
+	cd.mccpStats.Enabled = false
+
+	return err
+}
+
+func (cd *ConnectionDetails) IsCompressed() bool {
+	cd.mccpLock.Lock()
+	defer cd.mccpLock.Unlock()
+
+	return cd.mccp != nil
+}
+
+func (cd *ConnectionDetails) CompressionStats() CompressionStats {
+	cd.mccpLock.Lock()
+	defer cd.mccpLock.Unlock()
+
+	return cd.mccpStats
+}
+
+// Writes through the compressor if it is active.
+// handled is false if compression is not active and the caller should write normally.
+func (cd *ConnectionDetails) writeCompressed(p []byte) (n int, handled bool, err error) {
+
+	cd.mccpLock.Lock()
+	defer cd.mccpLock.Unlock()
+
+	if cd.mccp == nil {
+		return 0, false, nil
+	}
+
+	if n, err = cd.mccp.Write(p); err != nil {
+		return n, true, err
+	}
+
+	// Sync flush so the client can decompress everything written so far
+	if err = cd.mccp.Flush(); err != nil {
+		return n, true, err
+	}
+
+	cd.mccpStats.BytesIn += uint64(n)
+	cd.mccpStats.CompressedSends++
+
+	return n, true, nil
+}
+
+func StartCompression(id ConnectionId) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if cd, ok := netConnections[id]; ok {
+		return cd.StartCompression()
+	}
+
+	return errors.New("connection not found")
+}
+
+func StopCompression(id ConnectionId) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if cd, ok := netConnections[id]; ok {
+		return cd.StopCompression()
+	}
+
+	return errors.New("connection not found")
+}
+
+func GetCompressionStats(id ConnectionId) CompressionStats {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if cd, ok := netConnections[id]; ok {
+		return cd.CompressionStats()
+	}
+
+	return CompressionStats{}
+}
@@ -0,0 +1,54 @@
+package connections
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	cd := NewConnectionDetails(1, server, nil, nil)
+
+	received := make(chan []byte, 1)
+	go func() {
+		b, _ := io.ReadAll(client)
+		received <- b
+	}()
+
+	require.NoError(t, cd.StartCompression())
+	assert.ErrorIs(t, cd.StartCompression(), ErrCompressionActive)
+
+	msg := bytes.Repeat([]byte("A long room description that repeats itself. "), 50)
+	_, err := cd.Write(msg)
+	require.NoError(t, err)
+
+	cd.Close()
+
+	raw := <-received
+
+	startSeq := term.MccpStart.BytesWithPayload(nil)
+	require.True(t, bytes.HasPrefix(raw, startSeq))
+
+	zr, err := zlib.NewReader(bytes.NewReader(raw[len(startSeq):]))
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	assert.Equal(t, msg, decompressed)
+
+	stats := cd.CompressionStats()
+	assert.False(t, stats.Enabled)
+	assert.Equal(t, uint64(len(msg)), stats.BytesIn)
+	assert.Less(t, stats.BytesOut, stats.BytesIn)
+	assert.Greater(t, stats.Savings(), float64(0))
+}
@@ -0,0 +1,34 @@
+package connections
+
+import "github.com/GoMudEngine/GoMud/internal/metrics"
+
+func collectMetrics() []metrics.Metric {
+
+	lock.RLock()
+	telnetCt, websocketCt := 0, 0
+	for _, cd := range netConnections {
+		if cd.IsWebSocket() {
+			websocketCt++
+		} else {
+			telnetCt++
+		}
+	}
+	connectCt, disconnectCt := connectCounter, disconnectCounter
+	lock.RUnlock()
+
+	active := metrics.NewGauge(`gomud_connections_active`, `Open connections, by kind.`)
+	active.Add(float64(telnetCt), `kind`, `telnet`)
+	active.Add(float64(websocketCt), `kind`, `websocket`)
+
+	connects := metrics.NewCounter(`gomud_connections_total`, `Connections accepted since startup.`)
+	connects.Add(float64(connectCt))
+
+	disconnects := metrics.NewCounter(`gomud_disconnections_total`, `Connections dropped since startup.`)
+	disconnects.Add(float64(disconnectCt))
+
+	return []metrics.Metric{active, connects, disconnects}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -0,0 +1,212 @@
+package connections
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+PROXY protocol
+https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt
+
+Load balancers prepend a header to the stream describing the real client:
+
+	v1 (text):   PROXY TCP4 203.0.113.7 10.0.0.2 51234 33333\r\n
+	v2 (binary): 12 byte signature, version/command, family, length, addresses
+
+The header is only trusted when the connection comes from a configured proxy.
+*/
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	ErrProxyHeaderInvalid = errors.New("invalid PROXY protocol header")
+)
+
+const (
+	proxyV1MaxLength = 107 // Longest possible v1 header, including the CRLF
+	proxyV2HeaderLen = 16  // Signature + version/command + family + length
+)
+
+// Wraps a net.Conn so bytes read past the PROXY header aren't lost
+type proxiedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (p *proxiedConn) Read(b []byte) (int, error) {
+	return p.reader.Read(b)
+}
+
+// ReadProxyHeader reads an optional PROXY protocol v1/v2 header from the start of conn.
+// The returned net.Conn must be used in place of conn from then on.
+// addr is nil if no header was sent, or the header did not carry an address (LOCAL/UNKNOWN).
+// Clients that connect directly never send anything first, so wait at most timeout for a header.
+func ReadProxyHeader(conn net.Conn, timeout time.Duration) (wrapped net.Conn, addr net.Addr, err error) {
+
+	reader := bufio.NewReaderSize(conn, 256)
+	wrapped = &proxiedConn{Conn: conn, reader: reader}
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	first, err := reader.Peek(1)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return wrapped, nil, nil
+		}
+		return wrapped, nil, err
+	}
+
+	switch first[0] {
+	case proxyV1Prefix[0]:
+		if peek, err := reader.Peek(len(proxyV1Prefix)); err != nil || !bytes.Equal(peek, proxyV1Prefix) {
+			return wrapped, nil, nil
+		}
+		addr, err = readProxyV1(reader)
+		return wrapped, addr, err
+	case proxyV2Signature[0]:
+		if peek, err := reader.Peek(len(proxyV2Signature)); err != nil || !bytes.Equal(peek, proxyV2Signature) {
+			return wrapped, nil, nil
+		}
+		addr, err = readProxyV2(reader)
+		return wrapped, addr, err
+	}
+
+	return wrapped, nil, nil
+}
+
+func readProxyV1(reader *bufio.Reader) (net.Addr, error) {
+
+	line := make([]byte, 0, proxyV1MaxLength)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= proxyV1MaxLength {
+			return nil, fmt.Errorf("%w: v1 header too long", ErrProxyHeaderInvalid)
+		}
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("%w: v1 header missing CRLF", ErrProxyHeaderInvalid)
+	}
+
+	parts := strings.Split(string(line[:len(line)-2]), ` `)
+	if len(parts) >= 2 && parts[1] == `UNKNOWN` {
+		return nil, nil
+	}
+
+	if len(parts) != 6 || (parts[1] != `TCP4` && parts[1] != `TCP6`) {
+		return nil, fmt.Errorf("%w: v1 header %q", ErrProxyHeaderInvalid, string(line))
+	}
+
+	ip := net.ParseIP(parts[2])
+	if ip == nil {
+		return nil, fmt.Errorf("%w: v1 source address %q", ErrProxyHeaderInvalid, parts[2])
+	}
+
+	port, err := strconv.Atoi(parts[4])
+	if err != nil || port < 0 || port > 65535 {
+		return nil, fmt.Errorf("%w: v1 source port %q", ErrProxyHeaderInvalid, parts[4])
+	}
+
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
+
+func readProxyV2(reader *bufio.Reader) (net.Addr, error) {
+
+	header := make([]byte, proxyV2HeaderLen)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+
+	if header[12]>>4 != 2 {
+		return nil, fmt.Errorf("%w: v2 version %d", ErrProxyHeaderInvalid, header[12]>>4)
+	}
+
+	command := header[12] & 0x0F
+	family := header[13]
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, err
+	}
+
+	// LOCAL: health checks etc. from the proxy itself
+	if command == 0x0 {
+		return nil, nil
+	}
+
+	if command != 0x1 {
+		return nil, fmt.Errorf("%w: v2 command %d", ErrProxyHeaderInvalid, command)
+	}
+
+	switch family >> 4 {
+	case 0x1: // AF_INET
+		if len(payload) < 12 {
+			return nil, fmt.Errorf("%w: v2 short IPv4 address block", ErrProxyHeaderInvalid)
+		}
+		return &net.TCPAddr{IP: net.IP(payload[0:4]), Port: int(binary.BigEndian.Uint16(payload[8:10]))}, nil
+	case 0x2: // AF_INET6
+		if len(payload) < 36 {
+			return nil, fmt.Errorf("%w: v2 short IPv6 address block", ErrProxyHeaderInvalid)
+		}
+		return &net.TCPAddr{IP: net.IP(payload[0:16]), Port: int(binary.BigEndian.Uint16(payload[32:34]))}, nil
+	}
+
+	// AF_UNSPEC / AF_UNIX carry nothing useful to us
+	return nil, nil
+}
+
+// IsTrustedProxy returns true if addr matches any of the IPs or CIDR ranges in trusted
+func IsTrustedProxy(addr net.Addr, trusted []string) bool {
+
+	if len(trusted) == 0 || addr == nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+
+	return IsTrustedProxyIP(net.ParseIP(host), trusted)
+}
+
+// IsTrustedProxyIP returns true if ip matches any of the IPs or CIDR ranges in trusted
+func IsTrustedProxyIP(ip net.IP, trusted []string) bool {
+
+	if ip == nil {
+		return false
+	}
+
+	for _, entry := range trusted {
+		if strings.Contains(entry, `/`) {
+			if _, ipNet, err := net.ParseCIDR(entry); err == nil && ipNet.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if trustedIP := net.ParseIP(entry); trustedIP != nil && trustedIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
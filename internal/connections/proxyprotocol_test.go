@@ -0,0 +1,110 @@
+package connections
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Sends header+payload down one end of a pipe and runs ReadProxyHeader on the other
+func readProxyTest(t *testing.T, header []byte, payload string) (net.Addr, string, error) {
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		if len(header) > 0 {
+			client.Write(header)
+		}
+		client.Write([]byte(payload))
+	}()
+
+	conn, addr, err := ReadProxyHeader(server, 200*time.Millisecond)
+	if err != nil {
+		return nil, ``, err
+	}
+
+	buf := make([]byte, len(payload))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+
+	return addr, string(buf), nil
+}
+
+func TestReadProxyHeaderV1(t *testing.T) {
+
+	addr, rest, err := readProxyTest(t, []byte("PROXY TCP4 203.0.113.7 10.0.0.2 51234 33333\r\n"), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7:51234", addr.String())
+	assert.Equal(t, "hello", rest)
+
+	addr, _, err = readProxyTest(t, []byte("PROXY TCP6 2001:db8::1 2001:db8::2 4000 33333\r\n"), "x")
+	require.NoError(t, err)
+	assert.Equal(t, "[2001:db8::1]:4000", addr.String())
+
+	addr, _, err = readProxyTest(t, []byte("PROXY UNKNOWN\r\n"), "x")
+	require.NoError(t, err)
+	assert.Nil(t, addr)
+
+	_, _, err = readProxyTest(t, []byte("PROXY TCP4 nope 10.0.0.2 1 2\r\n"), "x")
+	assert.ErrorIs(t, err, ErrProxyHeaderInvalid)
+}
+
+func TestReadProxyHeaderV2(t *testing.T) {
+
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, 0x21, 0x11) // v2 PROXY, TCP over IPv4
+	header = binary.BigEndian.AppendUint16(header, 12)
+	header = append(header, 198, 51, 100, 9) // src
+	header = append(header, 10, 0, 0, 2)     // dst
+	header = binary.BigEndian.AppendUint16(header, 40000)
+	header = binary.BigEndian.AppendUint16(header, 33333)
+
+	addr, rest, err := readProxyTest(t, header, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.9:40000", addr.String())
+	assert.Equal(t, "hello", rest)
+
+	// LOCAL command (health checks) has no address
+	local := append([]byte{}, proxyV2Signature...)
+	local = append(local, 0x20, 0x00, 0x00, 0x00)
+
+	addr, _, err = readProxyTest(t, local, "x")
+	require.NoError(t, err)
+	assert.Nil(t, addr)
+}
+
+func TestReadProxyHeaderNone(t *testing.T) {
+
+	// Data that isn't a header is passed through untouched
+	addr, rest, err := readProxyTest(t, nil, "PROXIMITY")
+	require.NoError(t, err)
+	assert.Nil(t, addr)
+	assert.Equal(t, "PROXIMITY", rest)
+
+	// Client that waits for the server to talk first
+	server, client := net.Pipe()
+	defer client.Close()
+
+	start := time.Now()
+	_, addr, err = ReadProxyHeader(server, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Nil(t, addr)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestIsTrustedProxy(t *testing.T) {
+
+	trusted := []string{`127.0.0.1`, `10.0.0.0/8`, `2001:db8::/32`}
+
+	assert.True(t, IsTrustedProxy(&net.TCPAddr{IP: net.ParseIP(`127.0.0.1`), Port: 5000}, trusted))
+	assert.True(t, IsTrustedProxy(&net.TCPAddr{IP: net.ParseIP(`10.20.30.40`), Port: 5000}, trusted))
+	assert.True(t, IsTrustedProxy(&net.TCPAddr{IP: net.ParseIP(`2001:db8::5`), Port: 5000}, trusted))
+	assert.False(t, IsTrustedProxy(&net.TCPAddr{IP: net.ParseIP(`192.168.1.1`), Port: 5000}, trusted))
+	assert.False(t, IsTrustedProxy(&net.TCPAddr{IP: net.ParseIP(`127.0.0.1`), Port: 5000}, nil))
+}
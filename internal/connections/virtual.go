@@ -0,0 +1,35 @@
+package connections
+
+import (
+	"io"
+	"net"
+	"time"
+)
+
+//
+// Connections without a socket, for journal replays and world tests.
+// Everything sent to them goes to a writer, and they never have any input.
+//
+
+// Adds a connection that writes everything it's sent to w
+func AddVirtual(w io.Writer) *ConnectionDetails {
+	return Add(&virtualConn{w: w}, nil)
+}
+
+type virtualConn struct {
+	w io.Writer
+}
+
+func (c *virtualConn) Read(b []byte) (int, error)         { return 0, io.EOF }
+func (c *virtualConn) Write(b []byte) (int, error)        { return c.w.Write(b) }
+func (c *virtualConn) Close() error                       { return nil }
+func (c *virtualConn) LocalAddr() net.Addr                { return virtualAddr{} }
+func (c *virtualConn) RemoteAddr() net.Addr               { return virtualAddr{} }
+func (c *virtualConn) SetDeadline(t time.Time) error      { return nil }
+func (c *virtualConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *virtualConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type virtualAddr struct{}
+
+func (virtualAddr) Network() string { return `virtual` }
+func (virtualAddr) String() string  { return `virtual` }
@@ -0,0 +1,218 @@
+package copyover
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+Copyover (hot reboot)
+
+The running server saves the world, writes a state file, and replaces itself
+with a freshly exec'd copy of its binary. Listening sockets and live telnet
+connections are inherited as open file descriptors, so players stay connected.
+
+	old process: State.AddListener() / State.AddConnection() -> State.Exec()
+	new process: Load() -> State.Listener() / ConnectionState.Conn()
+*/
+
+// Environment variable used to hand the state file path to the new process
+const EnvStateFile = `COPYOVER_STATE`
+
+var (
+	ErrUnsupported = errors.New("copyover is not supported on this platform")
+)
+
+// Anything that can hand over a duplicate of its underlying socket
+// net.TCPListener, net.TCPConn and connections.ConnectionDetails all qualify.
+type Filer interface {
+	File() (*os.File, error)
+}
+
+type State struct {
+	Listeners   []ListenerState
+	Connections []ConnectionState
+
+	files []*os.File // Duplicated descriptors, kept open until exec
+}
+
+type ListenerState struct {
+	Port int
+	Fd   uintptr
+}
+
+type ConnectionState struct {
+	Fd             uintptr
+	ConnectionId   connections.ConnectionId
+	UserId         int
+	Username       string
+	RemoteAddr     string // Proxy reported client address, empty if not proxied
+	Compressed     bool   // Was MCCP2 active? Restarted on the new side.
+	ClientSettings connections.ClientSettings
+	PluginData     map[string][]byte `yaml:",omitempty"`
+}
+
+// Duplicates a listening socket so it survives exec
+func (s *State) AddListener(port int, l Filer) error {
+
+	f, err := l.File()
+	if err != nil {
+		return err
+	}
+
+	fd, err := inheritable(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.files = append(s.files, f)
+	s.Listeners = append(s.Listeners, ListenerState{Port: port, Fd: fd})
+
+	return nil
+}
+
+// Duplicates a live connection so it survives exec. cs.Fd is filled in.
+func (s *State) AddConnection(cs ConnectionState, c Filer) error {
+
+	f, err := c.File()
+	if err != nil {
+		return err
+	}
+
+	fd, err := inheritable(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	cs.Fd = fd
+
+	s.files = append(s.files, f)
+	s.Connections = append(s.Connections, cs)
+
+	return nil
+}
+
+// Writes the state file and replaces the current process with a fresh copy of the binary.
+// Only returns if something went wrong, in which case the duplicated descriptors are closed
+// and the server can carry on as it was.
+func (s *State) Exec(stateFilePath string) error {
+
+	defer s.closeFiles()
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(stateFilePath, data, 0600); err != nil {
+		return err
+	}
+
+	if err := execSelf(EnvStateFile + `=` + stateFilePath); err != nil {
+		os.Remove(stateFilePath)
+		return err
+	}
+
+	return nil
+}
+
+func (s *State) closeFiles() {
+	for _, f := range s.files {
+		f.Close()
+	}
+	s.files = nil
+}
+
+// Load reads the state left by a previous process, if this process was started by a copyover.
+// Returns nil, nil for a normal startup. The state file is removed once read.
+func Load() (*State, error) {
+
+	stateFilePath := os.Getenv(EnvStateFile)
+	if stateFilePath == `` {
+		return nil, nil
+	}
+
+	// Don't pass it along to anything we might start later
+	os.Unsetenv(EnvStateFile)
+
+	data, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(stateFilePath)
+
+	s := &State{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Returns the inherited listener for a port, or nil if there wasn't one.
+// Each listener can only be claimed once.
+func (s *State) Listener(port int) (net.Listener, error) {
+
+	if s == nil {
+		return nil, nil
+	}
+
+	for idx, ls := range s.Listeners {
+		if ls.Port != port {
+			continue
+		}
+
+		s.Listeners = append(s.Listeners[:idx], s.Listeners[idx+1:]...)
+
+		f := os.NewFile(ls.Fd, fmt.Sprintf(`listener:%d`, port))
+		defer f.Close()
+
+		return net.FileListener(f)
+	}
+
+	return nil, nil
+}
+
+// Closes any inherited listeners that weren't claimed, such as a port removed from the config.
+func (s *State) CloseUnclaimed() {
+
+	if s == nil {
+		return
+	}
+
+	for _, ls := range s.Listeners {
+		os.NewFile(ls.Fd, fmt.Sprintf(`listener:%d`, ls.Port)).Close()
+	}
+	s.Listeners = nil
+}
+
+// Rebuilds the inherited connection
+func (cs ConnectionState) Conn() (net.Conn, error) {
+
+	f := os.NewFile(cs.Fd, fmt.Sprintf(`conn:%d`, cs.ConnectionId))
+	defer f.Close()
+
+	return net.FileConn(f)
+}
+
+// Returns the proxy reported client address, or nil if there wasn't one
+func (cs ConnectionState) ProxiedAddr() net.Addr {
+
+	if cs.RemoteAddr == `` {
+		return nil
+	}
+
+	addr, err := net.ResolveTCPAddr(`tcp`, cs.RemoteAddr)
+	if err != nil {
+		return nil
+	}
+
+	return addr
+}
@@ -0,0 +1,129 @@
+//go:build !windows
+
+package copyover
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"gopkg.in/yaml.v2"
+)
+
+func TestLoad_NoState(t *testing.T) {
+	os.Unsetenv(EnvStateFile)
+
+	s, err := Load()
+	if err != nil || s != nil {
+		t.Fatalf("Load() = %v, %v; want nil, nil", s, err)
+	}
+
+	// Methods must be safe on a nil state
+	if l, err := s.Listener(1234); l != nil || err != nil {
+		t.Fatalf("nil State.Listener() = %v, %v; want nil, nil", l, err)
+	}
+	s.CloseUnclaimed()
+}
+
+func TestLoad_ReadsAndRemovesStateFile(t *testing.T) {
+
+	want := State{
+		Listeners: []ListenerState{{Port: 33333, Fd: 3}},
+		Connections: []ConnectionState{{
+			Fd:           4,
+			ConnectionId: 12,
+			UserId:       1,
+			Username:     `admin`,
+			RemoteAddr:   `203.0.113.7:51234`,
+			Compressed:   true,
+			ClientSettings: connections.ClientSettings{
+				Display:    connections.DisplaySettings{ScreenWidth: 120, ScreenHeight: 50},
+				MXPEnabled: true,
+				Charset:    `UTF-8`,
+			},
+			PluginData: map[string][]byte{`gmcp`: []byte(`{"GMCPAccepted":true}`)},
+		}},
+	}
+
+	data, err := yaml.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateFilePath := filepath.Join(t.TempDir(), `copyover.yaml`)
+	if err := os.WriteFile(stateFilePath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvStateFile, stateFilePath)
+
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if len(got.Listeners) != 1 || got.Listeners[0] != want.Listeners[0] {
+		t.Errorf("Listeners = %+v; want %+v", got.Listeners, want.Listeners)
+	}
+
+	if len(got.Connections) != 1 {
+		t.Fatalf("Connections = %+v; want 1 entry", got.Connections)
+	}
+
+	cs := got.Connections[0]
+	if cs.ConnectionId != 12 || cs.Username != `admin` || !cs.Compressed {
+		t.Errorf("Connection = %+v", cs)
+	}
+	if cs.ClientSettings != want.Connections[0].ClientSettings {
+		t.Errorf("ClientSettings = %+v; want %+v", cs.ClientSettings, want.Connections[0].ClientSettings)
+	}
+	if string(cs.PluginData[`gmcp`]) != `{"GMCPAccepted":true}` {
+		t.Errorf("PluginData = %q", cs.PluginData)
+	}
+	if addr := cs.ProxiedAddr(); addr == nil || addr.String() != `203.0.113.7:51234` {
+		t.Errorf("ProxiedAddr() = %v", addr)
+	}
+
+	if _, err := os.Stat(stateFilePath); !os.IsNotExist(err) {
+		t.Errorf("state file was not removed")
+	}
+	if os.Getenv(EnvStateFile) != `` {
+		t.Errorf("%s was not cleared", EnvStateFile)
+	}
+}
+
+func TestAddListener_Reclaim(t *testing.T) {
+
+	l, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	port := l.Addr().(*net.TCPAddr).Port
+
+	s := &State{}
+	if err := s.AddListener(port, l.(*net.TCPListener)); err != nil {
+		t.Fatalf("AddListener() error: %v", err)
+	}
+
+	// Mimic the new process picking up the inherited descriptor
+	s.files = nil
+
+	inherited, err := s.Listener(port)
+	if err != nil || inherited == nil {
+		t.Fatalf("Listener() = %v, %v", inherited, err)
+	}
+	defer inherited.Close()
+
+	if inherited.Addr().String() != l.Addr().String() {
+		t.Errorf("inherited listener on %s; want %s", inherited.Addr(), l.Addr())
+	}
+
+	// Can only be claimed once
+	if again, _ := s.Listener(port); again != nil {
+		t.Errorf("listener claimed twice")
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package copyover
+
+import (
+	"os"
+	"syscall"
+)
+
+// Clears close-on-exec so the descriptor is inherited by the new process
+func inheritable(f *os.File) (uintptr, error) {
+
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	var fd uintptr
+	var fcntlErr error
+
+	err = rawConn.Control(func(d uintptr) {
+		fd = d
+		if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, d, syscall.F_SETFD, 0); errno != 0 {
+			fcntlErr = errno
+		}
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return fd, fcntlErr
+}
+
+// Replaces the current process, keeping the same pid, arguments and environment
+func execSelf(extraEnv ...string) error {
+
+	binPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	return syscall.Exec(binPath, os.Args, append(os.Environ(), extraEnv...))
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package copyover
+
+import (
+	"os"
+)
+
+func inheritable(f *os.File) (uintptr, error) {
+	return 0, ErrUnsupported
+}
+
+func execSelf(extraEnv ...string) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,54 @@
+package datafiles
+
+import (
+	"os"
+	"sync"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"gopkg.in/yaml.v2"
+)
+
+//
+// Single yaml files kept directly in FilePaths.DataFiles, such as bans.yaml or world-state.yaml.
+// Each store keeps its own lock around its data, these just do the reading and writing.
+//
+
+// Where a file in FilePaths.DataFiles lives
+func Path(fileName string) string {
+	return util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, fileName)
+}
+
+// Reads a yaml file from FilePaths.DataFiles into out.
+// A file that doesn't exist yet isn't an error, and leaves out as it was.
+func LoadYaml(fileName string, out any) error {
+
+	fileBytes, err := os.ReadFile(Path(fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return yaml.Unmarshal(fileBytes, out)
+}
+
+// Writes v to a yaml file in FilePaths.DataFiles.
+// v is marshalled while holding lock (if it isn't nil), which is released before writing.
+func SaveYaml(fileName string, v any, lock sync.Locker) error {
+
+	if lock != nil {
+		lock.Lock()
+	}
+	data, err := yaml.Marshal(v)
+	if lock != nil {
+		lock.Unlock()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return util.Save(Path(fileName), data, bool(configs.GetFilePathsConfig().CarefulSaveFiles))
+}
@@ -0,0 +1,21 @@
+package datafilestest
+
+import (
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+)
+
+// Points FilePaths.DataFiles at a new temporary directory until the test ends, and returns it
+func TempDir(t testing.TB) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	t.Cleanup(configs.Snapshot())
+	if err := configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
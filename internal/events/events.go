@@ -220,7 +220,10 @@ func ProcessEvents() {
 
 		qLock.Unlock()
 
+		evtStart := time.Now()
 		evtResult = DoListeners(pe.event)
+		recordEventTime(pe.event.Type(), time.Since(evtStart))
+
 		if evtResult == CancelAndRequeue {
 			addToRequeue(pe.event, pe.priority)
 		}
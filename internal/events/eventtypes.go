@@ -200,6 +200,16 @@ type ScriptedEvent struct {
 
 func (s ScriptedEvent) Type() string { return `ScriptedEvent` }
 
+// A value in the world state store was set, changed or deleted
+type WorldStateChanged struct {
+	Namespace string
+	Key       string
+	Value     any // nil if it was deleted or expired
+	OldValue  any // nil if it wasn't set before
+}
+
+func (w WorldStateChanged) Type() string { return `WorldStateChanged` }
+
 // Entered the world
 type PlayerSpawn struct {
 	UserId        int
@@ -207,6 +217,7 @@ type PlayerSpawn struct {
 	RoomId        int
 	Username      string
 	CharacterName string
+	Copyover      bool // Re-entering after a copyover rather than logging in
 }
 
 func (p PlayerSpawn) Type() string { return `PlayerSpawn` }
@@ -12,6 +12,7 @@ type ListenerId uint64
 
 type ListenerWrapper struct {
 	id       ListenerId
+	name     string // function name, for profiling
 	listener Listener
 	isFinal  bool
 }
@@ -79,6 +80,7 @@ func RegisterListener(emptyEvent any, cbFunc Listener, qFlag ...QueueFlag) Liste
 
 	listenerDetails := ListenerWrapper{
 		id:       listenerCt,
+		name:     listenerName(cbFunc),
 		listener: cbFunc,
 		isFinal:  len(qFlag) > 0 && qFlag[0] == Last,
 	}
@@ -109,7 +111,7 @@ func RegisterListener(emptyEvent any, cbFunc Listener, qFlag ...QueueFlag) Liste
 	}
 
 	// Write it to debug out
-	//mudlog.Debug("Listener Registered", "Event", eType, "Function", runtime.FuncForPC(reflect.ValueOf(cbFunc).Pointer()).Name())
+	//mudlog.Debug("Listener Registered", "Event", eType, "Function", listenerDetails.name)
 
 	if eType == `*` {
 		hasWildcardListener = true
@@ -163,7 +165,7 @@ func DoListeners(e Event) ListenerReturn {
 		if vals, ok := eventListeners[`*`]; ok {
 			listenerFound = true
 			for _, lw := range vals {
-				if result := lw.listener(e); result != Continue {
+				if result := callListener(lw, e); result != Continue {
 					return result
 				}
 			}
@@ -174,7 +176,7 @@ func DoListeners(e Event) ListenerReturn {
 	if vals, ok := eventListeners[e.Type()]; ok {
 		listenerFound = true
 		for _, lw := range vals {
-			if result := lw.listener(e); result != Continue {
+			if result := callListener(lw, e); result != Continue {
 				return result
 			}
 		}
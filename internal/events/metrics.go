@@ -0,0 +1,80 @@
+package events
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/metrics"
+)
+
+// How many of each event type were handled, and how long their listeners took.
+// NewTurn and NewRound times are the time spent processing each turn and round.
+type eventTiming struct {
+	count   uint64
+	seconds float64
+}
+
+var (
+	timingLock   = sync.Mutex{}
+	eventTimings = map[string]*eventTiming{}
+)
+
+func recordEventTime(eventType string, d time.Duration) {
+	timingLock.Lock()
+	defer timingLock.Unlock()
+
+	t, ok := eventTimings[eventType]
+	if !ok {
+		t = &eventTiming{}
+		eventTimings[eventType] = t
+	}
+	t.count++
+	t.seconds += d.Seconds()
+}
+
+// How many events are waiting to be handled
+func QueueDepth() int {
+	qLock.Lock()
+	defer qLock.Unlock()
+
+	return globalQueue.Len() + len(requeues)
+}
+
+func collectMetrics() []metrics.Metric {
+
+	depth := metrics.NewGauge(`gomud_event_queue_depth`, `Events waiting in the queue.`)
+	depth.Add(float64(QueueDepth()))
+
+	processed := metrics.NewCounter(`gomud_events_processed_total`, `Events handled, by event type.`)
+	duration := metrics.NewSummary(`gomud_event_duration_seconds`, `Time spent in listeners, by event type.`)
+	turns := metrics.NewSummary(`gomud_turn_duration_seconds`, `Time spent processing each turn (NewTurn listeners).`)
+	rounds := metrics.NewSummary(`gomud_round_duration_seconds`, `Time spent processing each round (NewRound listeners).`)
+
+	timingLock.Lock()
+	eventTypes := make([]string, 0, len(eventTimings))
+	for eventType := range eventTimings {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	for _, eventType := range eventTypes {
+		t := eventTimings[eventType]
+		processed.Add(float64(t.count), `type`, eventType)
+		duration.Observed(t.seconds, float64(t.count), `type`, eventType)
+
+		switch eventType {
+		case NewTurn{}.Type():
+			turns.Observed(t.seconds, float64(t.count))
+		case NewRound{}.Type():
+			rounds.Observed(t.seconds, float64(t.count))
+		}
+	}
+	timingLock.Unlock()
+
+	return []metrics.Metric{depth, processed, duration, turns, rounds}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -0,0 +1,193 @@
+package events
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+//
+// Times every listener call.
+// Listeners over Timing.SlowListenerMs are always logged (at most once a minute each).
+// While profiling is on, calls, time and cancels are also added up per event type and listener.
+//
+
+const slowListenerWarnPeriod = time.Minute
+
+// What one listener of one event type has cost since profiling started
+type ListenerProfile struct {
+	EventType string
+	Listener  string
+	Calls     uint64
+	Total     time.Duration
+	Max       time.Duration
+	Cancels   uint64
+	Requeues  uint64
+}
+
+func (p ListenerProfile) Average() time.Duration {
+	if p.Calls == 0 {
+		return 0
+	}
+	return p.Total / time.Duration(p.Calls)
+}
+
+type slowListener struct {
+	lastWarned time.Time
+	suppressed int // slow calls not logged since the last warning
+}
+
+var (
+	profiling      atomic.Bool
+	profileLock    = sync.Mutex{}
+	profileStarted time.Time
+	profileStopped time.Time
+	profileData    = map[string]*ListenerProfile{}
+
+	slowListeners = map[string]*slowListener{}
+)
+
+// A readable name for a listener function, e.g. "internal/hooks.DoCombat" or "modules/follow.(*FollowModule).roundTick-fm"
+func listenerName(cbFunc Listener) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(cbFunc).Pointer())
+	if fn == nil {
+		return `unknown`
+	}
+	name := fn.Name()
+	if idx := strings.Index(name, `/internal/`); idx != -1 {
+		return name[idx+1:]
+	}
+	if idx := strings.Index(name, `/modules/`); idx != -1 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+// Starts a new profile, throwing away the last one
+func StartProfiling() {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	profileData = map[string]*ListenerProfile{}
+	profileStarted = time.Now()
+	profileStopped = time.Time{}
+	profiling.Store(true)
+}
+
+// Stops adding to the profile. It can still be read until the next start.
+func StopProfiling() {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	if profiling.Swap(false) {
+		profileStopped = time.Now()
+	}
+}
+
+// Whether a profile is running, and how long the current (or last) profile covers
+func ProfilingStatus() (running bool, duration time.Duration) {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	if profileStarted.IsZero() {
+		return false, 0
+	}
+	if profiling.Load() {
+		return true, time.Since(profileStarted)
+	}
+	return false, profileStopped.Sub(profileStarted)
+}
+
+// The current (or last) profile, most expensive first
+func GetProfile() []ListenerProfile {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	result := make([]ListenerProfile, 0, len(profileData))
+	for _, p := range profileData {
+		result = append(result, *p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total == result[j].Total {
+			return result[i].Listener < result[j].Listener
+		}
+		return result[i].Total > result[j].Total
+	})
+
+	return result
+}
+
+func callListener(lw ListenerWrapper, e Event) ListenerReturn {
+
+	start := time.Now()
+	result := lw.listener(e)
+	elapsed := time.Since(start)
+
+	eventType := e.Type()
+
+	if budgetMs := configs.GetTimingConfig().SlowListenerMs; budgetMs > 0 {
+		if budget := time.Duration(budgetMs) * time.Millisecond; elapsed > budget {
+			warnSlowListener(eventType, lw.name, elapsed, budget)
+		}
+	}
+
+	if profiling.Load() {
+		recordListener(eventType, lw.name, elapsed, result)
+	}
+
+	return result
+}
+
+func recordListener(eventType string, name string, elapsed time.Duration, result ListenerReturn) {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+
+	key := eventType + ` ` + name
+	p, ok := profileData[key]
+	if !ok {
+		p = &ListenerProfile{EventType: eventType, Listener: name}
+		profileData[key] = p
+	}
+
+	p.Calls++
+	p.Total += elapsed
+	if elapsed > p.Max {
+		p.Max = elapsed
+	}
+
+	switch result {
+	case Cancel:
+		p.Cancels++
+	case CancelAndRequeue:
+		p.Requeues++
+	}
+}
+
+// Only called from DoListeners, which holds listenerLock
+func warnSlowListener(eventType string, name string, elapsed time.Duration, budget time.Duration) {
+
+	key := eventType + ` ` + name
+	s, ok := slowListeners[key]
+	if !ok {
+		s = &slowListener{}
+		slowListeners[key] = s
+	}
+
+	if time.Since(s.lastWarned) < slowListenerWarnPeriod {
+		s.suppressed++
+		return
+	}
+
+	mudlog.Warn("Slow Listener", "event", eventType, "listener", name, "took", elapsed.String(), "budget", budget.String(), "unreported", s.suppressed)
+
+	s.lastWarned = time.Now()
+	s.suppressed = 0
+}
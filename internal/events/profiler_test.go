@@ -0,0 +1,68 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func profiledSlowListener(e Event) ListenerReturn {
+	time.Sleep(2 * time.Millisecond)
+	return Continue
+}
+
+func profiledCancelListener(e Event) ListenerReturn {
+	return Cancel
+}
+
+// TestProfiling verifies listener calls are only recorded while profiling, and the most expensive listener comes first.
+func TestProfiling(t *testing.T) {
+	ClearListeners()
+	defer ClearListeners()
+
+	RegisterListener(NewTurn{}, profiledSlowListener)
+	RegisterListener(NewTurn{}, profiledCancelListener)
+
+	AddToQueue(NewTurn{TurnNumber: 1})
+	ProcessEvents()
+
+	running, duration := ProfilingStatus()
+	assert.False(t, running, "Profiling should be off by default")
+	assert.Equal(t, time.Duration(0), duration, "No profile should exist before starting one")
+
+	StartProfiling()
+	AddToQueue(NewTurn{TurnNumber: 2})
+	AddToQueue(NewTurn{TurnNumber: 3})
+	ProcessEvents()
+	StopProfiling()
+
+	// Not recorded after stopping
+	AddToQueue(NewTurn{TurnNumber: 4})
+	ProcessEvents()
+
+	running, duration = ProfilingStatus()
+	assert.False(t, running, "Profiling should be stopped")
+	assert.Greater(t, duration, time.Duration(0), "The stopped profile should still cover some time")
+
+	profile := GetProfile()
+	if assert.Len(t, profile, 2, "Expected one entry per listener") {
+
+		slow, cancel := profile[0], profile[1]
+
+		assert.True(t, strings.HasSuffix(slow.Listener, `events.profiledSlowListener`), "Unexpected listener name %q", slow.Listener)
+		assert.Equal(t, `NewTurn`, slow.EventType)
+		assert.Equal(t, uint64(2), slow.Calls)
+		assert.GreaterOrEqual(t, slow.Max, 2*time.Millisecond)
+		assert.GreaterOrEqual(t, slow.Total, slow.Max)
+
+		assert.True(t, strings.HasSuffix(cancel.Listener, `events.profiledCancelListener`), "Unexpected listener name %q", cancel.Listener)
+		assert.Equal(t, uint64(2), cancel.Cancels)
+	}
+
+	// Starting again throws away the old profile
+	StartProfiling()
+	StopProfiling()
+	assert.Empty(t, GetProfile(), "A new profile should start empty")
+}
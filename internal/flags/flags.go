@@ -11,6 +11,12 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 )
 
+var (
+	recordPath       string
+	replayPath       string
+	replayOutputPath string
+)
+
 func HandleFlags(serverVersion string) {
 
 	var portsearch string
@@ -18,6 +24,9 @@ func HandleFlags(serverVersion string) {
 
 	flag.StringVar(&portsearch, "port-search", "", "Search for the first 10 open ports: -port-search=30000-40000")
 	flag.BoolVar(&showVersion, "version", false, "Display the current binary version")
+	flag.StringVar(&recordPath, "record", "", "Record all player input to a journal, next to a copy of the data files: -record=bug.jsonl")
+	flag.StringVar(&replayPath, "replay", "", "Play back a journal without any networking, then exit: -replay=bug.jsonl")
+	flag.StringVar(&replayOutputPath, "replay-output", "", "Where -replay writes what players were sent (default: the journal path + .out)")
 
 	flag.Parse()
 
@@ -32,6 +41,24 @@ func HandleFlags(serverVersion string) {
 	}
 }
 
+// The journal to record to, if any
+func RecordPath() string {
+	return recordPath
+}
+
+// The journal to replay, if any
+func ReplayPath() string {
+	return replayPath
+}
+
+// Where a replay writes its output
+func ReplayOutputPath() string {
+	if replayOutputPath == `` && replayPath != `` {
+		return replayPath + `.out`
+	}
+	return replayOutputPath
+}
+
 func doPortSearch(portRangeStr string) {
 	portRange := strings.Split(portRangeStr, `-`)
 
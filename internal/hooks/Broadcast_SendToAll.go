@@ -36,20 +36,25 @@ func Broadcast_SendToAll(e events.Event) events.ListenerReturn {
 			}
 		}
 
+		userText, userTextSR := textOut, textOutSR
+		if u.ClientSettings().IsMxp() {
+			userText, userTextSR = term.MxpEscape(textOut), term.MxpEscape(textOutSR)
+		}
+
 		events.AddToQueue(events.RedrawPrompt{UserId: u.UserId}, 100)
 
 		if u.ScreenReader {
 
-			if len(textOutSR) > 0 {
+			if len(userTextSR) > 0 {
 
 				if broadcast.SkipLineRefresh {
 					connections.SendTo(
-						[]byte(textOutSR),
+						[]byte(userTextSR),
 						u.ConnectionId(),
 					)
 				} else {
 					connections.SendTo(
-						[]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+textOutSR),
+						[]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+userTextSR),
 						u.ConnectionId(),
 					)
 				}
@@ -61,12 +66,12 @@ func Broadcast_SendToAll(e events.Event) events.ListenerReturn {
 
 		if broadcast.SkipLineRefresh {
 			connections.SendTo(
-				[]byte(textOut),
+				[]byte(userText),
 				u.ConnectionId(),
 			)
 		} else {
 			connections.SendTo(
-				[]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+textOut),
+				[]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+userText),
 				u.ConnectionId(),
 			)
 		}
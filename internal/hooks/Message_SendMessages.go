@@ -34,6 +34,9 @@ func Message_SendMessage(e events.Event) events.ListenerReturn {
 			if user.ScreenReader {
 				textOut = util.StripCharsForScreenReaders(textOut)
 			}
+			if user.ClientSettings().IsMxp() {
+				textOut = term.MxpEscape(textOut)
+			}
 			connections.SendTo([]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+textOut), user.ConnectionId())
 
 			events.AddToQueue(events.RedrawPrompt{UserId: user.UserId}, 100)
@@ -87,6 +90,9 @@ func Message_SendMessage(e events.Event) events.ListenerReturn {
 				if user.ScreenReader {
 					textOut = util.StripCharsForScreenReaders(textOut)
 				}
+				if user.ClientSettings().IsMxp() {
+					textOut = term.MxpEscape(textOut)
+				}
 
 				connections.SendTo([]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+textOut), user.ConnectionId())
 
@@ -361,6 +361,10 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 
 			roundResult := combat.AttackPlayerVsPlayer(user, defUser)
 
+			if defUser.Character.Health <= 0 {
+				scripting.TryKillEvents(user.UserId, 0, defUser.UserId, 0, &roundResult)
+			}
+
 			// If a mob attacks a player, check whether player has a charmed mob helping them, and if so, they will move to attack back
 			room := rooms.LoadRoom(roomId)
 			for _, instanceId := range room.GetMobs(rooms.FindCharmed) {
@@ -537,6 +541,10 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 
 			roundResult = combat.AttackPlayerVsMob(user, defMob)
 
+			if defMob.Character.Health <= 0 {
+				scripting.TryKillEvents(user.UserId, 0, 0, defMob.InstanceId, &roundResult)
+			}
+
 			for _, buffId := range roundResult.BuffSource {
 				user.AddBuff(buffId, `combat`)
 			}
@@ -837,6 +845,10 @@ func handleMobCombat(evt events.NewRound) (affectedPlayerIds []int, affectedMobI
 
 			roundResult = combat.AttackMobVsPlayer(mob, defUser)
 
+			if defUser.Character.Health <= 0 {
+				scripting.TryKillEvents(0, mob.InstanceId, defUser.UserId, 0, &roundResult)
+			}
+
 			// If a mob attacks a player, check whether player has a charmed mob helping them, and if so, they will move to attack back
 			room := rooms.LoadRoom(roomId)
 			for _, instanceId := range room.GetMobs(rooms.FindCharmed) {
@@ -972,6 +984,10 @@ func handleMobCombat(evt events.NewRound) (affectedPlayerIds []int, affectedMobI
 
 			roundResult = combat.AttackMobVsMob(mob, defMob)
 
+			if defMob.Character.Health <= 0 {
+				scripting.TryKillEvents(0, mob.InstanceId, 0, defMob.InstanceId, &roundResult)
+			}
+
 			for _, buffId := range roundResult.BuffSource {
 				mob.AddBuff(buffId, `combat`)
 			}
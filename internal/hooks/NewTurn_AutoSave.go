@@ -12,6 +12,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
 )
 
 //
@@ -63,6 +64,7 @@ func AutoSave(e events.Event) events.ListenerReturn {
 		events.AddToQueue(events.Broadcast{Text: `Saving other...`})
 		// Save plugin states if applicable
 		plugins.Save()
+		worldstate.SaveState()
 
 		events.AddToQueue(events.Broadcast{
 			Text:            `Done.` + term.CRLFStr,
@@ -0,0 +1,17 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
+)
+
+//
+// Removes expired world state values, so scripts hear about them going away
+//
+
+func ExpireWorldState(e events.Event) events.ListenerReturn {
+
+	worldstate.ExpireState()
+
+	return events.Continue
+}
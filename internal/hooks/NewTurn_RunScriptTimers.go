@@ -0,0 +1,24 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+)
+
+//
+// Runs script timers that are due
+//
+
+func RunScriptTimers(e events.Event) events.ListenerReturn {
+
+	evt, typeOk := e.(events.NewTurn)
+	if !typeOk {
+		mudlog.Error("Event", "Expected Type", "NewTurn", "Actual Type", e.Type())
+		return events.Cancel
+	}
+
+	scripting.RunTimers(evt.TurnNumber)
+
+	return events.Continue
+}
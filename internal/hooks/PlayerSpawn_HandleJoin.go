@@ -47,7 +47,7 @@ func HandleJoin(e events.Event) events.ListenerReturn {
 
 	// TODO HERE
 	loginCmds := configs.GetConfig().Server.OnLoginCommands
-	if len(loginCmds) > 0 {
+	if len(loginCmds) > 0 && !evt.Copyover {
 
 		for _, cmd := range loginCmds {
 
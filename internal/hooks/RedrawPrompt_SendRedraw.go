@@ -5,6 +5,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
@@ -36,6 +37,9 @@ func RedrawPrompt_SendRedraw(e events.Event) events.ListenerReturn {
 		}
 
 		pTxt := templates.AnsiParse(newCmdPrompt)
+		if user.ClientSettings().IsMxp() {
+			pTxt = term.MxpEscape(pTxt)
+		}
 		connections.SendTo([]byte(pTxt), user.ConnectionId())
 
 	}
@@ -0,0 +1,24 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+)
+
+//
+// Lets loaded room scripts react to changes in the world state
+//
+
+func NotifyRoomScripts(e events.Event) events.ListenerReturn {
+
+	evt, typeOk := e.(events.WorldStateChanged)
+	if !typeOk {
+		mudlog.Error("Event", "Expected Type", "WorldStateChanged", "Actual Type", e.Type())
+		return events.Cancel
+	}
+
+	scripting.TryRoomWorldStateEvents(evt.Namespace, evt.Key, evt.Value, evt.OldValue)
+
+	return events.Continue
+}
@@ -1,7 +1,9 @@
 package hooks
 
 import (
+	"github.com/GoMudEngine/GoMud/internal/combat"
 	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
 )
 
 // Register hooks here...
@@ -28,6 +30,8 @@ func RegisterListeners() {
 	// Combat goes here
 	//
 	events.RegisterListener(events.NewRound{}, DoCombat)
+	// Lets item and mob scripts change attacks before their damage is done
+	combat.SetAttackHandler(scripting.TryAttackEvents)
 	//
 	// Done with combat
 	//
@@ -40,6 +44,8 @@ func RegisterListeners() {
 	events.RegisterListener(events.NewTurn{}, AutoSave)
 	events.RegisterListener(events.NewTurn{}, PruneBuffs)
 	events.RegisterListener(events.NewTurn{}, ActionPoints)
+	events.RegisterListener(events.NewTurn{}, RunScriptTimers)
+	events.RegisterListener(events.NewTurn{}, ExpireWorldState)
 
 	// ItemOwnership
 	events.RegisterListener(events.ItemOwnership{}, CheckItemQuests)
@@ -59,6 +65,9 @@ func RegisterListeners() {
 	// Day/Night cycle
 	events.RegisterListener(events.DayNightCycle{}, NotifySunriseSunset)
 
+	// World state changes
+	events.RegisterListener(events.WorldStateChanged{}, NotifyRoomScripts)
+
 	// Looking
 	events.RegisterListener(events.Looking{}, HandleLookHints)
 
@@ -0,0 +1,20 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/metrics"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func collectMetrics() []metrics.Metric {
+
+	autoSave := metrics.NewSummary(`gomud_autosave_duration_seconds`, `Time spent saving all users and rooms.`)
+
+	t, _ := util.GetTimeTracker(`AutoSave`)
+	autoSave.Observed(t.Total, t.Count)
+
+	return []metrics.Metric{autoSave}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -12,8 +12,23 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/templates"
 	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
+// Checks a login password without the MUD lock, since hashing is slow on purpose.
+// u must not be shared with the game loop. The lock is only taken to store an upgraded hash.
+func checkLoginPassword(u *users.UserRecord, password string) bool {
+
+	ok, newHash := u.CheckPassword(password)
+	if ok && newHash != `` {
+		util.LockMud()
+		u.UpgradePassword(newHash)
+		util.UnlockMud()
+	}
+
+	return ok
+}
+
 // FinalizeLoginOrCreate is called after all prompts are successfully answered.
 func FinalizeLoginOrCreate(results map[string]string, sharedState map[string]any, clientInput *connections.ClientInput) bool {
 
@@ -54,7 +69,7 @@ func FinalizeLoginOrCreate(results map[string]string, sharedState map[string]any
 				return false // Indicate failure, connection removed
 			}
 
-			if !tmpUser.PasswordMatches(password) {
+			if !checkLoginPassword(tmpUser, password) {
 				connections.SendTo([]byte(`Nope. Bye!`), clientInput.ConnectionId)
 				connections.SendTo(term.CRLF, clientInput.ConnectionId)
 				connections.Remove(clientInput.ConnectionId)
@@ -190,9 +205,16 @@ func GetLoginPromptHandler() connections.InputHandler {
 
 				userid := users.FindUserId(results["username"])
 
+				// The online record belongs to the game loop, so check a copy of it
+				util.RLockMud()
+				var userCopy users.UserRecord
 				user := users.GetByUserId(userid)
+				if user != nil {
+					userCopy = *user
+				}
+				util.RUnlockMud()
 
-				return user != nil && user.PasswordMatches(results["password"])
+				return user != nil && checkLoginPassword(&userCopy, results["password"])
 			}, // Only run if username was not "new", password matches, and user is currently online.
 		},
 		//////////////////////////////////////////////////
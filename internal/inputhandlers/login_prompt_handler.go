@@ -261,6 +261,15 @@ func CreatePromptHandler(steps []*PromptStep, onComplete CompletionFunc) connect
 		clientInput.Buffer = clientInput.Buffer[:0] // Clear buffer for next input
 		state.maskTemplate = ""                     // Clear cached mask template
 
+		// Plain text MSSP request from a crawler that doesn't speak telnet
+		if state.CurrentStepIndex == 0 && submittedInput == term.MsspPlainRequest && configs.GetIntegrationsConfig().MSSP.Enabled {
+			mudlog.Debug("Received", "type", "MSSP-REQUEST", "connectionId", clientInput.ConnectionId)
+			connections.SendTo(term.MsspPlainText(GetMSSPVariables()), clientInput.ConnectionId)
+			connections.Remove(clientInput.ConnectionId)
+			state.CurrentStepIndex += 99
+			return false
+		}
+
 		// Validation
 
 		validatedValue, err := currentStep.Validator(submittedInput, state.Results)
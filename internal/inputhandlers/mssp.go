@@ -0,0 +1,97 @@
+package inputhandlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/races"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/version"
+)
+
+// Gathers everything reported to MUD listing crawlers via MSSP
+func GetMSSPVariables() []term.MSSPVariable {
+
+	c := configs.GetConfig()
+
+	// World data is owned by the main loop
+	util.RLockMud()
+	playerCt := len(users.GetOnlineUserIds())
+	roomCt := len(rooms.GetAllRoomIds())
+	areaCt := len(rooms.GetAllZoneNames())
+	mobCt := len(mobs.GetAllMobNames())
+	itemCt := len(items.GetAllItemNames())
+	raceCt := len(races.GetRaces())
+	util.RUnlockMud()
+
+	ports := []string{}
+	for _, port := range c.Network.TelnetPort {
+		if p, err := strconv.Atoi(port); err == nil && p > 0 {
+			ports = append(ports, strconv.Itoa(p))
+		}
+	}
+
+	boolStr := func(b bool) string {
+		if b {
+			return `1`
+		}
+		return `0`
+	}
+
+	vars := []term.MSSPVariable{
+		{Name: `NAME`, Values: []string{string(c.Server.MudName)}},
+		{Name: `PLAYERS`, Values: []string{strconv.Itoa(playerCt)}},
+		{Name: `UPTIME`, Values: []string{strconv.FormatInt(util.GetServerStartTime().Unix(), 10)}},
+		{Name: `CODEBASE`, Values: []string{version.Codebase + ` ` + version.ServerVersion().String()}},
+		{Name: `PORT`, Values: ports},
+		{Name: `AREAS`, Values: []string{strconv.Itoa(areaCt)}},
+		{Name: `ROOMS`, Values: []string{strconv.Itoa(roomCt)}},
+		{Name: `MOBILES`, Values: []string{strconv.Itoa(mobCt)}},
+		{Name: `OBJECTS`, Values: []string{strconv.Itoa(itemCt)}},
+		{Name: `RACES`, Values: []string{strconv.Itoa(raceCt)}},
+		{Name: `ANSI`, Values: []string{`1`}},
+		{Name: `UTF-8`, Values: []string{`1`}},
+		{Name: `MSP`, Values: []string{`1`}},
+		{Name: `MCCP`, Values: []string{boolStr(bool(c.Network.MCCPEnabled))}},
+		{Name: `MXP`, Values: []string{boolStr(bool(c.Network.MXPEnabled))}},
+	}
+
+	if c.Network.TelnetTlsPort > 0 {
+		vars = append(vars, term.MSSPVariable{Name: `SSL`, Values: []string{strconv.Itoa(int(c.Network.TelnetTlsPort))}})
+	}
+
+	// Operator supplied fields override anything generated above
+	fieldNames := make([]string, 0, len(c.Integrations.MSSP.Fields))
+	for name := range c.Integrations.MSSP.Fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+
+	for _, name := range fieldNames {
+
+		value := c.Integrations.MSSP.Fields[name].String()
+		upperName := strings.ToUpper(name)
+
+		replaced := false
+		for i, v := range vars {
+			if v.Name == upperName {
+				vars[i].Values = []string{value}
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			vars = append(vars, term.MSSPVariable{Name: upperName, Values: []string{value}})
+		}
+	}
+
+	return vars
+}
@@ -1,6 +1,8 @@
 package inputhandlers
 
 import (
+	"strings"
+
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
@@ -95,8 +97,98 @@ func TelnetIACHandler(clientInput *connections.ClientInput, sharedState map[stri
 			continue
 		}
 
+		if term.IsMCCPCommand(iacCmd) {
+
+			if ok, _ := term.Matches(iacCmd, term.MccpAccept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP2 Accept)")
+
+				if !configs.GetNetworkConfig().MCCPEnabled {
+					continue
+				}
+
+				if err := connections.StartCompression(clientInput.ConnectionId); err != nil {
+					mudlog.Warn("MCCP2", "connectionId", clientInput.ConnectionId, "error", err)
+				}
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.MccpRefuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP2 Refuse)")
+
+				if err := connections.StopCompression(clientInput.ConnectionId); err != nil {
+					mudlog.Warn("MCCP2", "connectionId", clientInput.ConnectionId, "error", err)
+				}
+
+				continue
+			}
+
+			continue
+		}
+
+		if term.IsMXPCommand(iacCmd) {
+
+			if ok, _ := term.Matches(iacCmd, term.MxpAccept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MXP Accept)")
+
+				if !configs.GetNetworkConfig().MXPEnabled {
+					continue
+				}
+
+				connections.SendTo(term.MxpStart.BytesWithPayload(nil), clientInput.ConnectionId)
+
+				cs := connections.GetClientSettings(clientInput.ConnectionId)
+				cs.MXPEnabled = true
+				connections.OverwriteClientSettings(clientInput.ConnectionId, cs)
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.MxpRefuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MXP Refuse)")
+
+				cs := connections.GetClientSettings(clientInput.ConnectionId)
+				cs.MXPEnabled = false
+				connections.OverwriteClientSettings(clientInput.ConnectionId, cs)
+
+				continue
+			}
+
+			continue
+		}
+
+		if term.IsMSSPCommand(iacCmd) {
+
+			if ok, _ := term.Matches(iacCmd, term.MsspAccept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MSSP Accept)")
+
+				if !configs.GetIntegrationsConfig().MSSP.Enabled {
+					continue
+				}
+
+				connections.SendTo(
+					term.MsspCommand.BytesWithPayload(term.MsspPayload(GetMSSPVariables())),
+					clientInput.ConnectionId,
+				)
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.MsspRefuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MSSP Refuse)")
+				continue
+			}
+
+			continue
+		}
+
 		if ok, payload := term.Matches(iacCmd, term.TelnetAcceptedChangeCharset); ok {
 			mudlog.Debug("Received", "type", "IAC (TelnetAcceptedChangeCharset)", "data", term.BytesString(payload))
+
+			cs := connections.GetClientSettings(clientInput.ConnectionId)
+			cs.Charset = strings.TrimSpace(string(payload))
+			connections.OverwriteClientSettings(clientInput.ConnectionId, cs)
+
 			continue
 		}
 
@@ -0,0 +1,63 @@
+package inputhandlers
+
+import (
+	"bytes"
+
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+var (
+	oobHandlers = []OOBHandler{}
+	oobPrefix   = []byte(`!!`)
+)
+
+// Handles out-of-band websocket messages, formatted the same way as the
+// server-sent `!!SOUND(...)`/`!!GMCP(...)` messages: `!!NAME(payload)`
+type OOBHandler interface {
+	HandleOOB(uint64, []byte) bool
+}
+
+func AddOOBHandler(h OOBHandler) {
+	oobHandlers = append(oobHandlers, h)
+}
+
+// IsOOBMessage returns true if the input looks like `!!NAME(...)`
+func IsOOBMessage(b []byte) bool {
+	if len(b) < 5 || !bytes.HasPrefix(b, oobPrefix) || b[len(b)-1] != ')' {
+		return false
+	}
+
+	openAt := bytes.IndexByte(b, '(')
+	if openAt < 3 {
+		return false
+	}
+
+	for _, c := range b[2:openAt] {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WebOOBHandler intercepts out-of-band messages from websocket clients so they never reach
+// the login prompt or command parser.
+func WebOOBHandler(clientInput *connections.ClientInput, sharedState map[string]any) (nextHandler bool) {
+
+	data := bytes.TrimSpace(clientInput.DataIn)
+	if !IsOOBMessage(data) {
+		return true
+	}
+
+	for _, h := range oobHandlers {
+		if h.HandleOOB(clientInput.ConnectionId, data) {
+			return false
+		}
+	}
+
+	mudlog.Debug("Received", "type", "OOB (Unhandled)", "data", string(data))
+
+	return false
+}
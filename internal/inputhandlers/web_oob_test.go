@@ -0,0 +1,28 @@
+package inputhandlers
+
+import (
+	"testing"
+)
+
+func TestIsOOBMessage(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`!!GMCP(Core.Supports.Set ["Char 1", "Room 1"])`, true},
+		{`!!GMCP(Core.Hello {"client":"web","version":"1.0"})`, true},
+		{`!!MSP2()`, true},
+		{`!!gmcp(Core.Hello {})`, false},
+		{`!!(Core.Hello {})`, false},
+		{`!!GMCP(Core.Hello {}`, false},
+		{`say !!GMCP(hi)`, false},
+		{`!!`, false},
+		{`say hello (everyone)`, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsOOBMessage([]byte(tt.input)); got != tt.expected {
+			t.Errorf("IsOOBMessage(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -23,6 +23,11 @@ func HandlePlayerSpawn(e events.Event) events.ListenerReturn {
 		return events.Cancel
 	}
 
+	// They never actually left
+	if evt.Copyover {
+		return events.Continue
+	}
+
 	user := users.GetByUserId(evt.UserId)
 	if user == nil {
 		return events.Cancel
@@ -2,6 +2,8 @@ package items
 
 import (
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
@@ -44,6 +46,89 @@ func CreateNewItemFile(newItemInfo ItemSpec) (int, error) {
 	return newItemInfo.Id(), nil
 }
 
+// Overwrites an existing item file with new spec data.
+// If the name or type change the file (and any script) is moved to match.
+func UpdateItemFile(itemInfo ItemSpec) error {
+
+	oldSpec, ok := items[itemInfo.ItemId]
+	if !ok {
+		return fmt.Errorf(`item %d not found`, itemInfo.ItemId)
+	}
+
+	// The id range decides the folder, so the type has to stay within it.
+	if itemInfo.Type != oldSpec.Type {
+		for _, iType := range ItemTypes() {
+			if iType.Type != string(itemInfo.Type) {
+				continue
+			}
+			if itemInfo.ItemId < iType.MinItemId || itemInfo.ItemId > iType.MaxItemId {
+				return fmt.Errorf(`item %d can't become type %s (ids %d-%d)`, itemInfo.ItemId, iType.Type, iType.MinItemId, iType.MaxItemId)
+			}
+		}
+	}
+
+	if err := itemInfo.Validate(); err != nil {
+		return err
+	}
+
+	oldFilepath := oldSpec.Filepath()
+	oldScriptPath := oldSpec.GetScriptPath()
+
+	// Same as new files, the dice roll is all that needs to be written.
+	// A roll that does no damage (e.g. 0@0d0 on armor) isn't written at all.
+	saveInfo := itemInfo
+	if saveInfo.Damage.DiceCount == 0 && saveInfo.Damage.BonusDamage == 0 {
+		saveInfo.Damage.DiceRoll = ``
+	}
+	saveInfo.Damage.Attacks = 0
+	saveInfo.Damage.DiceCount = 0
+	saveInfo.Damage.SideCount = 0
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*ItemSpec](configs.GetFilePathsConfig().DataFiles.String()+`/items`, &saveInfo, saveModes...); err != nil {
+		return err
+	}
+
+	if oldFilepath != itemInfo.Filepath() {
+		os.Remove(configs.GetFilePathsConfig().DataFiles.String() + `/items/` + oldFilepath)
+		if _, err := os.Stat(oldScriptPath); err == nil {
+			if err := os.Rename(oldScriptPath, itemInfo.GetScriptPath()); err != nil {
+				return err
+			}
+		}
+	}
+
+	items[itemInfo.Id()] = &itemInfo
+
+	return nil
+}
+
+// Removes an item file (and any script) and forgets the spec.
+// Nothing checks whether the item is still in use, so callers must (copies left behind have no spec).
+func DeleteItemFile(itemId int) error {
+
+	itemInfo, ok := items[itemId]
+	if !ok {
+		return fmt.Errorf(`item %d not found`, itemId)
+	}
+
+	if err := os.Remove(configs.GetFilePathsConfig().DataFiles.String() + `/items/` + itemInfo.Filepath()); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(itemInfo.GetScriptPath()); err == nil {
+		os.Remove(itemInfo.GetScriptPath())
+	}
+
+	delete(items, itemId)
+
+	return nil
+}
+
 func getNextItemId(t ItemType) int {
 
 	rangeMin := 0
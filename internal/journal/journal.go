@@ -0,0 +1,221 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// A journal records everything that comes into the world from outside, so a session can be played back later.
+// The file has one JSON object per line. The first is a Header with what's needed to start the world
+// the same way (random seed, turn and round numbers, a copy of the data files). Every line after is an Entry.
+// Everything else (mobs, combat, scripts) follows from these, as long as util.Rand() is seeded the same.
+//
+
+const (
+	Version = 1
+
+	SnapshotSuffix = `.snapshot` // The data files are copied next to the journal, e.g. bug.jsonl.snapshot/
+
+	KindInput  = `input`  // A player typed something
+	KindEnter  = `enter`  // A player entered the world after logging in (or reconnecting)
+	KindLeave  = `leave`  // A player left the world, e.g. quit
+	KindLogout = `logout` // A player's connection was logged out
+	KindZombie = `zombie` // A player's connection dropped, leaving their character behind
+)
+
+type Header struct {
+	Version       int       `json:"version"`
+	Started       time.Time `json:"started"`
+	Seed          int64     `json:"seed"`
+	Turn          uint64    `json:"turn"`
+	Round         uint64    `json:"round"`
+	TurnMs        int       `json:"turnms"`
+	TurnsPerRound int       `json:"turnsperround"`
+	Snapshot      string    `json:"snapshot"` // Copy of the data files taken when recording started
+}
+
+type Entry struct {
+	Turn     uint64        `json:"turn"`
+	Round    uint64        `json:"round"`
+	Kind     string        `json:"kind"`
+	UserId   int           `json:"userid"`
+	Username string        `json:"username,omitempty"`
+	RoomId   int           `json:"roomid,omitempty"`
+	Input    *events.Input `json:"input,omitempty"`
+}
+
+var (
+	recordLock = sync.Mutex{}
+	recordFile *os.File
+	recordPath string
+)
+
+// Copies a data files folder to a new folder
+func Snapshot(dataFiles string, destination string) error {
+
+	src, err := filepath.Abs(dataFiles)
+	if err != nil {
+		return err
+	}
+	dst, err := filepath.Abs(destination)
+	if err != nil {
+		return err
+	}
+
+	if dst == src || strings.HasPrefix(dst, src+string(filepath.Separator)) {
+		return fmt.Errorf("the snapshot %s can't be inside the data files it copies (%s)", destination, dataFiles)
+	}
+
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("%s already exists", destination)
+	}
+
+	return os.CopyFS(dst, os.DirFS(src))
+}
+
+// Copies the data files next to the journal and starts recording to it.
+// Won't overwrite an existing journal.
+func Start(path string, dataFiles string, h Header) error {
+
+	recordLock.Lock()
+	defer recordLock.Unlock()
+
+	if recordFile != nil {
+		return fmt.Errorf("already recording to %s", recordPath)
+	}
+
+	h.Version = Version
+	h.Snapshot = path + SnapshotSuffix
+
+	if err := Snapshot(dataFiles, h.Snapshot); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	if err := writeLine(f, h); err != nil {
+		f.Close()
+		return err
+	}
+
+	recordFile = f
+	recordPath = path
+
+	mudlog.Info("Journal", "action", "recording", "path", path, "snapshot", h.Snapshot, "seed", h.Seed, "turn", h.Turn, "round", h.Round)
+
+	return nil
+}
+
+func Recording() bool {
+	recordLock.Lock()
+	defer recordLock.Unlock()
+
+	return recordFile != nil
+}
+
+// Adds an entry for the current turn and round. Does nothing unless recording.
+// Each entry is written straight away so that a crash doesn't lose the lines leading up to it.
+func Record(e Entry) {
+
+	recordLock.Lock()
+	defer recordLock.Unlock()
+
+	if recordFile == nil {
+		return
+	}
+
+	e.Turn = util.GetTurnCount()
+	e.Round = util.GetRoundCount()
+
+	if err := writeLine(recordFile, e); err != nil {
+		mudlog.Error("Journal", "path", recordPath, "error", err)
+	}
+}
+
+func Stop() error {
+
+	recordLock.Lock()
+	defer recordLock.Unlock()
+
+	if recordFile == nil {
+		return nil
+	}
+
+	err := recordFile.Close()
+	recordFile = nil
+
+	mudlog.Info("Journal", "action", "stopped", "path", recordPath)
+
+	return err
+}
+
+func writeLine(f *os.File, v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Reads a journal. A partly written last line (say, from a crash) is ignored.
+func Load(path string) (Header, []Entry, error) {
+
+	h := Header{}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return h, nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return h, nil, err
+		}
+		return h, nil, errors.New("journal is empty")
+	}
+
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return h, nil, fmt.Errorf("header: %w", err)
+	}
+
+	if h.Version != Version {
+		return h, nil, fmt.Errorf("journal version %d, expected %d", h.Version, Version)
+	}
+
+	entries := []Entry{}
+
+	lineNum := 1
+	for scanner.Scan() {
+		lineNum++
+
+		e := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			mudlog.Warn("Journal", "path", path, "line", lineNum, "error", err.Error())
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	return h, entries, scanner.Err()
+}
@@ -0,0 +1,87 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func TestRecordAndLoad(t *testing.T) {
+
+	dir := t.TempDir()
+	dataFiles := filepath.Join(dir, `world`)
+	require.NoError(t, os.MkdirAll(filepath.Join(dataFiles, `users`), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataFiles, `users`, `1.yaml`), []byte(`username: bob`), 0644))
+
+	path := filepath.Join(dir, `bug.jsonl`)
+
+	// Nothing is written until recording starts
+	Record(Entry{Kind: KindLeave, UserId: 1})
+
+	require.NoError(t, Start(path, dataFiles, Header{Seed: 42, Turn: 7, Round: 100, TurnMs: 50, TurnsPerRound: 80}))
+	assert.True(t, Recording())
+
+	// One recording at a time
+	assert.Error(t, Start(path+`2`, dataFiles, Header{}))
+
+	util.SetTurnCount(9)
+	util.SetRoundCount(101)
+
+	Record(Entry{Kind: KindEnter, UserId: 1, Username: `bob`, RoomId: 2})
+	Record(Entry{Kind: KindInput, UserId: 1, Input: &events.Input{UserId: 1, InputText: `look`, ReadyTurn: 9}})
+
+	require.NoError(t, Stop())
+	assert.False(t, Recording())
+
+	h, entries, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), h.Seed)
+	assert.Equal(t, uint64(7), h.Turn)
+	assert.Equal(t, path+SnapshotSuffix, h.Snapshot)
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{Turn: 9, Round: 101, Kind: KindEnter, UserId: 1, Username: `bob`, RoomId: 2}, entries[0])
+	assert.Equal(t, `look`, entries[1].Input.InputText)
+
+	// The snapshot is a copy of the data files
+	b, err := os.ReadFile(filepath.Join(h.Snapshot, `users`, `1.yaml`))
+	require.NoError(t, err)
+	assert.Equal(t, `username: bob`, string(b))
+
+	// Existing journals aren't overwritten
+	require.NoError(t, os.RemoveAll(h.Snapshot))
+	assert.Error(t, Start(path, dataFiles, Header{}))
+}
+
+func TestSnapshotInsideDataFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.Error(t, Snapshot(dir, filepath.Join(dir, `snapshot`)))
+}
+
+func TestLoadBadJournal(t *testing.T) {
+	dir := t.TempDir()
+
+	empty := filepath.Join(dir, `empty.jsonl`)
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+	_, _, err := Load(empty)
+	assert.Error(t, err)
+
+	// A crash can leave half a line at the end
+	partial := filepath.Join(dir, `partial.jsonl`)
+	require.NoError(t, os.WriteFile(partial, []byte("{\"version\":1}\n{\"turn\":3,\"kind\":\"leave\",\"userid\":1}\n{\"turn\":4,\"ki"), 0644))
+	_, entries, err := Load(partial)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
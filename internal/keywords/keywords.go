@@ -39,6 +39,12 @@ type Aliases struct {
 	commandAliases map[string]string
 	// Converted strings to runes
 	mapLegendOverrides map[string]map[rune]string
+
+	// What AddCommand() added, so RemoveAddedCommands() can take it out again.
+	// Help topics map to the topic they replaced, or nil.
+	addedHelpTopics     map[string]*HelpTopic
+	addedHelpAliases    map[string]struct{}
+	addedCommandAliases map[string]struct{}
 }
 
 // Presumably to ensure the datafile hasn't messed something up.
@@ -69,6 +75,9 @@ func (a *Aliases) Validate() error {
 	a.helpAliases = map[string]string{}
 	a.commandAliases = map[string]string{}
 	a.mapLegendOverrides = map[string]map[rune]string{}
+	a.addedHelpTopics = map[string]*HelpTopic{}
+	a.addedHelpAliases = map[string]struct{}{}
+	a.addedCommandAliases = map[string]struct{}{}
 
 	for _, ma := range mergeAliases {
 
@@ -226,6 +235,70 @@ func TryHelpAlias(input string) string {
 	return input
 }
 
+// Adds a help topic and aliases for a command that isn't in keywords.yaml, such as one a script defines.
+// Aliases already in use are left alone. RemoveAddedCommands() takes it all out again.
+func AddCommand(command string, category string, adminOnly bool, aliases ...string) {
+
+	command = strings.ToLower(command)
+
+	helpGroup := `command`
+	if adminOnly {
+		helpGroup = `admin`
+	}
+
+	if _, ok := loadedKeywords.addedHelpTopics[command]; !ok {
+		var replaced *HelpTopic
+		if topic, ok := loadedKeywords.helpTopics[command]; ok {
+			replaced = &topic
+		}
+		loadedKeywords.addedHelpTopics[command] = replaced
+	}
+
+	loadedKeywords.helpTopics[command] = HelpTopic{
+		Command:   command,
+		Type:      helpGroup,
+		Category:  strings.ToLower(category),
+		AdminOnly: adminOnly,
+	}
+
+	for _, alias := range aliases {
+		alias = strings.ToLower(alias)
+		if _, ok := loadedKeywords.commandAliases[alias]; !ok {
+			loadedKeywords.commandAliases[alias] = command
+			loadedKeywords.addedCommandAliases[alias] = struct{}{}
+		}
+		if _, ok := loadedKeywords.helpAliases[alias]; !ok {
+			loadedKeywords.helpAliases[alias] = command
+			loadedKeywords.addedHelpAliases[alias] = struct{}{}
+		}
+	}
+}
+
+// Undoes every AddCommand() since the keywords were loaded, putting back any help topics it replaced.
+// Lets a caller re-add its commands without leaving behind ones that are gone.
+func RemoveAddedCommands() {
+
+	for command, replaced := range loadedKeywords.addedHelpTopics {
+		if replaced != nil {
+			loadedKeywords.helpTopics[command] = *replaced
+		} else {
+			delete(loadedKeywords.helpTopics, command)
+		}
+	}
+
+	for alias := range loadedKeywords.addedCommandAliases {
+		delete(loadedKeywords.commandAliases, alias)
+	}
+
+	for alias := range loadedKeywords.addedHelpAliases {
+		delete(loadedKeywords.helpAliases, alias)
+	}
+
+	clear(loadedKeywords.addedHelpTopics)
+	clear(loadedKeywords.addedCommandAliases)
+	clear(loadedKeywords.addedHelpAliases)
+}
+
 // Loads the ansi aliases from the config file
 // Only if the file has been modified since the last load
 func LoadAliases(f ...fileloader.ReadableGroupFS) {
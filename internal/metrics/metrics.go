@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//
+// Server metrics in the Prometheus text format, without any outside dependency.
+// Packages add a collector (usually from an init() in their metrics.go) that reports their current values.
+// Collectors are only run when metrics are requested, so they read whatever the package already tracks.
+//
+
+type Type string
+
+const (
+	Counter Type = `counter`
+	Gauge   Type = `gauge`
+	Summary Type = `summary` // Only _sum and _count, no quantiles
+)
+
+// One line of output. Labels are name/value pairs, e.g. "type", "NewRound"
+type Sample struct {
+	Suffix string // "_sum" or "_count" for summaries
+	Labels []string
+	Value  float64
+}
+
+type Metric struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+type Collector func() []Metric
+
+var (
+	collectors []Collector
+)
+
+func NewCounter(name string, help string) Metric {
+	return Metric{Name: name, Help: help, Type: Counter}
+}
+
+func NewGauge(name string, help string) Metric {
+	return Metric{Name: name, Help: help, Type: Gauge}
+}
+
+func NewSummary(name string, help string) Metric {
+	return Metric{Name: name, Help: help, Type: Summary}
+}
+
+// Adds a value to a counter or gauge
+func (m *Metric) Add(value float64, labels ...string) {
+	m.Samples = append(m.Samples, Sample{Labels: labels, Value: value})
+}
+
+// Adds the total and count of observations to a summary
+func (m *Metric) Observed(sum float64, count float64, labels ...string) {
+	m.Samples = append(m.Samples,
+		Sample{Suffix: `_sum`, Labels: labels, Value: sum},
+		Sample{Suffix: `_count`, Labels: labels, Value: count},
+	)
+}
+
+func AddCollector(c Collector) {
+	collectors = append(collectors, c)
+}
+
+// Runs every collector and returns the metrics sorted by name
+func Gather() []Metric {
+
+	result := []Metric{}
+	for _, c := range collectors {
+		result = append(result, c()...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// Writes metrics in the Prometheus text exposition format (version 0.0.4)
+func Write(w io.Writer, all []Metric) error {
+
+	bw := bufio.NewWriter(w)
+
+	for _, m := range all {
+
+		bw.WriteString(`# HELP ` + m.Name + ` ` + escapeHelp(m.Help) + "\n")
+		bw.WriteString(`# TYPE ` + m.Name + ` ` + string(m.Type) + "\n")
+
+		for _, s := range m.Samples {
+			bw.WriteString(m.Name + s.Suffix)
+			writeLabels(bw, s.Labels)
+			bw.WriteString(` ` + strconv.FormatFloat(s.Value, 'g', -1, 64) + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeLabels(bw *bufio.Writer, labels []string) {
+
+	if len(labels) < 2 {
+		return
+	}
+
+	bw.WriteString(`{`)
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i > 0 {
+			bw.WriteString(`,`)
+		}
+		bw.WriteString(labels[i] + `="` + escapeLabel(labels[i+1]) + `"`)
+	}
+	bw.WriteString(`}`)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func goCollector() []Metric {
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	goroutines := NewGauge(`gomud_go_goroutines`, `Number of goroutines that currently exist.`)
+	goroutines.Add(float64(runtime.NumGoroutine()))
+
+	heap := NewGauge(`gomud_go_heap_alloc_bytes`, `Bytes of allocated heap objects.`)
+	heap.Add(float64(m.HeapAlloc))
+
+	gcs := NewCounter(`gomud_go_gc_total`, `Number of completed GC cycles.`)
+	gcs.Add(float64(m.NumGC))
+
+	return []Metric{goroutines, heap, gcs}
+}
+
+func init() {
+	AddCollector(goCollector)
+}
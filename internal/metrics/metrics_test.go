@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+
+	gauge := NewGauge(`test_connections`, `Open connections.`)
+	gauge.Add(3, `kind`, `telnet`)
+	gauge.Add(1, `kind`, `web"socket`)
+
+	summary := NewSummary(`test_duration_seconds`, "Time taken.\nSecond line.")
+	summary.Observed(1.5, 4)
+
+	counter := NewCounter(`test_total`, `Things done.`)
+	counter.Add(12345678)
+
+	var sb strings.Builder
+	if err := Write(&sb, []Metric{gauge, summary, counter}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP test_connections Open connections.
+# TYPE test_connections gauge
+test_connections{kind="telnet"} 3
+test_connections{kind="web\"socket"} 1
+# HELP test_duration_seconds Time taken.\nSecond line.
+# TYPE test_duration_seconds summary
+test_duration_seconds_sum 1.5
+test_duration_seconds_count 4
+# HELP test_total Things done.
+# TYPE test_total counter
+test_total 1.2345678e+07
+`
+	if got := sb.String(); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGather(t *testing.T) {
+
+	AddCollector(func() []Metric {
+		return []Metric{NewGauge(`aaa_first`, ``)}
+	})
+
+	all := Gather()
+	if len(all) < 2 || all[0].Name != `aaa_first` {
+		t.Fatalf("Gather() did not sort by name: %v", all)
+	}
+
+	found := false
+	for _, m := range all {
+		if m.Name == `gomud_go_goroutines` {
+			found = len(m.Samples) == 1 && m.Samples[0].Value > 0
+		}
+	}
+	if !found {
+		t.Error("Go runtime metrics missing")
+	}
+}
@@ -0,0 +1,15 @@
+package mobs
+
+import "github.com/GoMudEngine/GoMud/internal/metrics"
+
+func collectMetrics() []metrics.Metric {
+
+	instances := metrics.NewGauge(`gomud_mob_instances`, `Mobs spawned in the world.`)
+	instances.Add(float64(len(mobInstances)))
+
+	return []metrics.Metric{instances}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -2,8 +2,11 @@ package mobs
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
@@ -69,6 +72,114 @@ func CreateNewMobFile(newMobInfo Mob, copyScript string) (MobId, error) {
 	return newMobInfo.MobId, nil
 }
 
+// Overwrites an existing mob file with new mob data.
+// A new name or zone moves the file (and any scripts) to match.
+func UpdateMobFile(mobInfo Mob) error {
+
+	oldMob, ok := mobs[int(mobInfo.MobId)]
+	if !ok {
+		return fmt.Errorf(`mob %d not found`, mobInfo.MobId)
+	}
+
+	// Descriptions are hashed in memory, but the file needs the text.
+	mobInfo.Character.Description = mobInfo.Character.GetDescription()
+
+	if err := mobInfo.Validate(); err != nil {
+		return err
+	}
+
+	mobsPath := configs.GetFilePathsConfig().DataFiles.String() + `/mobs`
+
+	oldName := mobNameCache[mobInfo.MobId]
+	oldFilepath := oldMob.Filepath()
+	oldScriptPath := oldMob.GetScriptPath()
+
+	// The filename follows the name, so it has to be current before saving.
+	mobNameCache[mobInfo.MobId] = mobInfo.Character.Name
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*Mob](mobsPath, &mobInfo, saveModes...); err != nil {
+		mobNameCache[mobInfo.MobId] = oldName
+		return err
+	}
+
+	if oldFilepath != mobInfo.Filepath() {
+
+		os.Remove(util.FilePath(mobsPath, `/`, oldFilepath))
+
+		// Scripts are named after the yaml file, with an optional -{ScriptTag}
+		oldScriptDir := filepath.Dir(oldScriptPath)
+		newScriptDir := filepath.Dir(mobInfo.GetScriptPath())
+		oldBase := strings.TrimSuffix(filepath.Base(oldFilepath), `.yaml`)
+		newBase := strings.TrimSuffix(mobInfo.Filename(), `.yaml`)
+
+		scriptFiles, _ := filepath.Glob(filepath.Join(oldScriptDir, oldBase+`-*.js`))
+		if _, err := os.Stat(filepath.Join(oldScriptDir, oldBase+`.js`)); err == nil {
+			scriptFiles = append(scriptFiles, filepath.Join(oldScriptDir, oldBase+`.js`))
+		}
+
+		for _, scriptFile := range scriptFiles {
+			os.MkdirAll(newScriptDir, os.ModePerm)
+			newScriptFile := filepath.Join(newScriptDir, newBase+strings.TrimPrefix(filepath.Base(scriptFile), oldBase))
+			if err := os.Rename(scriptFile, newScriptFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	if oldName != mobInfo.Character.Name {
+		if idx := slices.Index(allMobNames, oldName); idx >= 0 {
+			allMobNames[idx] = mobInfo.Character.Name
+		}
+	}
+
+	mobInfo.Character.CacheDescription()
+	mobs[mobInfo.Id()] = &mobInfo
+
+	return nil
+}
+
+// Removes a mob file (and any scripts) and forgets the mob.
+// Mobs that are currently spawned must be gone first.
+func DeleteMobFile(mobId MobId) error {
+
+	mobInfo, ok := mobs[int(mobId)]
+	if !ok {
+		return fmt.Errorf(`mob %d not found`, mobId)
+	}
+
+	for _, m := range mobInstances {
+		if m.MobId == mobId {
+			return fmt.Errorf(`mob %d has spawned instances in the world`, mobId)
+		}
+	}
+
+	mobsPath := configs.GetFilePathsConfig().DataFiles.String() + `/mobs`
+
+	if err := os.Remove(util.FilePath(mobsPath, `/`, mobInfo.Filepath())); err != nil {
+		return err
+	}
+
+	scriptDir := filepath.Dir(mobInfo.GetScriptPath())
+	baseName := strings.TrimSuffix(mobInfo.Filename(), `.yaml`)
+	scriptFiles, _ := filepath.Glob(filepath.Join(scriptDir, baseName+`-*.js`))
+	for _, scriptFile := range append(scriptFiles, filepath.Join(scriptDir, baseName+`.js`)) {
+		os.Remove(scriptFile)
+	}
+
+	if idx := slices.Index(allMobNames, mobNameCache[mobId]); idx >= 0 {
+		allMobNames = slices.Delete(allMobNames, idx, idx+1)
+	}
+	delete(mobNameCache, mobId)
+	delete(mobs, int(mobId))
+
+	return nil
+}
+
 func getNextMobId() MobId {
 
 	lowestFreeId := MobId(0)
@@ -0,0 +1,88 @@
+package mutators
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+var (
+	// Mutator ids double as filenames
+	validMutatorId = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+func CreateNewMutatorFile(newMutatorInfo MutatorSpec) error {
+
+	if !validMutatorId.MatchString(newMutatorInfo.MutatorId) {
+		return errors.New(`mutator id must be lowercase letters, numbers or underscores`)
+	}
+
+	if _, ok := allMutators[newMutatorInfo.MutatorId]; ok {
+		return fmt.Errorf(`mutator %s already exists`, newMutatorInfo.MutatorId)
+	}
+
+	if err := newMutatorInfo.Validate(); err != nil {
+		return err
+	}
+
+	if err := saveMutatorFile(&newMutatorInfo); err != nil {
+		return err
+	}
+
+	allMutators[newMutatorInfo.Id()] = &newMutatorInfo
+
+	return nil
+}
+
+// Overwrites an existing mutator file with new spec data.
+func UpdateMutatorFile(mutatorInfo MutatorSpec) error {
+
+	if _, ok := allMutators[mutatorInfo.MutatorId]; !ok {
+		return fmt.Errorf(`mutator %s not found`, mutatorInfo.MutatorId)
+	}
+
+	if err := mutatorInfo.Validate(); err != nil {
+		return err
+	}
+
+	if err := saveMutatorFile(&mutatorInfo); err != nil {
+		return err
+	}
+
+	allMutators[mutatorInfo.Id()] = &mutatorInfo
+
+	return nil
+}
+
+// Removes a mutator file and forgets the spec.
+// Callers must make sure no room or zone still uses it.
+func DeleteMutatorFile(mutatorId string) error {
+
+	mutatorInfo, ok := allMutators[mutatorId]
+	if !ok {
+		return fmt.Errorf(`mutator %s not found`, mutatorId)
+	}
+
+	if err := os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/mutators/`, mutatorInfo.Filepath())); err != nil {
+		return err
+	}
+
+	delete(allMutators, mutatorId)
+
+	return nil
+}
+
+func saveMutatorFile(mutatorInfo *MutatorSpec) error {
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	return fileloader.SaveFlatFile[*MutatorSpec](configs.GetFilePathsConfig().DataFiles.String()+`/mutators`, mutatorInfo, saveModes...)
+}
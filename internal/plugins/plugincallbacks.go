@@ -13,9 +13,13 @@ type PluginCallbacks struct {
 	scriptCommands map[string]map[string]any
 
 	iacHandler   func(uint64, []byte) bool
+	oobHandler   func(uint64, []byte) bool
 	onLoad       func()
 	onSave       func()
 	onNetConnect func(NetConnection)
+
+	copyoverSave    func(uint64) []byte
+	copyoverRestore func(uint64, []byte)
 }
 
 func newPluginCallbacks() PluginCallbacks {
@@ -40,6 +44,12 @@ func (c *PluginCallbacks) SetIACHandler(f func(uint64, []byte) bool) {
 	c.iacHandler = f
 }
 
+// Websocket clients have no IAC, so out-of-band messages arrive as
+// prefixed text frames such as `!!GMCP(Core.Hello {...})`
+func (c *PluginCallbacks) SetOOBHandler(f func(uint64, []byte) bool) {
+	c.oobHandler = f
+}
+
 func (c *PluginCallbacks) SetOnLoad(f func()) {
 	c.onLoad = f
 }
@@ -51,3 +61,11 @@ func (c *PluginCallbacks) SetOnSave(f func()) {
 func (c *PluginCallbacks) SetOnNetConnect(f func(NetConnection)) {
 	c.onNetConnect = f
 }
+
+// Carries per-connection state (such as negotiated protocols) across a copyover.
+// save returns nil if there is nothing to keep for that connection.
+// restore is called in place of the NetConnect callback for connections that survived.
+func (c *PluginCallbacks) SetCopyoverHandlers(save func(uint64) []byte, restore func(uint64, []byte)) {
+	c.copyoverSave = save
+	c.copyoverRestore = restore
+}
@@ -115,6 +115,24 @@ func (p pluginRegistry) GetExportedFunction(funcName string) (any, bool) {
 	return nil, false
 }
 
+// The functions every plugin has added for scripts, by namespace and then function name.
+// These are the same ones Load() hands to the scripting package.
+func (p pluginRegistry) ScriptingFunctions() map[string]map[string]any {
+
+	allFuncs := map[string]map[string]any{}
+
+	for _, pItem := range p {
+		for nameSpace, funcMap := range pItem.Callbacks.scriptCommands {
+			if _, ok := allFuncs[nameSpace]; !ok {
+				allFuncs[nameSpace] = map[string]any{}
+			}
+			maps.Copy(allFuncs[nameSpace], funcMap)
+		}
+	}
+
+	return allFuncs
+}
+
 // Receive functions to satisfy the web.WebPlugin interface
 func (p pluginRegistry) NavLinks() map[string]string {
 
@@ -141,6 +159,20 @@ func (p pluginRegistry) HandleIAC(connectionId uint64, iacCmd []byte) bool {
 	return false
 }
 
+func (p pluginRegistry) HandleOOB(connectionId uint64, oobCmd []byte) bool {
+
+	for _, pItem := range p {
+		if pItem.Callbacks.oobHandler == nil {
+			continue
+		}
+		if pItem.Callbacks.oobHandler(connectionId, oobCmd) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p pluginRegistry) WebRequest(r *http.Request) (html string, templateData map[string]any, ok bool) {
 
 	reqPath := filepath.Clean(r.URL.Path) // Example: / or /info/faq
@@ -171,6 +203,21 @@ func (p pluginRegistry) WebRequest(r *http.Request) (html string, templateData m
 	return html, templateData, false
 }
 
+func (p pluginRegistry) ApiRequest(name string, r *http.Request) (data any, ok bool) {
+
+	for _, pItem := range p {
+
+		dataFunc, ok := pItem.Web.apiData[name]
+		if !ok {
+			continue
+		}
+
+		return dataFunc(r), true
+	}
+
+	return nil, false
+}
+
 // Iterator for all plugin file systems.
 // This allows you to process each one individually.
 func (p pluginRegistry) AllFileSubSystems(yield func(fs.ReadFileFS) bool) {
@@ -509,6 +556,37 @@ func OnNetConnect(n NetConnection) {
 
 }
 
+// Collects per-connection state from plugins ahead of a copyover, keyed by plugin name
+func CopyoverSave(connectionId uint64) map[string][]byte {
+
+	data := map[string][]byte{}
+
+	for _, p := range registry {
+		if p.Callbacks.copyoverSave == nil {
+			continue
+		}
+		if b := p.Callbacks.copyoverSave(connectionId); b != nil {
+			data[p.name] = b
+		}
+	}
+
+	return data
+}
+
+// Hands per-connection state back to the plugins that saved it
+func CopyoverRestore(connectionId uint64, data map[string][]byte) {
+
+	for _, p := range registry {
+		if p.Callbacks.copyoverRestore == nil {
+			continue
+		}
+		if b, ok := data[p.name]; ok {
+			p.Callbacks.copyoverRestore(connectionId, b)
+		}
+	}
+
+}
+
 func ReadFile(dfPath string) ([]byte, error) {
 	return registry.ReadFile(dfPath)
 }
@@ -3,8 +3,9 @@ package plugins
 import "net/http"
 
 type WebConfig struct {
-	navLinks map[string]string  // name=>path
-	pages    map[string]WebPage // path=>WebPage
+	navLinks map[string]string                    // name=>path
+	pages    map[string]WebPage                   // path=>WebPage
+	apiData  map[string]func(r *http.Request) any // name=>data function
 }
 
 type WebPage struct {
@@ -18,6 +19,7 @@ func newWebConfig() WebConfig {
 	return WebConfig{
 		navLinks: map[string]string{},
 		pages:    map[string]WebPage{},
+		apiData:  map[string]func(r *http.Request) any{},
 	}
 }
 
@@ -37,3 +39,9 @@ func (w *WebConfig) WebPage(name string, path string, file string, addToNav bool
 	}
 
 }
+
+// Exposes data to the JSON api, at /api/v1/{name}
+// Whatever is returned is encoded as JSON.
+func (w *WebConfig) ApiData(name string, dataFunc func(r *http.Request) any) {
+	w.apiData[name] = dataFunc
+}
@@ -0,0 +1,101 @@
+package races
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func CreateNewRaceFile(newRaceInfo Race) (int, error) {
+
+	newRaceInfo.RaceId = getNextRaceId()
+
+	if err := newRaceInfo.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := saveRaceFile(&newRaceInfo); err != nil {
+		return 0, err
+	}
+
+	races[newRaceInfo.Id()] = &newRaceInfo
+
+	return newRaceInfo.RaceId, nil
+}
+
+// Overwrites an existing race file with new race data.
+// A new name renames the file to match.
+func UpdateRaceFile(raceInfo Race) error {
+
+	oldRace, ok := races[raceInfo.RaceId]
+	if !ok {
+		return fmt.Errorf(`race %d not found`, raceInfo.RaceId)
+	}
+
+	if err := raceInfo.Validate(); err != nil {
+		return err
+	}
+
+	oldFilepath := oldRace.Filepath()
+
+	if err := saveRaceFile(&raceInfo); err != nil {
+		return err
+	}
+
+	if oldFilepath != raceInfo.Filepath() {
+		os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/races/`, oldFilepath))
+	}
+
+	races[raceInfo.Id()] = &raceInfo
+
+	return nil
+}
+
+// Removes a race file and forgets the race.
+// Callers must make sure nothing still uses the race.
+func DeleteRaceFile(raceId int) error {
+
+	raceInfo, ok := races[raceId]
+	if !ok {
+		return fmt.Errorf(`race %d not found`, raceId)
+	}
+
+	if err := os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/races/`, raceInfo.Filepath())); err != nil {
+		return err
+	}
+
+	delete(races, raceId)
+
+	return nil
+}
+
+func saveRaceFile(raceInfo *Race) error {
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	// Like items, only the dice roll needs to be written.
+	saveInfo := *raceInfo
+	saveInfo.Damage.Attacks = 0
+	saveInfo.Damage.DiceCount = 0
+	saveInfo.Damage.SideCount = 0
+
+	return fileloader.SaveFlatFile[*Race](configs.GetFilePathsConfig().DataFiles.String()+`/races`, &saveInfo, saveModes...)
+}
+
+func getNextRaceId() int {
+
+	lowestFreeId := 1
+	for _, rInfo := range races {
+		if rInfo.RaceId >= lowestFreeId {
+			lowestFreeId = rInfo.RaceId + 1
+		}
+	}
+
+	return lowestFreeId
+}
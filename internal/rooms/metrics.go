@@ -0,0 +1,21 @@
+package rooms
+
+import "github.com/GoMudEngine/GoMud/internal/metrics"
+
+func collectMetrics() []metrics.Metric {
+
+	loaded := metrics.NewGauge(`gomud_rooms_loaded`, `Rooms loaded in memory.`)
+	loaded.Add(float64(len(roomManager.rooms)))
+
+	total := metrics.NewGauge(`gomud_rooms_total`, `Rooms that exist, loaded or not.`)
+	total.Add(float64(len(roomManager.roomIdToFileCache)))
+
+	chunks := metrics.NewGauge(`gomud_ephemeral_chunks`, `Ephemeral room chunks in use.`)
+	chunks.Add(float64(GetChunkCount()))
+
+	return []metrics.Metric{loaded, total, chunks}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -134,6 +134,20 @@ func GetAllRoomIds() []int {
 	return roomIds
 }
 
+// The file of every room that isn't in memory, by room id.
+// For reading them with LoadRoomInstanceFile() after letting go of the MUD lock.
+func GetUnloadedRoomFiles() map[int]string {
+
+	roomFiles := map[int]string{}
+	for roomId, filename := range roomManager.roomIdToFileCache {
+		if _, ok := roomManager.rooms[roomId]; !ok {
+			roomFiles[roomId] = filename
+		}
+	}
+
+	return roomFiles
+}
+
 func GetZonesWithMutators() ([]string, []int) {
 
 	zNames := []string{}
@@ -788,6 +802,78 @@ func ConnectRoom(fromRoomId int, toRoomId int, exitName string, mapDirection ...
 	return nil
 }
 
+// Adds a room made with NewRoom() to an existing zone, unconnected to anything.
+func AddNewRoom(newRoom *Room) error {
+
+	if _, ok := roomManager.zones[newRoom.Zone]; !ok {
+		return fmt.Errorf(`zone %s does not exist`, newRoom.Zone)
+	}
+
+	if _, ok := roomManager.rooms[newRoom.RoomId]; ok || roomManager.GetFilePath(newRoom.RoomId) != `` {
+		return fmt.Errorf(`room %d already exists`, newRoom.RoomId)
+	}
+
+	if err := newRoom.Validate(); err != nil {
+		return err
+	}
+
+	if err := addRoomToMemory(newRoom); err != nil {
+		return err
+	}
+
+	return SaveRoomTemplate(*newRoom)
+}
+
+// Deletes a room template and instance from disk and memory.
+// Zone roots, occupied rooms and rooms that other rooms still have exits to are refused.
+func DeleteRoom(roomId int) error {
+
+	room := LoadRoom(roomId)
+	if room == nil || room.RoomId != roomId {
+		return fmt.Errorf(`room %d not found`, roomId)
+	}
+
+	if room.IsEphemeral() {
+		return errors.New(`ephemeral rooms are not saved`)
+	}
+
+	if zoneInfo, ok := roomManager.zones[room.Zone]; ok && zoneInfo.RoomId == roomId {
+		return fmt.Errorf(`room %d is the root of zone %s`, roomId, room.Zone)
+	}
+
+	if len(room.players) > 0 {
+		return fmt.Errorf(`room %d has players in it`, roomId)
+	}
+
+	for _, otherRoomId := range GetAllRoomIds() {
+		if otherRoomId == roomId {
+			continue
+		}
+		if otherRoom := LoadRoomTemplate(otherRoomId); otherRoom != nil {
+			for exitName, exitInfo := range otherRoom.Exits {
+				if exitInfo.RoomId == roomId {
+					return fmt.Errorf(`room %d has a "%s" exit to room %d`, otherRoomId, exitName, roomId)
+				}
+			}
+		}
+	}
+
+	for _, mobInstanceId := range room.mobs {
+		mobs.DestroyInstance(mobInstanceId)
+	}
+
+	roomFilePath := roomManager.GetFilePath(roomId)
+
+	if err := os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/rooms/`, roomFilePath)); err != nil {
+		return err
+	}
+	os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/rooms.instances/`, roomFilePath))
+
+	ClearRoomCache(roomId)
+
+	return nil
+}
+
 func GetRoomCount(zoneName string) int {
 
 	zoneInfo, ok := roomManager.zones[zoneName]
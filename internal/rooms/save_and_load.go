@@ -99,8 +99,7 @@ func LoadRoom(roomId int) *Room {
 // See: B. LOADING ROOMS FROM FILES
 func LoadRoomInstance(roomId int) *Room {
 
-	room := LoadRoomTemplate(roomId)
-	if room == nil {
+	if roomId >= ephemeralRoomIdMinimum {
 		return nil
 	}
 
@@ -110,6 +109,18 @@ func LoadRoomInstance(roomId int) *Room {
 		return nil
 	}
 
+	return LoadRoomInstanceFile(filename)
+}
+
+// The same as LoadRoomInstance(), for a file named by GetUnloadedRoomFiles().
+// Only reads files, so it doesn't need the MUD lock.
+func LoadRoomInstanceFile(filename string) *Room {
+
+	room, _ := loadRoomFromFile(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/rooms/`, filename))
+	if room == nil {
+		return nil
+	}
+
 	// Look for specially saved instance data
 	filepath := util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/rooms.instances/`, filename)
 
@@ -0,0 +1,22 @@
+package rooms
+
+// A persistent timer set by the room's script. The scripting package runs it.
+type ScriptTimer struct {
+	TimerId  int    `yaml:"-"`                  // Id of the running timer. Zero until it's been started after loading.
+	Function string `yaml:"function"`           // Name of the room script's function to call
+	DueRound uint64 `yaml:"dueround"`           // Round it's next due to run
+	Interval uint64 `yaml:"interval,omitempty"` // Turns between runs, or zero to run once
+}
+
+// Rooms in memory that have persistent script timers
+func GetRoomsWithScriptTimers() []int {
+
+	roomIds := []int{}
+	for roomId, room := range roomManager.rooms {
+		if len(room.ScriptTimers) > 0 {
+			roomIds = append(roomIds, roomId)
+		}
+	}
+
+	return roomIds
+}
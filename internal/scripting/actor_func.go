@@ -800,6 +800,34 @@ func (a ScriptActor) PathingAtWaypoint() bool {
 	return pathStep != nil && pathStep.Waypoint()
 }
 
+func (a ScriptActor) SetTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(a.timerOwner(vm), call, vm, turnsPerRound(), false)
+}
+
+func (a ScriptActor) SetInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(a.timerOwner(vm), call, vm, turnsPerRound(), true)
+}
+
+func (a ScriptActor) SetTurnTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(a.timerOwner(vm), call, vm, 1, false)
+}
+
+func (a ScriptActor) SetTurnInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(a.timerOwner(vm), call, vm, 1, true)
+}
+
+func (a ScriptActor) ClearTimer(timerId int) bool {
+	return clearTimer(scriptTimer{kind: timerMob, mobInstanceId: a.mobInstanceId}, timerId)
+}
+
+// Only mobs have timers. Users come and go, and have no script of their own.
+func (a ScriptActor) timerOwner(vm *goja.Runtime) scriptTimer {
+	if a.mobInstanceId == 0 {
+		panic(vm.NewTypeError(`only mobs can have timers`))
+	}
+	return scriptTimer{kind: timerMob, mobInstanceId: a.mobInstanceId}
+}
+
 // ////////////////////////////////////////////////////////
 //
 // # These functions get exported to the scripting engine
@@ -0,0 +1,81 @@
+package scripting
+
+import (
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/combat"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+// Fires the combat events for an attack that's been worked out, but hasn't done any damage yet.
+// The attacker's equipped items (and script, if it's a mob) get onAttack, then onHit or onMiss, then onCrit.
+// The defender's get onDefend. Once a script cancels the attack, no more events are fired for it.
+func TryAttackEvents(sourceUserId int, sourceMobInstanceId int, targetUserId int, targetMobInstanceId int, attackResult *combat.AttackResult) {
+
+	sAttack := newScriptAttack(sourceUserId, sourceMobInstanceId, targetUserId, targetMobInstanceId, attackResult)
+	if sAttack == nil {
+		return
+	}
+
+	timestart := time.Now()
+	defer func() {
+		mudlog.Debug("TryAttackEvents()", "source", sAttack.attacker.GetCharacterName(false), "target", sAttack.defender.GetCharacterName(false), "time", time.Since(timestart))
+	}()
+
+	eventNames := []string{`onAttack`}
+	if attackResult.Hit {
+		eventNames = append(eventNames, `onHit`)
+	} else {
+		eventNames = append(eventNames, `onMiss`)
+	}
+	if attackResult.Crit {
+		eventNames = append(eventNames, `onCrit`)
+	}
+
+	for _, eventName := range eventNames {
+		if !tryCombatEvent(eventName, sAttack.attacker, sAttack.defender, sAttack) {
+			return
+		}
+	}
+
+	tryCombatEvent(`onDefend`, sAttack.defender, sAttack.attacker, sAttack)
+}
+
+// Fires onKill for the equipped items (and script, if it's a mob) of whoever made an attack that killed its target.
+// The attack's damage has already been done, so changing it does nothing.
+func TryKillEvents(sourceUserId int, sourceMobInstanceId int, targetUserId int, targetMobInstanceId int, attackResult *combat.AttackResult) {
+
+	sAttack := newScriptAttack(sourceUserId, sourceMobInstanceId, targetUserId, targetMobInstanceId, attackResult)
+	if sAttack == nil {
+		return
+	}
+
+	tryCombatEvent(`onKill`, sAttack.attacker, sAttack.defender, sAttack)
+}
+
+// Returns false if the attack has been cancelled
+func tryCombatEvent(eventName string, sActor *ScriptActor, sOpponent *ScriptActor, sAttack *ScriptAttack) bool {
+
+	for _, itm := range sActor.characterRecord.Equipment.GetAllItems() {
+		if sAttack.IsCancelled() {
+			return false
+		}
+		tryItemCombatEvent(eventName, itm, sActor, sAttack)
+	}
+
+	if sAttack.IsCancelled() {
+		return false
+	}
+
+	if sActor.mobRecord != nil {
+
+		sourceId, sourceType := sOpponent.userId, `user`
+		if sOpponent.mobRecord != nil {
+			sourceId, sourceType = sOpponent.mobInstanceId, `mob`
+		}
+
+		TryMobScriptEvent(eventName, sActor.mobInstanceId, sourceId, sourceType, map[string]any{`attack`: sAttack})
+	}
+
+	return !sAttack.IsCancelled()
+}
@@ -0,0 +1,111 @@
+package scripting
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/combat"
+)
+
+type ScriptAttack struct {
+	attacker     *ScriptActor
+	defender     *ScriptActor
+	attackResult *combat.AttackResult
+	sent         combat.AttackResult // Messages from scripts, which cancelling the attack keeps
+}
+
+func newScriptAttack(sourceUserId int, sourceMobInstanceId int, targetUserId int, targetMobInstanceId int, attackResult *combat.AttackResult) *ScriptAttack {
+
+	attacker := GetActor(sourceUserId, sourceMobInstanceId)
+	defender := GetActor(targetUserId, targetMobInstanceId)
+	if attacker == nil || defender == nil || attackResult == nil {
+		return nil
+	}
+
+	return &ScriptAttack{
+		attacker:     attacker,
+		defender:     defender,
+		attackResult: attackResult,
+	}
+}
+
+func (a *ScriptAttack) GetAttacker() *ScriptActor {
+	return a.attacker
+}
+
+func (a *ScriptAttack) GetDefender() *ScriptActor {
+	return a.defender
+}
+
+func (a *ScriptAttack) IsHit() bool {
+	return a.attackResult.Hit
+}
+
+func (a *ScriptAttack) IsCrit() bool {
+	return a.attackResult.Crit
+}
+
+func (a *ScriptAttack) IsCancelled() bool {
+	return a.attackResult.Cancelled
+}
+
+func (a *ScriptAttack) Cancel() {
+	a.attackResult.Cancel()
+	a.attackResult.MessagesToSource = append(a.attackResult.MessagesToSource, a.sent.MessagesToSource...)
+	a.attackResult.MessagesToTarget = append(a.attackResult.MessagesToTarget, a.sent.MessagesToTarget...)
+	a.attackResult.MessagesToSourceRoom = append(a.attackResult.MessagesToSourceRoom, a.sent.MessagesToSourceRoom...)
+}
+
+func (a *ScriptAttack) GetDamage() int {
+	return a.attackResult.DamageToTarget
+}
+
+func (a *ScriptAttack) SetDamage(amount int) int {
+	if a.attackResult.Cancelled {
+		return 0
+	}
+
+	if amount < 0 {
+		amount = 0
+	}
+	a.attackResult.DamageToTarget = amount
+
+	return a.attackResult.DamageToTarget
+}
+
+func (a *ScriptAttack) AddDamage(amount int) int {
+	return a.SetDamage(a.attackResult.DamageToTarget + amount)
+}
+
+func (a *ScriptAttack) GetAttackerDamage() int {
+	return a.attackResult.DamageToSource
+}
+
+func (a *ScriptAttack) SetAttackerDamage(amount int) int {
+	if a.attackResult.Cancelled {
+		return 0
+	}
+
+	if amount < 0 {
+		amount = 0
+	}
+	a.attackResult.DamageToSource = amount
+
+	return a.attackResult.DamageToSource
+}
+
+func (a *ScriptAttack) AddAttackerDamage(amount int) int {
+	return a.SetAttackerDamage(a.attackResult.DamageToSource + amount)
+}
+
+func (a *ScriptAttack) SendToAttacker(msg string) {
+	a.attackResult.SendToSource(msg)
+	a.sent.SendToSource(msg)
+}
+
+func (a *ScriptAttack) SendToDefender(msg string) {
+	a.attackResult.SendToTarget(msg)
+	a.sent.SendToTarget(msg)
+}
+
+func (a *ScriptAttack) SendToRoom(msg string) {
+	a.attackResult.SendToSourceRoom(msg)
+	a.sent.SendToSourceRoom(msg)
+}
@@ -0,0 +1,45 @@
+package scripting
+
+import (
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/combat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptAttack_Damage(t *testing.T) {
+
+	result := &combat.AttackResult{Hit: true, DamageToTarget: 10}
+	sAttack := &ScriptAttack{attackResult: result}
+
+	assert.Equal(t, 15, sAttack.AddDamage(5))
+	assert.Equal(t, 0, sAttack.SetDamage(-3))
+	assert.Equal(t, 4, sAttack.AddAttackerDamage(4))
+
+	assert.Equal(t, 0, result.DamageToTarget)
+	assert.Equal(t, 4, result.DamageToSource)
+}
+
+func TestScriptAttack_Cancel(t *testing.T) {
+
+	result := &combat.AttackResult{Hit: true, Crit: true, DamageToTarget: 10, BuffTarget: []int{1}}
+	result.SendToSource(`You hit the rat.`)
+
+	sAttack := &ScriptAttack{attackResult: result}
+	sAttack.SendToAttacker(`Your sword glows.`)
+	sAttack.Cancel()
+	sAttack.SendToDefender(`You dodge.`)
+
+	assert.True(t, sAttack.IsCancelled())
+	assert.False(t, sAttack.IsHit())
+	assert.Equal(t, 0, result.DamageToTarget)
+	assert.Empty(t, result.BuffTarget)
+
+	// Only what scripts sent is kept
+	assert.Equal(t, []string{`Your sword glows.`}, result.MessagesToSource)
+	assert.Equal(t, []string{`You dodge.`}, result.MessagesToTarget)
+
+	// A cancelled attack stays harmless
+	assert.Equal(t, 0, sAttack.SetDamage(5))
+	assert.Equal(t, 0, result.DamageToTarget)
+}
@@ -68,6 +68,7 @@ func TryBuffScriptEvent(eventName string, userId int, mobInstanceId int, buffId
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`buff`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -121,6 +122,7 @@ func TryBuffCommand(cmd string, rest string, userId int, mobInstanceId int, buff
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`buff`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -159,6 +161,7 @@ func TryBuffCommand(cmd string, rest string, userId int, mobInstanceId int, buff
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`buff`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -219,6 +222,7 @@ func getBuffVM(buffId int) (*VMWrapper, error) {
 			mudlog.Error("JSVM", "exception", finalErr)
 			return nil, finalErr
 		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`buff`)
 			mudlog.Error("JSVM", "interrupted", finalErr)
 			return nil, finalErr
 		}
@@ -0,0 +1,261 @@
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/dop251/goja"
+)
+
+const commandFolder = `commands`
+
+var (
+	commandVMCache       = make(map[string]*VMWrapper)
+	commandScripts       = make(map[string]string) // Command name to script path, kept when the VMs are cleared
+	scriptCommandTimeout = 50 * time.Millisecond
+
+	errCommandName = errors.New(`command names must be a single word`)
+)
+
+// What a command script declares in its top level "command" object
+type CommandInfo struct {
+	Name               string   // Defaults to the file name
+	Aliases            []string // Other words that run it
+	Help               string   // Shown by "help <name>"
+	Category           string   // Where it's listed in "help". Defaults to "all"
+	AdminOnly          bool
+	DisabledWhenDowned bool
+}
+
+func ClearCommandVMs() {
+	clear(commandVMCache)
+}
+
+// Loads every script in the world's commands folder (and its sub folders), and returns what they declare.
+// Scripts that fail to load, have no onCommand() or reuse a name are logged and left out.
+func LoadCommands() []CommandInfo {
+
+	ClearCommandVMs()
+	clear(commandScripts)
+
+	commandInfo := []CommandInfo{}
+
+	folder := filepath.Join(configs.GetFilePathsConfig().DataFiles.String(), commandFolder)
+
+	err := filepath.WalkDir(folder, func(scriptPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(scriptPath) != `.js` {
+			return nil
+		}
+
+		info, vmw, err := loadCommand(scriptPath)
+		if err != nil {
+			mudlog.Error("LoadCommands", "file", scriptPath, "error", err)
+			return nil
+		}
+
+		if otherPath, ok := commandScripts[info.Name]; ok {
+			mudlog.Error("LoadCommands", "file", scriptPath, "error", fmt.Sprintf(`command "%s" is already defined in %s`, info.Name, otherPath))
+			return nil
+		}
+
+		commandScripts[info.Name] = scriptPath
+		commandVMCache[info.Name] = vmw
+		commandInfo = append(commandInfo, info)
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		mudlog.Error("LoadCommands", "error", err)
+	}
+
+	sort.Slice(commandInfo, func(i, j int) bool {
+		return commandInfo[i].Name < commandInfo[j].Name
+	})
+
+	mudlog.Info("LoadCommands", "loadedCount", len(commandInfo))
+
+	return commandInfo
+}
+
+// Runs a command script's onCommand(rest, user, room).
+// Anything but returning false counts as handled.
+func TryCommandScript(name string, rest string, userId int) (bool, error) {
+
+	timestart := time.Now()
+	defer func() {
+		mudlog.Debug("TryCommandScript()", "name", name, "userId", userId, "time", time.Since(timestart))
+	}()
+
+	vmw, err := getCommandVM(name)
+	if err != nil {
+		return false, err
+	}
+
+	onCommandFunc, ok := vmw.GetFunction(`onCommand`)
+	if !ok {
+		return false, ErrEventNotFound
+	}
+
+	sUser := GetActor(userId, 0)
+	if sUser == nil {
+		return false, errors.New("user not found")
+	}
+	sRoom := GetRoom(sUser.GetRoomId())
+
+	tmr := time.AfterFunc(scriptCommandTimeout, func() {
+		vmw.VM.Interrupt(errTimeout)
+	})
+	res, err := onCommandFunc(goja.Undefined(),
+		vmw.VM.ToValue(rest),
+		vmw.VM.ToValue(sUser),
+		vmw.VM.ToValue(sRoom),
+	)
+	vmw.VM.ClearInterrupt()
+	tmr.Stop()
+
+	if err != nil {
+
+		// Wrap the error
+		finalErr := fmt.Errorf("%s onCommand(): %w", name, err)
+
+		if _, ok := finalErr.(*goja.Exception); ok {
+			mudlog.Error("JSVM", "exception", finalErr)
+			return false, finalErr
+		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`command`)
+			mudlog.Error("JSVM", "interrupted", finalErr)
+			return false, finalErr
+		}
+
+		mudlog.Error("JSVM", "error", finalErr)
+		return false, finalErr
+	}
+
+	if boolVal, ok := res.Export().(bool); ok {
+		return boolVal, nil
+	}
+
+	return true, nil
+}
+
+func getCommandVM(name string) (*VMWrapper, error) {
+
+	if vmw, ok := commandVMCache[name]; ok {
+		return vmw, nil
+	}
+
+	scriptPath, ok := commandScripts[name]
+	if !ok {
+		return nil, errNoScript
+	}
+
+	_, vmw, err := loadCommand(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	commandVMCache[name] = vmw
+
+	return vmw, nil
+}
+
+func loadCommand(scriptPath string) (CommandInfo, *VMWrapper, error) {
+
+	info := CommandInfo{
+		Name:     strings.ToLower(strings.TrimSuffix(filepath.Base(scriptPath), `.js`)),
+		Category: `all`,
+	}
+
+	script, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return info, nil, err
+	}
+
+	vm := goja.New()
+	setAllScriptingFunctions(vm)
+
+	prg, err := goja.Compile(filepath.ToSlash(filepath.Join(commandFolder, filepath.Base(scriptPath))), string(script), false)
+	if err != nil {
+		return info, nil, fmt.Errorf("Compile: %w", err)
+	}
+
+	//
+	// Run the program
+	//
+	tmr := time.AfterFunc(scriptLoadTimeout, func() {
+		vm.Interrupt(errTimeout)
+	})
+	_, err = vm.RunProgram(prg)
+	vm.ClearInterrupt()
+	tmr.Stop()
+
+	if err != nil {
+		if errors.Is(err, errTimeout) {
+			countTimeout(`command`)
+		}
+		return info, nil, fmt.Errorf("RunProgram: %w", err)
+	}
+
+	vmw := newVMWrapper(vm, 0)
+
+	if _, ok := vmw.GetFunction(`onCommand`); !ok {
+		return info, nil, errors.New(`no onCommand() function`)
+	}
+
+	// "const command = {...}" isn't a property of the global object, so vm.Get() wouldn't find it
+	if declared, err := vm.RunString("(typeof command === 'object') ? command : undefined"); err == nil {
+		if declaredMap, ok := declared.Export().(map[string]any); ok {
+			readCommandInfo(declaredMap, &info)
+		}
+	}
+
+	if info.Name == `` || strings.ContainsAny(info.Name, " \t\r\n") {
+		return info, nil, errCommandName
+	}
+
+	return info, vmw, nil
+}
+
+// Fills in whatever the script declared, ignoring anything of the wrong type
+func readCommandInfo(declared map[string]any, info *CommandInfo) {
+
+	if name, ok := declared[`name`].(string); ok {
+		info.Name = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if aliases, ok := declared[`aliases`].([]any); ok {
+		for _, alias := range aliases {
+			if aliasStr, ok := alias.(string); ok && aliasStr != `` {
+				info.Aliases = append(info.Aliases, strings.ToLower(aliasStr))
+			}
+		}
+	}
+
+	if help, ok := declared[`help`].(string); ok {
+		info.Help = help
+	}
+
+	if category, ok := declared[`category`].(string); ok && category != `` {
+		info.Category = strings.ToLower(category)
+	}
+
+	if adminOnly, ok := declared[`adminOnly`].(bool); ok {
+		info.AdminOnly = adminOnly
+	}
+
+	if disabledWhenDowned, ok := declared[`disabledWhenDowned`].(bool); ok {
+		info.DisabledWhenDowned = disabledWhenDowned
+	}
+}
@@ -0,0 +1,90 @@
+package scripting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Puts command scripts in a temporary world
+func setupCommands(t *testing.T, scripts map[string]string) {
+
+	dir := datafilestest.TempDir(t)
+
+	for name, src := range scripts {
+		path := filepath.Join(dir, commandFolder, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+	}
+
+	t.Cleanup(func() {
+		ClearCommandVMs()
+		clear(commandScripts)
+	})
+}
+
+func TestLoadCommands(t *testing.T) {
+
+	setupCommands(t, map[string]string{
+		`roll.js`: `
+const command = {
+    name: "Roll",
+    aliases: ["dice", 5, "d"],
+    help: "Rolls dice.",
+    category: "Fun",
+    adminOnly: false,
+    disabledWhenDowned: true
+};
+function onCommand(rest, user, room) { return true; }`,
+		`admin/wipe.js`: `
+var command = { adminOnly: true, disabledWhenDowned: "yes" };
+function onCommand(rest, user, room) { return true; }`,
+		`nofunc.js`:    `const command = { name: "nofunc" };`,
+		`broken.js`:    `function onCommand( {`,
+		`twoword.js`:   `const command = { name: "two words" }; function onCommand() {}`,
+		`roll_copy.js`: `const command = { name: "roll" }; function onCommand() {}`,
+		`notes.txt`:    `not a script`,
+		`throws.js`:    `throw new Error("nope"); function onCommand() {}`,
+		`plain.js`:     `function onCommand() {}`,
+		`fun/empty.js`: `function onCommand() {}`,
+	})
+
+	commands := LoadCommands()
+
+	assert.Equal(t, []CommandInfo{
+		{Name: `empty`, Category: `all`},
+		{Name: `plain`, Category: `all`},
+		{Name: `roll`, Aliases: []string{`dice`, `d`}, Help: `Rolls dice.`, Category: `fun`, DisabledWhenDowned: true},
+		{Name: `wipe`, Category: `all`, AdminOnly: true},
+	}, commands)
+}
+
+func TestTryCommandScript_NotLoaded(t *testing.T) {
+
+	setupCommands(t, nil)
+	LoadCommands()
+
+	handled, err := TryCommandScript(`nope`, ``, 1)
+	assert.False(t, handled)
+	assert.ErrorIs(t, err, errNoScript)
+}
+
+func TestGetCommandVM_ReloadsAfterPrune(t *testing.T) {
+
+	setupCommands(t, map[string]string{
+		`wave.js`: `function onCommand() {}`,
+	})
+	LoadCommands()
+
+	PruneVMs(true)
+	assert.Empty(t, commandVMCache)
+
+	vmw, err := getCommandVM(`wave`)
+	require.NoError(t, err)
+	assert.NotNil(t, vmw)
+	assert.Contains(t, commandVMCache, `wave`)
+}
@@ -0,0 +1,146 @@
+package scripting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/dop251/goja"
+)
+
+var (
+	// One VM per admin, so variables and functions they define are still there next time
+	evalVMCache = make(map[int]*goja.Runtime)
+)
+
+type EvalResult struct {
+	Console []string // Lines written with console.log() and friends
+	Value   string   // The value of the last statement, if it had one
+}
+
+func ClearEvalVMs() {
+	clear(evalVMCache)
+}
+
+// Removes the VM of an admin, or every admin that's gone offline when no id is given
+func PruneEvalVMs(userIds ...int) {
+
+	if len(userIds) > 0 {
+		for _, userId := range userIds {
+			delete(evalVMCache, userId)
+		}
+		return
+	}
+
+	for userId := range evalVMCache {
+		if users.GetByUserId(userId) == nil {
+			delete(evalVMCache, userId)
+		}
+	}
+}
+
+// Runs JavaScript for an admin with the same functions every script gets.
+// user and room are bound to the admin and the room they're in, and mob to mobInstanceId (if not 0).
+// Console output is returned instead of logged. Runs with the room script timeout.
+func Eval(code string, userId int, roomId int, mobInstanceId int) (EvalResult, error) {
+
+	result := EvalResult{}
+
+	vm, ok := evalVMCache[userId]
+	if !ok {
+		vm = goja.New()
+		setAllScriptingFunctions(vm)
+		evalVMCache[userId] = vm
+	}
+
+	vm.Set(`console`, newEvalConsole(vm, &result.Console))
+	vm.Set(`user`, GetActor(userId, 0))
+	vm.Set(`room`, GetRoom(roomId))
+	if mobInstanceId > 0 {
+		vm.Set(`mob`, GetMob(mobInstanceId))
+	} else {
+		vm.Set(`mob`, goja.Undefined())
+	}
+
+	tmr := time.AfterFunc(scriptRoomTimeout, func() {
+		vm.Interrupt(errTimeout)
+	})
+
+	val, err := vm.RunScript(`eval`, code)
+
+	vm.ClearInterrupt()
+	tmr.Stop()
+
+	if err != nil {
+		if errors.Is(err, errTimeout) {
+			err = fmt.Errorf("%w after %s", errTimeout, scriptRoomTimeout)
+		}
+		mudlog.Info("JSVM", "eval", code, "userId", userId, "error", err)
+		return result, err
+	}
+
+	if val != nil && !goja.IsUndefined(val) {
+		result.Value = formatValue(vm, val)
+	}
+
+	return result, nil
+}
+
+// Shows a value the way a browser console would, more or less
+func formatValue(vm *goja.Runtime, val goja.Value) string {
+
+	if goja.IsNull(val) || goja.IsUndefined(val) {
+		return val.String()
+	}
+
+	if _, ok := goja.AssertFunction(val); ok {
+		return `function`
+	}
+
+	exported := val.Export()
+	if exported == nil {
+		return val.String()
+	}
+
+	switch reflect.TypeOf(exported).Kind() {
+	case reflect.String:
+		return fmt.Sprintf(`%q`, exported)
+	case reflect.Map, reflect.Slice:
+		// Plain objects and arrays
+		if b, err := json.Marshal(exported); err == nil {
+			return string(b)
+		}
+	case reflect.Pointer:
+		// Go objects such as ScriptActor and ScriptRoom
+		return strings.TrimPrefix(reflect.TypeOf(exported).String(), `*scripting.`)
+	}
+
+	return val.String()
+}
+
+func newEvalConsole(vm *goja.Runtime, lines *[]string) *goja.Object {
+
+	write := func(call goja.FunctionCall) goja.Value {
+		parts := make([]string, 0, len(call.Arguments))
+		for _, arg := range call.Arguments {
+			if s, ok := arg.Export().(string); ok {
+				parts = append(parts, s)
+				continue
+			}
+			parts = append(parts, formatValue(vm, arg))
+		}
+		*lines = append(*lines, strings.Join(parts, ` `))
+		return goja.Undefined()
+	}
+
+	obj := vm.NewObject()
+	for _, name := range []string{`log`, `info`, `debug`, `warn`, `error`} {
+		obj.Set(name, write)
+	}
+	return obj
+}
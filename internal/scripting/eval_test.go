@@ -0,0 +1,90 @@
+package scripting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func TestEval(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		code    string
+		console []string
+		value   string
+	}{
+		{`number`, `1 + 2`, nil, `3`},
+		{`string`, `"a" + "b"`, nil, `"ab"`},
+		{`nothing`, `var x = 1`, nil, ``},
+		{`null`, `null`, nil, `null`},
+		{`object`, `({a: 1, b: [1, 2]})`, nil, `{"a":1,"b":[1,2]}`},
+		{`function`, `(function() {})`, nil, `function`},
+		{`console`, `console.log("hi", 1, {a: 2}); console.error("oops"); 5`, []string{`hi 1 {"a":2}`, `oops`}, `5`},
+		{`no mob`, `typeof mob`, nil, `"undefined"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ClearEvalVMs()
+
+			result, err := Eval(tt.code, 1, 0, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.console, result.Console)
+			assert.Equal(t, tt.value, result.Value)
+		})
+	}
+}
+
+func TestEval_KeepsVariables(t *testing.T) {
+	defer ClearEvalVMs()
+
+	_, err := Eval(`var counter = 10; function bump() { return ++counter }`, 1, 0, 0)
+	require.NoError(t, err)
+
+	result, err := Eval(`bump()`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `11`, result.Value)
+
+	// Another admin has their own
+	result, err = Eval(`typeof counter`, 2, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `"undefined"`, result.Value)
+
+	PruneEvalVMs(1)
+
+	result, err = Eval(`typeof counter`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `"undefined"`, result.Value)
+}
+
+func TestEval_Errors(t *testing.T) {
+	defer ClearEvalVMs()
+
+	_, err := Eval(`this is not javascript`, 1, 0, 0)
+	assert.ErrorContains(t, err, `SyntaxError`)
+
+	result, err := Eval(`console.log("before"); throw new Error("boom")`, 1, 0, 0)
+	assert.ErrorContains(t, err, `boom`)
+	assert.Equal(t, []string{`before`}, result.Console)
+
+	defer func(d time.Duration) { scriptRoomTimeout = d }(scriptRoomTimeout)
+	scriptRoomTimeout = 20 * time.Millisecond
+
+	_, err = Eval(`while (true) {}`, 1, 0, 0)
+	assert.ErrorIs(t, err, errTimeout)
+
+	// Still usable afterwards
+	result, err = Eval(`1`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `1`, result.Value)
+}
@@ -63,6 +63,7 @@ func TryItemScriptEvent(eventName string, item items.Item, userId int) (bool, er
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`item`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -85,6 +86,62 @@ func TryItemScriptEvent(eventName string, item items.Item, userId int) (bool, er
 	return false, ErrEventNotFound
 }
 
+// Runs a combat event on an item the actor has equipped, with the attack it's about
+func tryItemCombatEvent(eventName string, item items.Item, sActor *ScriptActor, sAttack *ScriptAttack) (bool, error) {
+
+	sItem := GetItem(item)
+
+	vmw, err := getItemVM(sItem)
+	if err != nil {
+		return false, err
+	}
+
+	if onCommandFunc, ok := vmw.GetFunction(eventName); ok {
+
+		sRoom := GetRoom(sActor.GetRoomId())
+
+		tmr := time.AfterFunc(scriptItemTimeout, func() {
+			vmw.VM.Interrupt(errTimeout)
+		})
+		res, err := onCommandFunc(goja.Undefined(),
+			vmw.VM.ToValue(sActor),
+			vmw.VM.ToValue(sItem),
+			vmw.VM.ToValue(sRoom),
+			vmw.VM.ToValue(sAttack),
+		)
+		vmw.VM.ClearInterrupt()
+		tmr.Stop()
+
+		if err != nil {
+
+			// Wrap the error
+			finalErr := fmt.Errorf("%s(): %w", eventName, err)
+
+			if _, ok := finalErr.(*goja.Exception); ok {
+				mudlog.Error("JSVM", "exception", finalErr)
+				return false, finalErr
+			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`item`)
+				mudlog.Error("JSVM", "interrupted", finalErr)
+				return false, finalErr
+			}
+
+			mudlog.Error("JSVM", "error", finalErr)
+			return false, finalErr
+		}
+
+		// Save any changed that might have happened to the item
+		sActor.characterRecord.Equipment.UpdateItem(item, *sItem.itemRecord)
+
+		if boolVal, ok := res.Export().(bool); ok {
+			return boolVal, nil
+		}
+
+	}
+
+	return false, ErrEventNotFound
+}
+
 func TryItemCommand(cmd string, item items.Item, userId int) (bool, error) {
 
 	sItem := GetItem(item)
@@ -124,6 +181,7 @@ func TryItemCommand(cmd string, item items.Item, userId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`item`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -165,6 +223,7 @@ func TryItemCommand(cmd string, item items.Item, userId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`item`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -226,6 +285,7 @@ func getItemVM(sItem *ScriptItem) (*VMWrapper, error) {
 			mudlog.Error("JSVM", "exception", finalErr)
 			return nil, finalErr
 		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`item`)
 			mudlog.Error("JSVM", "interrupted", finalErr)
 			return nil, finalErr
 		}
@@ -106,6 +106,35 @@ func (i ScriptItem) Redescribe(newDescription string) {
 	i.itemRecord.Redescribe(newDescription)
 }
 
+func (i ScriptItem) SetTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(i.timerOwner(vm), call, vm, turnsPerRound(), false)
+}
+
+func (i ScriptItem) SetInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(i.timerOwner(vm), call, vm, turnsPerRound(), true)
+}
+
+func (i ScriptItem) SetTurnTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(i.timerOwner(vm), call, vm, 1, false)
+}
+
+func (i ScriptItem) SetTurnInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(i.timerOwner(vm), call, vm, 1, true)
+}
+
+func (i ScriptItem) ClearTimer(timerId int) bool {
+	return clearTimer(scriptTimer{kind: timerItem, itemUUID: i.itemRecord.UUID}, timerId)
+}
+
+// Item timers follow the item from whoever is carrying it to whoever is carrying it next
+func (i ScriptItem) timerOwner(vm *goja.Runtime) scriptTimer {
+	t := scriptTimer{kind: timerItem, itemUUID: i.itemRecord.UUID}
+	if _, _, ok := t.findItem(); !ok {
+		panic(vm.NewTypeError(`only items someone is carrying can have timers`))
+	}
+	return t
+}
+
 // Converts an item into a ScriptItem for use in the scripting engine
 func GetItem(i items.Item) *ScriptItem {
 	sItm := newScriptItem(i)
@@ -0,0 +1,55 @@
+package scripting
+
+import (
+	"sync"
+
+	"github.com/GoMudEngine/GoMud/internal/metrics"
+)
+
+var (
+	timeoutLock   = sync.Mutex{}
+	timeoutCounts = map[string]uint64{}
+)
+
+// Counts a script that was interrupted for running too long
+func countTimeout(kind string) {
+	timeoutLock.Lock()
+	defer timeoutLock.Unlock()
+
+	timeoutCounts[kind]++
+}
+
+func collectMetrics() []metrics.Metric {
+
+	vms := metrics.NewGauge(`gomud_script_vms`, `JavaScript VMs loaded, by kind of script.`)
+	vms.Add(float64(len(buffVMCache)), `kind`, `buff`)
+	vms.Add(float64(len(commandVMCache)), `kind`, `command`)
+	vms.Add(float64(len(itemVMCache)), `kind`, `item`)
+	vms.Add(float64(len(mobVMCache)), `kind`, `mob`)
+	vms.Add(float64(len(roomVMCache)), `kind`, `room`)
+	vms.Add(float64(len(spellVMCache)), `kind`, `spell`)
+
+	timerCounts := map[string]int{}
+	for _, t := range timers {
+		timerCounts[t.kind]++
+	}
+
+	scriptTimers := metrics.NewGauge(`gomud_script_timers`, `Timers set by scripts, by kind of script.`)
+	for _, kind := range []string{timerItem, timerMob, timerRoom} {
+		scriptTimers.Add(float64(timerCounts[kind]), `kind`, kind)
+	}
+
+	timeouts := metrics.NewCounter(`gomud_script_timeouts_total`, `Scripts interrupted for running too long, by kind of script.`)
+
+	timeoutLock.Lock()
+	for _, kind := range []string{`buff`, `command`, `item`, `mob`, `room`, `spell`} {
+		timeouts.Add(float64(timeoutCounts[kind]), `kind`, kind)
+	}
+	timeoutLock.Unlock()
+
+	return []metrics.Metric{vms, scriptTimers, timeouts}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -68,6 +68,7 @@ func TryPlayerDownedEvent(mobInstanceId int, downedPlayerId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`mob`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -134,6 +135,7 @@ func TryMobScriptEvent(eventName string, mobInstanceId int, sourceId int, source
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`mob`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -198,6 +200,7 @@ func TryMobCommand(cmd string, rest string, mobInstanceId int, sourceId int, sou
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`mob`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -241,6 +244,7 @@ func TryMobCommand(cmd string, rest string, mobInstanceId int, sourceId int, sou
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`mob`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -298,6 +302,7 @@ func getMobVM(mobActor *ScriptActor) (*VMWrapper, error) {
 			mudlog.Error("JSVM", "exception", finalErr)
 			return nil, finalErr
 		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`mob`)
 			mudlog.Error("JSVM", "interrupted", finalErr)
 			return nil, finalErr
 		}
@@ -324,6 +329,7 @@ func getMobVM(mobActor *ScriptActor) (*VMWrapper, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return nil, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`mob`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return nil, finalErr
 			}
@@ -0,0 +1,156 @@
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/dop251/goja"
+)
+
+const (
+	libFolder = `scripts/lib`
+)
+
+var (
+	// Compiled once and shared by every VM that requires them, until the next reload
+	libProgramCache = make(map[string]*goja.Program)
+
+	// Other places to find libraries, after the world's own (modules)
+	libFileSystems []fs.ReadFileFS
+
+	errLibNotFound = errors.New("library not found")
+	errLibName     = errors.New("invalid library name")
+)
+
+func RegisterFS(f fs.ReadFileFS) {
+	libFileSystems = append(libFileSystems, f)
+}
+
+// Forgets every compiled library, so they're read again the next time they're required
+func ClearLibraries() {
+	clear(libProgramCache)
+}
+
+// Adds require(name) to a VM. Each VM keeps its own copy of every library it requires,
+// so a library's state is shared by the scripts of one room or mob but not the next.
+func setRequireFunction(vm *goja.Runtime) {
+
+	modules := map[string]*goja.Object{}
+
+	vm.Set(`require`, func(call goja.FunctionCall) goja.Value {
+
+		name, err := libName(call.Argument(0).String())
+		if err != nil {
+			panic(vm.NewGoError(err))
+		}
+
+		if module, ok := modules[name]; ok {
+			return module.Get(`exports`)
+		}
+
+		prg, err := getLibProgram(name)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("require(%q): %w", name, err)))
+		}
+
+		module := vm.NewObject()
+		exports := vm.NewObject()
+		module.Set(`exports`, exports)
+		module.Set(`id`, name)
+
+		// Added before it runs, so libraries that require each other get what's been exported so far
+		modules[name] = module
+
+		// Running the program only makes the wrapper function, calling it runs the library
+		tmr := time.AfterFunc(scriptLoadTimeout, func() {
+			vm.Interrupt(errTimeout)
+		})
+		defer tmr.Stop()
+
+		wrapper, err := vm.RunProgram(prg)
+		if err == nil {
+			fn, _ := goja.AssertFunction(wrapper)
+			_, err = fn(exports, exports, vm.Get(`require`), module)
+		}
+
+		if err != nil {
+			delete(modules, name)
+
+			var interrupted *goja.InterruptedError
+			if errors.As(err, &interrupted) {
+				// Nothing can catch this, it stops (and is counted against) whichever script did the requiring too
+				mudlog.Error("JSVM", "require", name, "interrupted", err)
+				panic(interrupted)
+			}
+
+			panic(vm.NewGoError(fmt.Errorf("require(%q): %w", name, err)))
+		}
+
+		return module.Get(`exports`)
+	})
+}
+
+// Tidies up a library name, e.g. "./combat.js" becomes "combat".
+// Names are always relative to the library folder and can't go above it.
+func libName(name string) (string, error) {
+
+	name = strings.TrimSpace(name)
+	name = strings.TrimSuffix(name, `.js`)
+
+	clean := path.Clean(`/` + name)[1:]
+	if clean == `` || strings.HasPrefix(name, `/`) || clean != strings.TrimPrefix(name, `./`) {
+		return ``, fmt.Errorf("%w: %q", errLibName, name)
+	}
+
+	return clean, nil
+}
+
+func getLibProgram(name string) (*goja.Program, error) {
+
+	if prg, ok := libProgramCache[name]; ok {
+		return prg, nil
+	}
+
+	source, err := readLib(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same wrapper node.js uses, so libraries have their own scope and export with module.exports
+	wrapped := "(function(exports, require, module) {" + source + "\n})"
+
+	prg, err := goja.Compile(libFolder+`/`+name+`.js`, wrapped, false)
+	if err != nil {
+		return nil, fmt.Errorf("Compile: %w", err)
+	}
+
+	libProgramCache[name] = prg
+
+	return prg, nil
+}
+
+// The world's libraries come first, so a world can replace a module's version of one
+func readLib(name string) (string, error) {
+
+	libPath := util.FilePath(libFolder, `/`, name+`.js`)
+
+	if b, err := os.ReadFile(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, libPath)); err == nil {
+		return string(b), nil
+	}
+
+	for _, f := range libFileSystems {
+		if b, err := f.ReadFile(libPath); err == nil {
+			return string(b), nil
+		}
+	}
+
+	return ``, errLibNotFound
+}
@@ -0,0 +1,177 @@
+package scripting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Puts libraries in a temporary world, and others in a module
+func setupLibs(t *testing.T, world map[string]string, module map[string]string) string {
+
+	dir := datafilestest.TempDir(t)
+
+	for name, src := range world {
+		writeLib(t, dir, name, src)
+	}
+
+	moduleFS := fstest.MapFS{}
+	for name, src := range module {
+		moduleFS[libFolder+`/`+name+`.js`] = &fstest.MapFile{Data: []byte(src)}
+	}
+
+	oldFileSystems := libFileSystems
+	libFileSystems = nil
+	RegisterFS(moduleFS)
+
+	t.Cleanup(func() {
+		libFileSystems = oldFileSystems
+		ClearLibraries()
+		ClearEvalVMs()
+	})
+
+	return dir
+}
+
+func writeLib(t *testing.T, dir string, name string, src string) {
+	path := filepath.Join(dir, libFolder, name+`.js`)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+}
+
+func TestLibName(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{`combat`, `combat`, false},
+		{`combat.js`, `combat`, false},
+		{`./combat`, `combat`, false},
+		{`util/strings`, `util/strings`, false},
+		{``, ``, true},
+		{`../secrets`, ``, true},
+		{`util/../../secrets`, ``, true},
+		{`/etc/passwd`, ``, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := libName(tt.name)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errLibName)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+
+	setupLibs(t,
+		map[string]string{
+			`greet`:      `exports.hello = function(name) { return "Hello " + name; };`,
+			`util/twice`: `module.exports = function(x) { return x * 2; };`,
+			`shared`:     `module.exports = "from the world";`,
+			`uses`:       `var twice = require("util/twice"); exports.four = twice(2);`,
+		},
+		map[string]string{
+			`shared`: `module.exports = "from a module";`,
+			`mod`:    `module.exports = "module only";`,
+		},
+	)
+
+	tests := []struct {
+		name  string
+		code  string
+		value string
+	}{
+		{`exports`, `require("greet").hello("Bob")`, `"Hello Bob"`},
+		{`module.exports`, `require("util/twice")(4)`, `8`},
+		{`from a library`, `require("uses").four`, `4`},
+		{`module files`, `require("mod")`, `"module only"`},
+		{`world first`, `require("shared")`, `"from the world"`},
+		{`not found`, `try { require("nope"); "no" } catch (e) { "caught" }`, `"caught"`},
+		{`bad name`, `try { require("../nope"); "no" } catch (e) { "caught" }`, `"caught"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Eval(tt.code, 1, 0, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, result.Value)
+		})
+	}
+}
+
+func TestRequire_CachedPerVM(t *testing.T) {
+
+	dir := setupLibs(t, map[string]string{
+		`counter`: `var n = 0; exports.next = function() { return ++n; };`,
+	}, nil)
+
+	result, err := Eval(`require("counter").next(); require("counter").next()`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `2`, result.Value)
+
+	// Another VM gets its own copy
+	result, err = Eval(`require("counter").next()`, 2, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `1`, result.Value)
+
+	// Changes aren't picked up until a reload
+	writeLib(t, dir, `counter`, `exports.next = function() { return "changed"; };`)
+	ClearEvalVMs()
+
+	result, err = Eval(`require("counter").next()`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `1`, result.Value)
+
+	PruneVMs(true)
+
+	result, err = Eval(`require("counter").next()`, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `"changed"`, result.Value)
+}
+
+func TestRequire_Errors(t *testing.T) {
+
+	setupLibs(t, map[string]string{
+		`broken`: `exports.x = ;`,
+		`throws`: `throw new Error("nope");`,
+	}, nil)
+
+	_, err := Eval(`require("broken")`, 1, 0, 0)
+	assert.ErrorContains(t, err, `Compile`)
+
+	_, err = Eval(`require("throws")`, 1, 0, 0)
+	assert.ErrorContains(t, err, `nope`)
+}
+
+func TestRequire_Timeout(t *testing.T) {
+
+	setupLibs(t, map[string]string{
+		`forever`: `while (true) {}`,
+	}, nil)
+
+	// The library's own load timeout has to be what stops it
+	oldLoad, oldRoom := scriptLoadTimeout, scriptRoomTimeout
+	scriptLoadTimeout, scriptRoomTimeout = 20*time.Millisecond, 5*time.Second
+	defer func() {
+		scriptLoadTimeout, scriptRoomTimeout = oldLoad, oldRoom
+	}()
+
+	start := time.Now()
+	_, err := Eval(`try { require("forever") } catch (e) { "caught" }`, 1, 0, 0)
+	assert.ErrorIs(t, err, errTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -3,6 +3,7 @@ package scripting
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
@@ -36,6 +37,7 @@ func PruneRoomVMs(roomIds ...int) {
 				delete(roomVMCache, roomId)
 			}
 		}
+		PruneTimers(roomIds...)
 		return
 	}
 	for roomId, _ := range roomVMCache {
@@ -91,6 +93,7 @@ func TryRoomScriptEvent(eventName string, userId int, roomId int) (bool, error)
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -150,6 +153,7 @@ func TryRoomIdleEvent(roomId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -166,6 +170,73 @@ func TryRoomIdleEvent(roomId int) (bool, error) {
 	return false, ErrEventNotFound
 }
 
+// Calls onWorldStateChange(namespace, key, value, oldValue, room) in every room script that's currently loaded
+func TryRoomWorldStateEvents(namespace string, key string, value any, oldValue any) {
+
+	timestart := time.Now()
+	defer func() {
+		mudlog.Debug("TryRoomWorldStateEvents()", "namespace", namespace, "key", key, "time", time.Since(timestart))
+	}()
+
+	roomIds := make([]int, 0, len(roomVMCache))
+	for roomId := range roomVMCache {
+		roomIds = append(roomIds, roomId)
+	}
+	sort.Ints(roomIds)
+
+	for _, roomId := range roomIds {
+
+		vmw := roomVMCache[roomId]
+
+		onChangeFunc, ok := vmw.GetFunction(`onWorldStateChange`)
+		if !ok {
+			continue
+		}
+
+		sRoom := GetRoom(roomId)
+		if sRoom == nil {
+			continue
+		}
+
+		// Set forced ansi tag wrappers
+		userTextWrap.Set(`script-text`, ``, ``)
+		roomTextWrap.Set(`script-text`, ``, ``)
+
+		tmr := time.AfterFunc(scriptRoomTimeout, func() {
+			vmw.VM.Interrupt(errTimeout)
+		})
+
+		_, err := onChangeFunc(goja.Undefined(),
+			vmw.VM.ToValue(namespace),
+			vmw.VM.ToValue(key),
+			vmw.VM.ToValue(value),
+			vmw.VM.ToValue(oldValue),
+			vmw.VM.ToValue(sRoom),
+		)
+
+		vmw.VM.ClearInterrupt()
+		tmr.Stop()
+
+		userTextWrap.Reset()
+		roomTextWrap.Reset()
+
+		if err != nil {
+
+			// Wrap the error
+			finalErr := fmt.Errorf("onWorldStateChange(): %w", err)
+
+			if _, ok := finalErr.(*goja.Exception); ok {
+				mudlog.Error("JSVM", "exception", finalErr, "roomId", roomId)
+			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
+				mudlog.Error("JSVM", "interrupted", finalErr, "roomId", roomId)
+			} else {
+				mudlog.Error("JSVM", "error", finalErr, "roomId", roomId)
+			}
+		}
+	}
+}
+
 func TryRoomCommand(cmd string, rest string, userId int) (bool, error) {
 
 	user := users.GetByUserId(userId)
@@ -236,6 +307,7 @@ func TryRoomCommand(cmd string, rest string, userId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -281,6 +353,7 @@ func TryRoomCommand(cmd string, rest string, userId int) (bool, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -341,6 +414,7 @@ func getRoomVM(roomId int) (*VMWrapper, error) {
 			mudlog.Error("JSVM", "exception", finalErr)
 			return nil, finalErr
 		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`room`)
 			mudlog.Error("JSVM", "interrupted", finalErr)
 			return nil, finalErr
 		}
@@ -369,6 +443,7 @@ func getRoomVM(roomId int) (*VMWrapper, error) {
 				mudlog.Error("JSVM", "exception", finalErr)
 				return nil, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`room`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return nil, finalErr
 			}
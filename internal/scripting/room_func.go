@@ -381,6 +381,30 @@ func (r ScriptRoom) IsEphemeral() bool {
 	return rooms.IsEphemeralRoomId(r.roomRecord.RoomId)
 }
 
+func (r ScriptRoom) SetTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(r.timerOwner(), call, vm, turnsPerRound(), false)
+}
+
+func (r ScriptRoom) SetInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(r.timerOwner(), call, vm, turnsPerRound(), true)
+}
+
+func (r ScriptRoom) SetTurnTimeout(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(r.timerOwner(), call, vm, 1, false)
+}
+
+func (r ScriptRoom) SetTurnInterval(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	return setTimer(r.timerOwner(), call, vm, 1, true)
+}
+
+func (r ScriptRoom) ClearTimer(timerId int) bool {
+	return clearTimer(r.timerOwner(), timerId)
+}
+
+func (r ScriptRoom) timerOwner() scriptTimer {
+	return scriptTimer{kind: timerRoom, roomId: r.roomId}
+}
+
 // ////////////////////////////////////////////////////////
 //
 // # These functions get exported to the scripting engine
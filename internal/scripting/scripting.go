@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/colorpatterns"
+	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/dop251/goja"
 )
 
@@ -91,9 +92,12 @@ func Setup(scriptLoadTimeoutMs int, scriptRoomTimeoutMs int) {
 	scriptItemTimeout = t
 	scriptMobTimeout = t
 	scriptSpellTimeout = t
+	scriptCommandTimeout = t
 }
 
 func setAllScriptingFunctions(vm *goja.Runtime) {
+	// Math.random() draws from the same seeded source as util.Rand(), so replays roll the same numbers
+	vm.SetRandSource(util.RandFloat)
 	setMessagingFunctions(vm)
 	setRoomFunctions(vm)
 	setActorFunctions(vm)
@@ -101,6 +105,22 @@ func setAllScriptingFunctions(vm *goja.Runtime) {
 	setItemFunctions(vm)
 	setUtilFunctions(vm)
 	setModuleFunctions(vm)
+	setRequireFunction(vm)
+}
+
+// The Go functions and values every script is given, by the global name they have in scripts.
+// cmd/generate/scripttypes describes them in the TypeScript declarations.
+func Globals() map[string]any {
+
+	vm := goja.New()
+	setAllScriptingFunctions(vm)
+
+	globals := map[string]any{}
+	for _, name := range vm.GlobalObject().Keys() {
+		globals[name] = vm.Get(name).Export()
+	}
+
+	return globals
 }
 
 func PruneVMs(forceClear ...bool) {
@@ -111,12 +131,18 @@ func PruneVMs(forceClear ...bool) {
 		ClearBuffVMs()
 		ClearItemVMs()
 		ClearSpellVMs()
+		ClearEvalVMs()
+		ClearCommandVMs()
+		ClearLibraries()
+		ClearTimers()
 	} else {
 		PruneRoomVMs()
 		PruneMobVMs()
 		PruneBuffVMs()
 		PruneItemVMs()
 		PruneSpellVMs()
+		PruneEvalVMs()
+		PruneTimers()
 	}
 
 }
@@ -137,6 +137,7 @@ func TrySpellScriptEvent(eventName string, sourceUserId int, sourceMobInstanceId
 				mudlog.Error("JSVM", "exception", finalErr)
 				return false, finalErr
 			} else if errors.Is(finalErr, errTimeout) {
+				countTimeout(`spell`)
 				mudlog.Error("JSVM", "interrupted", finalErr)
 				return false, finalErr
 			}
@@ -199,6 +200,7 @@ func getSpellVM(scriptId string) (*VMWrapper, error) {
 			mudlog.Error("JSVM", "exception", finalErr)
 			return nil, finalErr
 		} else if errors.Is(finalErr, errTimeout) {
+			countTimeout(`spell`)
 			mudlog.Error("JSVM", "interrupted", finalErr)
 			return nil, finalErr
 		}
@@ -0,0 +1,573 @@
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/uuid"
+	"github.com/dop251/goja"
+)
+
+const (
+	timerRoom = `room`
+	timerMob  = `mob`
+	timerItem = `item`
+)
+
+var (
+	timers      = make(map[int]*scriptTimer)
+	lastTimerId = 0
+)
+
+// A callback a room, mob or item script wants run later. Delays are counted in turns.
+type scriptTimer struct {
+	timerId       int
+	kind          string    // room, mob or item
+	roomId        int       // The room, for room timers
+	mobInstanceId int       // The mob, for mob timers. For item timers, the mob carrying it.
+	userId        int       // For item timers, the user carrying it
+	itemUUID      uuid.UUID // The item, for item timers
+	funcName      string    // Name of the callback, if it has one
+	callback      goja.Callable
+	vm            *goja.Runtime // The VM the callback belongs to
+	dueTurn       uint64
+	interval      uint64 // Turns between runs, or zero to run once
+	persistent    bool   // Persistent room timers call funcName in the room's script, and are saved with the room
+}
+
+// A timer as the admin script command lists it
+type TimerInfo struct {
+	TimerId    int
+	Kind       string // room, mob or item
+	Owner      string // Who it belongs to, e.g. "room 12" or "guard (#3)"
+	Function   string
+	TurnsLeft  uint64
+	Interval   uint64 // Turns between runs, or zero to run once
+	Persistent bool
+}
+
+// Every timer, in the order they were set
+func GetTimers() []TimerInfo {
+
+	turnNow := util.GetTurnCount()
+
+	result := make([]TimerInfo, 0, len(timers))
+	for _, t := range timers {
+
+		info := TimerInfo{
+			TimerId:    t.timerId,
+			Kind:       t.kind,
+			Owner:      t.ownerName(),
+			Function:   t.funcName,
+			Interval:   t.interval,
+			Persistent: t.persistent,
+		}
+
+		if info.Function == `` {
+			info.Function = `(anonymous)`
+		}
+
+		if t.dueTurn > turnNow {
+			info.TurnsLeft = t.dueTurn - turnNow
+		}
+
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TimerId < result[j].TimerId
+	})
+
+	return result
+}
+
+// Stops a timer, whoever it belongs to. Returns false if there's no such timer.
+func StopTimer(timerId int) bool {
+
+	t, ok := timers[timerId]
+	if !ok {
+		return false
+	}
+
+	removeTimer(t)
+
+	return true
+}
+
+// Runs every timer that's due. Called once a turn.
+func RunTimers(turnNumber uint64) {
+
+	// Once a round, start persistent timers of rooms that have been loaded since
+	if turnNumber%turnsPerRound() == 0 {
+		startRoomTimers()
+	}
+
+	due := []*scriptTimer{}
+	for _, t := range timers {
+		if t.dueTurn <= turnNumber {
+			due = append(due, t)
+		}
+	}
+
+	if len(due) == 0 {
+		return
+	}
+
+	timestart := time.Now()
+	defer func() {
+		mudlog.Debug("RunTimers()", "turn", turnNumber, "count", len(due), "time", time.Since(timestart))
+	}()
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].dueTurn != due[j].dueTurn {
+			return due[i].dueTurn < due[j].dueTurn
+		}
+		return due[i].timerId < due[j].timerId
+	})
+
+	for _, t := range due {
+
+		// Cleared by a callback that ran before it
+		if timers[t.timerId] != t {
+			continue
+		}
+
+		if !t.ownerExists() {
+			delete(timers, t.timerId)
+			continue
+		}
+
+		if t.interval > 0 {
+			t.dueTurn = turnNumber + t.interval
+			t.save()
+		} else {
+			removeTimer(t)
+		}
+
+		t.run()
+	}
+}
+
+// Forgets every timer that isn't persistent. Their callbacks belong to scripts that are about to be reloaded.
+func ClearTimers() {
+	for timerId, t := range timers {
+		if !t.persistent {
+			delete(timers, timerId)
+		}
+	}
+}
+
+// Forgets the timers of rooms that have been unloaded, mobs that are gone and items nobody is carrying.
+// Persistent room timers are still saved with the room, and start again when it's loaded.
+func PruneTimers(roomIds ...int) {
+
+	if len(roomIds) > 0 {
+		for timerId, t := range timers {
+			if t.kind != timerRoom {
+				continue
+			}
+			for _, roomId := range roomIds {
+				if t.roomId == roomId {
+					delete(timers, timerId)
+				}
+			}
+		}
+		return
+	}
+
+	for timerId, t := range timers {
+		if !t.ownerExists() {
+			delete(timers, timerId)
+		}
+	}
+}
+
+// Stops a timer, and if it's persistent, removes it from the room too
+func removeTimer(t *scriptTimer) {
+
+	delete(timers, t.timerId)
+
+	if !t.persistent || !rooms.IsRoomLoaded(t.roomId) {
+		return
+	}
+
+	room := rooms.LoadRoom(t.roomId)
+	for i := range room.ScriptTimers {
+		if room.ScriptTimers[i].TimerId == t.timerId {
+			room.ScriptTimers = append(room.ScriptTimers[:i], room.ScriptTimers[i+1:]...)
+			break
+		}
+	}
+
+	// Nil matches the room template, so nothing is saved to the instance file
+	if len(room.ScriptTimers) == 0 {
+		room.ScriptTimers = nil
+	}
+}
+
+// Starts any persistent timers of rooms in memory that aren't running yet, e.g. after a restart
+func startRoomTimers() {
+
+	turnNow := util.GetTurnCount()
+	roundNow := util.GetRoundCount()
+
+	for _, roomId := range rooms.GetRoomsWithScriptTimers() {
+
+		room := rooms.LoadRoom(roomId)
+
+		for i := range room.ScriptTimers {
+
+			st := &room.ScriptTimers[i]
+			if _, ok := timers[st.TimerId]; ok && st.TimerId != 0 {
+				continue
+			}
+
+			lastTimerId++
+			st.TimerId = lastTimerId
+
+			t := &scriptTimer{
+				timerId:    st.TimerId,
+				kind:       timerRoom,
+				roomId:     roomId,
+				funcName:   st.Function,
+				dueTurn:    turnNow,
+				interval:   st.Interval,
+				persistent: true,
+			}
+
+			// Any that came due while the room wasn't loaded run right away
+			if st.DueRound > roundNow {
+				t.dueTurn += (st.DueRound - roundNow) * turnsPerRound()
+			}
+
+			timers[t.timerId] = t
+		}
+	}
+}
+
+// Sets a timer from the arguments of SetTimeout(callback, delay [, persistent]) and friends.
+// unitTurns is how many turns a unit of delay is. Returns the timer id.
+func setTimer(t scriptTimer, call goja.FunctionCall, vm *goja.Runtime, unitTurns uint64, repeat bool) goja.Value {
+
+	callbackArg := call.Argument(0)
+
+	if fn, ok := goja.AssertFunction(callbackArg); ok {
+		t.callback = fn
+		if name := callbackArg.ToObject(vm).Get(`name`); name != nil {
+			t.funcName = name.String()
+		}
+	} else if name, ok := callbackArg.Export().(string); ok {
+		if t.callback, ok = goja.AssertFunction(vm.Get(name)); !ok {
+			panic(vm.NewTypeError(`%s is not a function`, name))
+		}
+		t.funcName = name
+	} else {
+		panic(vm.NewTypeError(`a timer needs a function, or the name of one`))
+	}
+	t.vm = vm
+
+	turns := unitTurns
+	if delay := call.Argument(1).ToInteger(); delay > 1 {
+		turns *= uint64(delay)
+	}
+
+	if call.Argument(2).ToBoolean() {
+
+		if t.kind != timerRoom {
+			panic(vm.NewTypeError(`only room timers can be persistent`))
+		}
+
+		// The function is looked up again each time it runs, so it has to be one the script defines by name
+		if _, ok := goja.AssertFunction(vm.Get(t.funcName)); !ok || t.funcName == `` {
+			panic(vm.NewTypeError(`persistent timers need a function the script defines by name`))
+		}
+
+		t.persistent = true
+		t.callback = nil
+		t.vm = nil
+	}
+
+	if repeat {
+		t.interval = turns
+	}
+
+	lastTimerId++
+	t.timerId = lastTimerId
+	t.dueTurn = util.GetTurnCount() + turns
+
+	timers[t.timerId] = &t
+
+	if t.persistent {
+
+		room := rooms.LoadRoom(t.roomId)
+
+		// A room keeps one persistent timer per function, so setting it again in onLoad() doesn't pile them up
+		for i := len(room.ScriptTimers) - 1; i >= 0; i-- {
+			if room.ScriptTimers[i].Function == t.funcName {
+				delete(timers, room.ScriptTimers[i].TimerId)
+				room.ScriptTimers = append(room.ScriptTimers[:i], room.ScriptTimers[i+1:]...)
+			}
+		}
+
+		room.ScriptTimers = append(room.ScriptTimers, rooms.ScriptTimer{TimerId: t.timerId, Function: t.funcName})
+		t.save()
+	}
+
+	return vm.ToValue(t.timerId)
+}
+
+// Stops a timer for ClearTimer(), if it belongs to the same owner
+func clearTimer(owner scriptTimer, timerId int) bool {
+
+	t, ok := timers[timerId]
+	if !ok || t.kind != owner.kind {
+		return false
+	}
+
+	switch t.kind {
+	case timerRoom:
+		ok = t.roomId == owner.roomId
+	case timerMob:
+		ok = t.mobInstanceId == owner.mobInstanceId
+	case timerItem:
+		ok = t.itemUUID == owner.itemUUID
+	}
+
+	if ok {
+		removeTimer(t)
+	}
+
+	return ok
+}
+
+// Updates when a persistent timer is next due in the room's saved copy of it
+func (t *scriptTimer) save() {
+
+	if !t.persistent || !rooms.IsRoomLoaded(t.roomId) {
+		return
+	}
+
+	room := rooms.LoadRoom(t.roomId)
+	for i := range room.ScriptTimers {
+		if room.ScriptTimers[i].TimerId == t.timerId {
+			room.ScriptTimers[i].DueRound = dueRound(t.dueTurn)
+			room.ScriptTimers[i].Interval = t.interval
+			return
+		}
+	}
+}
+
+func (t *scriptTimer) ownerExists() bool {
+
+	switch t.kind {
+
+	case timerRoom:
+		if !rooms.IsRoomLoaded(t.roomId) {
+			return false
+		}
+		if !t.persistent {
+			return true
+		}
+		// The room may have been unloaded and loaded again, which starts its timers over
+		for _, st := range rooms.LoadRoom(t.roomId).ScriptTimers {
+			if st.TimerId == t.timerId {
+				return true
+			}
+		}
+		return false
+
+	case timerMob:
+		return mobs.GetInstance(t.mobInstanceId) != nil
+
+	case timerItem:
+		_, _, ok := t.findItem()
+		return ok
+	}
+
+	return false
+}
+
+func (t *scriptTimer) ownerName() string {
+
+	switch t.kind {
+
+	case timerRoom:
+		return fmt.Sprintf(`room %d`, t.roomId)
+
+	case timerMob:
+		if sMob := GetActor(0, t.mobInstanceId); sMob != nil {
+			return fmt.Sprintf(`%s (#%d)`, sMob.GetCharacterName(false), t.mobInstanceId)
+		}
+		return fmt.Sprintf(`mob #%d`, t.mobInstanceId)
+
+	case timerItem:
+		if sActor, itm, ok := t.findItem(); ok {
+			return fmt.Sprintf(`%s (%s)`, itm.NameSimple(), sActor.GetCharacterName(false))
+		}
+	}
+
+	return `gone`
+}
+
+// Finds the item of an item timer and who's carrying it, checking whoever had it last first
+func (t *scriptTimer) findItem() (*ScriptActor, items.Item, bool) {
+
+	if sActor := GetActor(t.userId, t.mobInstanceId); sActor != nil {
+		if itm, ok := findItemOn(sActor.characterRecord, t.itemUUID); ok {
+			return sActor, itm, true
+		}
+	}
+
+	for _, user := range users.GetAllActiveUsers() {
+		if itm, ok := findItemOn(user.Character, t.itemUUID); ok {
+			t.userId, t.mobInstanceId = user.UserId, 0
+			return GetActor(user.UserId, 0), itm, true
+		}
+	}
+
+	for _, mobInstanceId := range mobs.GetAllMobInstanceIds() {
+		if mob := mobs.GetInstance(mobInstanceId); mob != nil {
+			if itm, ok := findItemOn(&mob.Character, t.itemUUID); ok {
+				t.userId, t.mobInstanceId = 0, mobInstanceId
+				return GetActor(0, mobInstanceId), itm, true
+			}
+		}
+	}
+
+	return nil, items.Item{}, false
+}
+
+func findItemOn(c *characters.Character, itemUUID uuid.UUID) (items.Item, bool) {
+
+	for _, itm := range c.Items {
+		if itm.UUID == itemUUID {
+			return itm, true
+		}
+	}
+
+	for _, itm := range c.Equipment.GetAllItems() {
+		if itm.UUID == itemUUID {
+			return itm, true
+		}
+	}
+
+	return items.Item{}, false
+}
+
+// Calls the timer's callback with its owner. Room callbacks get (room),
+// mob callbacks get (mob, room) and item callbacks get (actor, item, room).
+func (t *scriptTimer) run() {
+
+	fn, vm := t.callback, t.vm
+
+	switch t.kind {
+
+	case timerRoom:
+
+		if t.persistent {
+			vmw, err := getRoomVM(t.roomId)
+			if err != nil {
+				mudlog.Error("RunTimers()", "roomId", t.roomId, "function", t.funcName, "error", err)
+				return
+			}
+			var ok bool
+			if fn, ok = vmw.GetFunction(t.funcName); !ok {
+				mudlog.Error("RunTimers()", "roomId", t.roomId, "function", t.funcName, "error", "function not found")
+				return
+			}
+			vm = vmw.VM
+		}
+
+		// Set forced ansi tag wrappers
+		userTextWrap.Set(`script-text`, ``, ``)
+		roomTextWrap.Set(`script-text`, ``, ``)
+
+		t.call(fn, vm, scriptRoomTimeout, vm.ToValue(GetRoom(t.roomId)))
+
+		userTextWrap.Reset()
+		roomTextWrap.Reset()
+
+	case timerMob:
+
+		sMob := GetActor(0, t.mobInstanceId)
+
+		t.call(fn, vm, scriptMobTimeout,
+			vm.ToValue(sMob),
+			vm.ToValue(GetRoom(sMob.GetRoomId())),
+		)
+
+	case timerItem:
+
+		sActor, itm, ok := t.findItem()
+		if !ok {
+			return
+		}
+		sItem := GetItem(itm)
+
+		if t.call(fn, vm, scriptItemTimeout,
+			vm.ToValue(sActor),
+			vm.ToValue(sItem),
+			vm.ToValue(GetRoom(sActor.GetRoomId())),
+		) {
+			// Save any changes that might have happened to the item
+			if !sActor.characterRecord.UpdateItem(itm, *sItem.itemRecord) {
+				sActor.characterRecord.Equipment.UpdateItem(itm, *sItem.itemRecord)
+			}
+		}
+	}
+}
+
+func (t *scriptTimer) call(fn goja.Callable, vm *goja.Runtime, timeout time.Duration, args ...goja.Value) bool {
+
+	tmr := time.AfterFunc(timeout, func() {
+		vm.Interrupt(errTimeout)
+	})
+	_, err := fn(goja.Undefined(), args...)
+	vm.ClearInterrupt()
+	tmr.Stop()
+
+	if err != nil {
+
+		// Wrap the error
+		finalErr := fmt.Errorf("timer %d %s(): %w", t.timerId, t.funcName, err)
+
+		if errors.Is(finalErr, errTimeout) {
+			countTimeout(t.kind)
+			mudlog.Error("JSVM", "interrupted", finalErr)
+			return false
+		}
+
+		mudlog.Error("JSVM", "exception", finalErr)
+		return false
+	}
+
+	return true
+}
+
+// The round a turn falls in, rounded up
+func dueRound(turn uint64) uint64 {
+
+	turnNow := util.GetTurnCount()
+	if turn <= turnNow {
+		return util.GetRoundCount()
+	}
+
+	return util.GetRoundCount() + (turn-turnNow+turnsPerRound()-1)/turnsPerRound()
+}
+
+func turnsPerRound() uint64 {
+	if tpr := configs.GetTimingConfig().TurnsPerRound(); tpr > 0 {
+		return uint64(tpr)
+	}
+	return 1
+}
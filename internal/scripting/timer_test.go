@@ -0,0 +1,89 @@
+package scripting
+
+import (
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A VM whose later() and every() set timers for a mob that doesn't exist
+func setupTimerVM(t *testing.T) *goja.Runtime {
+
+	t.Cleanup(func() {
+		clear(timers)
+	})
+
+	vm := goja.New()
+	vm.Set(`later`, func(call goja.FunctionCall) goja.Value {
+		return setTimer(scriptTimer{kind: timerMob, mobInstanceId: 999999}, call, vm, 3, false)
+	})
+	vm.Set(`every`, func(call goja.FunctionCall) goja.Value {
+		return setTimer(scriptTimer{kind: timerMob, mobInstanceId: 999999}, call, vm, 3, true)
+	})
+
+	return vm
+}
+
+func TestSetTimer(t *testing.T) {
+	vm := setupTimerVM(t)
+
+	_, err := vm.RunString(`
+function tick() {}
+var a = later(tick, 2);
+var b = every("tick", 0);
+var c = later(() => 1, 1);
+`)
+	require.NoError(t, err)
+
+	a := timers[int(vm.Get(`a`).ToInteger())]
+	require.NotNil(t, a)
+	assert.Equal(t, `tick`, a.funcName)
+	assert.Equal(t, util.GetTurnCount()+6, a.dueTurn)
+	assert.Zero(t, a.interval)
+
+	b := timers[int(vm.Get(`b`).ToInteger())]
+	require.NotNil(t, b)
+	assert.Equal(t, `tick`, b.funcName)
+	assert.Equal(t, uint64(3), b.interval)
+
+	c := timers[int(vm.Get(`c`).ToInteger())]
+	require.NotNil(t, c)
+	assert.Equal(t, ``, c.funcName)
+
+	infos := GetTimers()
+	require.Len(t, infos, 3)
+	assert.Equal(t, `(anonymous)`, infos[2].Function)
+	assert.Equal(t, `mob #999999`, infos[2].Owner)
+}
+
+func TestSetTimer_Errors(t *testing.T) {
+	vm := setupTimerVM(t)
+
+	_, err := vm.RunString(`later(5, 1)`)
+	assert.ErrorContains(t, err, `a timer needs a function`)
+
+	_, err = vm.RunString(`later("nope", 1)`)
+	assert.ErrorContains(t, err, `nope is not a function`)
+
+	_, err = vm.RunString(`function tick() {}; later(tick, 1, true)`)
+	assert.ErrorContains(t, err, `only room timers can be persistent`)
+
+	assert.Empty(t, timers)
+}
+
+func TestRunTimers_OwnerGone(t *testing.T) {
+	vm := setupTimerVM(t)
+
+	_, err := vm.RunString(`var ran = false; var id = every(() => { ran = true; }, 1);`)
+	require.NoError(t, err)
+
+	// The mob doesn't exist, so the timer is dropped rather than run
+	RunTimers(util.GetTurnCount() + 3)
+	assert.False(t, vm.Get(`ran`).ToBoolean())
+	assert.Empty(t, timers)
+
+	assert.False(t, StopTimer(int(vm.Get(`id`).ToInteger())))
+}
@@ -2,14 +2,17 @@ package scripting
 
 import (
 	"strings"
+	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/colorpatterns"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
 	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
 	"github.com/dop251/goja"
 )
 
@@ -43,6 +46,11 @@ func setUtilFunctions(vm *goja.Runtime) {
 	vm.Set(`UtilLocateUser`, UtilLocateUser)
 	vm.Set(`UtilApplyColorPattern`, UtilApplyColorPattern)
 	vm.Set(`UtilGetConfig`, UtilGetConfig)
+	vm.Set(`UtilGetWorldState`, UtilGetWorldState)
+	vm.Set(`UtilSetWorldState`, UtilSetWorldState)
+	vm.Set(`UtilIncrementWorldState`, UtilIncrementWorldState)
+	vm.Set(`UtilDeleteWorldState`, UtilDeleteWorldState)
+	vm.Set(`UtilGetWorldStateTTL`, UtilGetWorldStateTTL)
 	vm.Set(`ColorWrap`, ColorWrap)
 	vm.Set(`EventFlags`, EventFlags)
 	vm.Set(`RaiseEvent`, RaiseEvent)
@@ -167,6 +175,59 @@ func UtilGetConfig() configs.Config {
 	return configs.GetConfig()
 }
 
+func UtilGetWorldState(namespace string, key string) any {
+	return worldstate.Get(namespace, key)
+}
+
+func UtilSetWorldState(namespace string, key string, value any, ttlSeconds ...int) bool {
+
+	ttl := time.Duration(0)
+	if len(ttlSeconds) > 0 && ttlSeconds[0] > 0 {
+		ttl = time.Duration(ttlSeconds[0]) * time.Second
+	}
+
+	if err := worldstate.Set(namespace, key, value, ttl); err != nil {
+		mudlog.Error("UtilSetWorldState", "namespace", namespace, "key", key, "error", err)
+		return false
+	}
+
+	return true
+}
+
+func UtilIncrementWorldState(namespace string, key string, amount ...int) int {
+
+	incrementBy := 1
+	if len(amount) > 0 {
+		incrementBy = amount[0]
+	}
+
+	newValue, err := worldstate.Increment(namespace, key, incrementBy)
+	if err != nil {
+		mudlog.Error("UtilIncrementWorldState", "namespace", namespace, "key", key, "error", err)
+	}
+
+	return newValue
+}
+
+func UtilDeleteWorldState(namespace string, key string) bool {
+	return worldstate.Delete(namespace, key)
+}
+
+func UtilGetWorldStateTTL(namespace string, key string) int {
+
+	ttl, ok := worldstate.GetTTL(namespace, key)
+	if !ok {
+		return 0
+	}
+
+	if ttl == 0 {
+		return -1
+	}
+
+	// Round up, so it doesn't read as 0 while it's still set
+	return int((ttl + time.Second - 1) / time.Second)
+}
+
 func ColorWrap(txt string, colorClass ...string) string {
 
 	if len(colorClass) > 0 && colorClass[0] != `` {
@@ -153,6 +153,14 @@ func Process(fname string, data any, receivingUserId ...int) (string, error) {
 		templateConfigCache[userId] = tplConfig
 	}
 
+	// MXP depends on the client they are currently connected with, so don't cache it.
+	mxpEnabled := false
+	if userId > 0 && forceAnsiFlags != AnsiTagsStrip {
+		if tmpU := users.GetByUserId(userId); tmpU != nil {
+			mxpEnabled = tmpU.ClientSettings().IsMxp()
+		}
+	}
+
 	var buf bytes.Buffer
 
 	// Contains each template to attempt to load, in order.
@@ -188,7 +196,7 @@ func Process(fname string, data any, receivingUserId ...int) (string, error) {
 
 		if fileBytes, err := readFile(tplInfo.path); err == nil {
 
-			tpl, err := template.New(tplInfo.name).Funcs(funcMap).Parse(string(fileBytes))
+			tpl, err := template.New(tplInfo.name).Funcs(funcMap).Funcs(mxpFuncs(mxpEnabled)).Parse(string(fileBytes))
 			if err != nil {
 				return string(fileBytes), err
 			}
@@ -223,7 +231,7 @@ func Process(fname string, data any, receivingUserId ...int) (string, error) {
 		}
 
 		// parse the file contents as a template
-		tpl, err := template.New(tplInfo.name).Funcs(funcMap).Parse(string(fileContents))
+		tpl, err := template.New(tplInfo.name).Funcs(funcMap).Funcs(mxpFuncs(mxpEnabled)).Parse(string(fileContents))
 		if err != nil {
 			return string(fileContents), err
 		}
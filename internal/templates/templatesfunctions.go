@@ -16,6 +16,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/language"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/mattn/go-runewidth"
@@ -210,6 +211,14 @@ var (
 	}
 )
 
+func init() {
+	// Plain text versions, so any template can use them.
+	// Process() swaps in the MXP versions for clients that support it.
+	for name, f := range mxpFuncs(false) {
+		funcMap[name] = f
+	}
+}
+
 // Usage:
 //
 //	{{ implode .items "," }}
@@ -428,3 +437,68 @@ func makeMap(kvs ...any) map[any]any {
 
 	return m
 }
+
+var mxpEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`, `"`, `&quot;`)
+
+// Returns the MXP link functions bound to whether the receiving client has MXP enabled.
+// When it doesn't, every function just returns the display text.
+func mxpFuncs(enabled bool) template.FuncMap {
+
+	send := func(href string, text string, hint ...string) string {
+		if !enabled || href == `` {
+			return text
+		}
+		tag := `<send href="` + mxpEscaper.Replace(href) + `"`
+		if len(hint) > 0 && hint[0] != `` {
+			tag += ` hint="` + mxpEscaper.Replace(hint[0]) + `"`
+		}
+		return term.MxpTempSecure + tag + `>` + text + term.MxpTempSecure + `</send>`
+	}
+
+	return template.FuncMap{
+		// Usage:
+		//
+		//	{{ mxpsend "look sword|drop sword" "a sword" "Look at the sword" }}
+		//		OUTPUT: `<send href="look sword|drop sword" hint="Look at the sword">a sword</send>` (or "a sword" without MXP)
+		"mxpsend": send,
+		// Usage:
+		//
+		//	{{ mxpexit "north" }}
+		//		OUTPUT: `<send href="north" hint="Go north">north</send>` (or "north" without MXP)
+		"mxpexit": func(exitName string, text ...string) string {
+			if len(text) > 0 {
+				return send(exitName, text[0], `Go `+exitName)
+			}
+			return send(exitName, exitName, `Go `+exitName)
+		},
+		// Usage:
+		//
+		//	{{ mxphelp "say" }}
+		//		OUTPUT: `<send href="help say" hint="Help for say">say</send>` (or "say" without MXP)
+		"mxphelp": func(topic string, text ...string) string {
+			if len(text) > 0 {
+				return send(`help `+topic, text[0], `Help for `+topic)
+			}
+			return send(`help `+topic, topic, `Help for `+topic)
+		},
+		// Usage:
+		//
+		//	{{ mxpitem "sword" "<ansi fg=\"itemname\">sword</ansi>" "look" "drop" }}
+		//		OUTPUT: `<send href="look sword|drop sword" hint="...">...</send>` (or the text without MXP)
+		//		The first verb is the default click action, the rest show up in a right-click menu.
+		//		An empty item name is never clickable.
+		"mxpitem": func(itemName string, text string, verbs ...string) string {
+			if itemName == `` {
+				return text
+			}
+			if len(verbs) == 0 {
+				verbs = []string{`look`}
+			}
+			commands := make([]string, len(verbs))
+			for i, verb := range verbs {
+				commands[i] = verb + ` ` + itemName
+			}
+			return send(strings.Join(commands, `|`), text, strings.Join(commands, `|`))
+		},
+	}
+}
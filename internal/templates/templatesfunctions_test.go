@@ -1,8 +1,11 @@
 package templates
 
 import (
+	"bytes"
 	"testing"
+	"text/template"
 
+	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -203,3 +206,47 @@ func TestStringOr(t *testing.T) {
 		})
 	}
 }
+
+func TestMxpFuncs(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		tpl      string
+		enabled  bool
+		expected string
+	}{
+		{"Exit plain", `{{ mxpexit "north" }}`, false, "north"},
+		{"Exit MXP", `{{ mxpexit "north" }}`, true, "\033[4z<send href=\"north\" hint=\"Go north\">north\033[4z</send>"},
+		{"Help MXP with text", `{{ mxphelp "say" "help say" }}`, true, "\033[4z<send href=\"help say\" hint=\"Help for say\">help say\033[4z</send>"},
+		{"Item menu", `{{ mxpitem "sword" "a sword" "look" "drop" }}`, true, "\033[4z<send href=\"look sword|drop sword\" hint=\"look sword|drop sword\">a sword\033[4z</send>"},
+		{"Item without name", `{{ mxpitem "" "a corpse" "get" }}`, true, "a corpse"},
+		{"Send escapes attributes", `{{ mxpsend "say \"hi\"" "hi" }}`, true, "\033[4z<send href=\"say &quot;hi&quot;\">hi\033[4z</send>"},
+		{"Send plain", `{{ mxpsend "say hi" "hi" }}`, false, "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := template.Must(template.New("").Funcs(funcMap).Funcs(mxpFuncs(tt.enabled)).Parse(tt.tpl))
+			var buf bytes.Buffer
+			assert.NoError(t, tpl.Execute(&buf, nil))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestMxpEscape(t *testing.T) {
+
+	tpl := template.Must(template.New("").Funcs(funcMap).Funcs(mxpFuncs(true)).Parse(
+		`Bob says "<b>hi</b> & bye" {{ mxpitem "sword" "a <sharp> sword" "look" }} >`,
+	))
+	var buf bytes.Buffer
+	assert.NoError(t, tpl.Execute(&buf, nil))
+
+	assert.Equal(t,
+		"Bob says \"&lt;b&gt;hi&lt;/b&gt; &amp; bye\" \033[4z<send href=\"look sword\" hint=\"look sword\">a &lt;sharp&gt; sword\033[4z</send> &gt;",
+		term.MxpEscape(buf.String()),
+	)
+
+	// An unfinished tag isn't trusted
+	assert.Equal(t, "\033[4z&lt;send", term.MxpEscape("\033[4z<send"))
+}
@@ -0,0 +1,28 @@
+package term
+
+const (
+	MCCP2 IACByte = 86 // https://tintin.mudhalla.net/protocols/mccp/
+)
+
+/*
+Handshake
+The server sends IAC WILL MCCP2 to offer compression.
+The client responds with IAC DO MCCP2 or IAC DONT MCCP2.
+Once the server receives IAC DO MCCP2, it sends IAC SB MCCP2 IAC SE and
+everything sent after that sequence is a zlib stream.
+Compression is only ever applied to data going from the server to the client.
+*/
+
+var (
+	MccpEnable  = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MCCP2}, []byte{}} // Indicates the server wants to enable MCCP2.
+	MccpDisable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WONT, MCCP2}, []byte{}} // Indicates the server wants to disable MCCP2.
+
+	MccpAccept = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MCCP2}, []byte{}}   // Indicates the client accepts MCCP2
+	MccpRefuse = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MCCP2}, []byte{}} // Indicates the client refuses (or wants to stop) MCCP2
+
+	MccpStart = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MCCP2, TELNET_IAC, TELNET_SE}, []byte{}} // Everything after this is compressed
+)
+
+func IsMCCPCommand(b []byte) bool {
+	return len(b) > 2 && b[0] == TELNET_IAC && b[2] == MCCP2
+}
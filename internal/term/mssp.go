@@ -0,0 +1,84 @@
+package term
+
+import "strings"
+
+const (
+	MSSP     IACByte = 70 // https://tintin.mudhalla.net/protocols/mssp/
+	MSSP_VAR IACByte = 1
+	MSSP_VAL IACByte = 2
+
+	// Plain text fallback for crawlers that don't speak telnet
+	MsspPlainRequest    = `MSSP-REQUEST`
+	MsspPlainReplyStart = `MSSP-REPLY-START`
+	MsspPlainReplyEnd   = `MSSP-REPLY-END`
+)
+
+/*
+Handshake
+The server sends IAC WILL MSSP.
+The client responds with IAC DO MSSP or IAC DONT MSSP.
+Once the server receives IAC DO MSSP it sends the variables:
+IAC SB MSSP MSSP_VAR "PLAYERS" MSSP_VAL "52" MSSP_VAR "UPTIME" MSSP_VAL "1234567890" IAC SE
+A variable may have more than one MSSP_VAL (such as multiple ports)
+*/
+
+var (
+	MsspEnable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MSSP}, []byte{}} // Indicates the server supports MSSP.
+
+	MsspAccept = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MSSP}, []byte{}}   // Indicates the client wants MSSP data
+	MsspRefuse = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MSSP}, []byte{}} // Indicates the client doesn't want MSSP data
+
+	MsspCommand = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MSSP}, []byte{TELNET_IAC, TELNET_SE}} // Send via TELNET MSSP Command
+)
+
+type MSSPVariable struct {
+	Name   string
+	Values []string
+}
+
+func IsMSSPCommand(b []byte) bool {
+	return len(b) > 2 && b[0] == TELNET_IAC && b[2] == MSSP
+}
+
+// Encodes the variables into a telnet subnegotiation body
+func MsspPayload(vars []MSSPVariable) []byte {
+	payload := []byte{}
+	for _, v := range vars {
+		payload = append(payload, MSSP_VAR)
+		payload = append(payload, msspClean(v.Name)...)
+		for _, val := range v.Values {
+			payload = append(payload, MSSP_VAL)
+			payload = append(payload, msspClean(val)...)
+		}
+	}
+	return payload
+}
+
+// Encodes the variables as a plain text MSSP-REPLY
+// Multiple values are separated by tabs
+func MsspPlainText(vars []MSSPVariable) []byte {
+	var sb strings.Builder
+	sb.WriteString(MsspPlainReplyStart + CRLFStr)
+	for _, v := range vars {
+		sb.WriteString(string(msspClean(v.Name)))
+		for _, val := range v.Values {
+			sb.WriteString("\t" + string(msspClean(val)))
+		}
+		sb.WriteString(CRLFStr)
+	}
+	sb.WriteString(MsspPlainReplyEnd + CRLFStr)
+	return []byte(sb.String())
+}
+
+// Strips any bytes that would break MSSP framing
+func msspClean(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case MSSP_VAR, MSSP_VAL, TELNET_IAC, 0, '\t', '\r', '\n':
+			continue
+		}
+		out = append(out, s[i])
+	}
+	return out
+}
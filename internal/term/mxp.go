@@ -0,0 +1,69 @@
+package term
+
+import (
+	"strings"
+)
+
+const (
+	MXP IACByte = 91 // https://www.zuggsoft.com/zmud/mxp.htm
+)
+
+/*
+Handshake
+The server sends IAC WILL MXP to offer MXP.
+The client responds with IAC DO MXP or IAC DONT MXP.
+Once the server receives IAC DO MXP, it sends IAC SB MXP IAC SE and the
+client starts parsing MXP tags out of the text stream.
+
+<send> is a secure tag, so each opening and closing tag is prefixed with
+the "temp secure" line mode escape, which only applies to the next tag.
+*/
+
+const (
+	MxpTempSecure = "\033[4z" // The next tag is parsed in secure mode, then reverts to open mode
+)
+
+var (
+	MxpEnable  = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MXP}, []byte{}} // Indicates the server wants to enable MXP.
+	MxpDisable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WONT, MXP}, []byte{}} // Indicates the server wants to disable MXP.
+
+	MxpAccept = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MXP}, []byte{}}   // Indicates the client accepts MXP
+	MxpRefuse = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MXP}, []byte{}} // Indicates the client refuses MXP
+
+	MxpStart = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MXP, TELNET_IAC, TELNET_SE}, []byte{}} // Client should begin parsing MXP tags
+)
+
+func IsMXPCommand(b []byte) bool {
+	return len(b) > 2 && b[0] == TELNET_IAC && b[2] == MXP
+}
+
+var mxpEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+
+// Escapes text for a client that parses MXP, which treats any < or & in open mode as markup.
+// Tags the server marked with MxpTempSecure are left alone. Run it on the final text,
+// after ansi tags have been turned into escape codes, since they look like tags too.
+func MxpEscape(text string) string {
+
+	var sb strings.Builder
+
+	for {
+		start := strings.Index(text, MxpTempSecure+`<`)
+		if start == -1 {
+			break
+		}
+
+		end := strings.IndexByte(text[start:], '>')
+		if end == -1 {
+			break
+		}
+		end += start + 1
+
+		sb.WriteString(mxpEscaper.Replace(text[:start]))
+		sb.WriteString(text[start:end])
+		text = text[end:]
+	}
+
+	sb.WriteString(mxpEscaper.Replace(text))
+
+	return sb.String()
+}
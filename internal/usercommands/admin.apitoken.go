@@ -0,0 +1,195 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/apitokens"
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Role Permissions:
+* apitoken 				(All)
+ */
+func ApiToken(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		infoOutput, _ := templates.Process("admincommands/help/command.apitoken", nil, user.UserId)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case `list`:
+		return apitoken_List(len(args) > 1 && strings.ToLower(args[1]) == `all`, user)
+	case `info`:
+		return apitoken_Info(args[1:], user)
+	case `revoke`:
+		return apitoken_Revoke(args[1:], user)
+	case `create`:
+		return apitoken_Create(args[1:], user)
+	}
+
+	infoOutput, _ := templates.Process("admincommands/help/command.apitoken", nil, user.UserId)
+	user.SendText(infoOutput)
+	return true, nil
+}
+
+func apitoken_List(includeInactive bool, user *users.UserRecord) (bool, error) {
+
+	allTokens := apitokens.GetAll(includeInactive)
+
+	if len(allTokens) == 0 {
+		user.SendText(`There are no API tokens.`)
+		return true, nil
+	}
+
+	headers := []string{`Id`, `Kind`, `Subject`, `Status`, `Expires`, `Issued By`, `Note`}
+	rows := [][]string{}
+
+	for _, t := range allTokens {
+
+		note := t.Note
+		if len(note) > 30 {
+			note = note[:27] + `...`
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(t.TokenId),
+			string(t.Kind),
+			t.Subject,
+			t.Status(),
+			t.ExpiresString(),
+			t.IssuedBy,
+			note,
+		})
+	}
+
+	title := `Active API Tokens`
+	if includeInactive {
+		title = `All API Tokens`
+	}
+
+	tokenTableData := templates.GetTable(title, headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", tokenTableData, user.UserId, user.UserId)
+	user.SendText(tplTxt)
+
+	return true, nil
+}
+
+func apitoken_Info(args []string, user *users.UserRecord) (bool, error) {
+
+	t, ok := apitoken_Find(args, user)
+	if !ok {
+		return true, nil
+	}
+
+	user.SendText(``)
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">API Token #%d</ansi>`, t.TokenId))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Kind:      </ansi> %s`, t.Kind))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Subject:   </ansi> %s`, t.Subject))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Status:    </ansi> %s`, t.Status()))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Note:      </ansi> %s`, t.Note))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Issued By: </ansi> %s`, t.IssuedBy))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Issued At: </ansi> %s`, t.IssuedAt.Format(time.DateTime)))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Expires:   </ansi> %s`, t.ExpiresString()))
+	if !t.RevokedAt.IsZero() {
+		user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Revoked By:</ansi> %s`, t.RevokedBy))
+		user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Revoked At:</ansi> %s`, t.RevokedAt.Format(time.DateTime)))
+	}
+	user.SendText(``)
+
+	return true, nil
+}
+
+func apitoken_Revoke(args []string, user *users.UserRecord) (bool, error) {
+
+	t, ok := apitoken_Find(args, user)
+	if !ok {
+		return true, nil
+	}
+
+	t, err := apitokens.Revoke(t.TokenId, user.Username)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not revoke API token #%d: %s`, t.TokenId, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`API token #%d for <ansi fg="yellow">%s</ansi> (%s) has been <ansi fg="alert-1">revoked</ansi>.`, t.TokenId, t.Subject, t.Kind))
+
+	return true, nil
+}
+
+// apitoken create <user|role> <name> <duration> [note...]
+func apitoken_Create(args []string, user *users.UserRecord) (bool, error) {
+
+	if len(args) < 3 {
+		user.SendText(`Usage: <ansi fg="command">apitoken create [user|role] [username|role] [duration] [note]</ansi>`)
+		return true, nil
+	}
+
+	kind := apitokens.Kind(strings.ToLower(args[0]))
+	subject := args[1]
+
+	duration, err := bans.ParseDuration(args[2])
+	if err != nil {
+		user.SendText(fmt.Sprintf(`%s. Try something like <ansi fg="command">30d</ansi>, <ansi fg="command">12w</ansi> or <ansi fg="command">perm</ansi>.`, err))
+		return true, nil
+	}
+
+	switch kind {
+	case apitokens.KindUser:
+		if !users.Exists(subject) {
+			user.SendText(fmt.Sprintf(`There is no account named <ansi fg="username">%s</ansi>.`, subject))
+			return true, nil
+		}
+	case apitokens.KindRole:
+		subject = strings.ToLower(subject)
+		if _, ok := configs.GetRolesConfig()[subject]; !ok && subject != users.RoleAdmin && subject != users.RoleUser {
+			user.SendText(fmt.Sprintf(`There is no role named <ansi fg="yellow">%s</ansi>. Roles are set in the Roles section of the config.`, subject))
+			return true, nil
+		}
+	}
+
+	t, secret, err := apitokens.Create(kind, subject, strings.Join(args[3:], ` `), user.Username, duration)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not create API token: %s`, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`API token #%d created for <ansi fg="yellow">%s</ansi> (%s), expires: %s`, t.TokenId, t.Subject, t.Kind, t.ExpiresString()))
+	user.SendText(`Copy it now, it will not be shown again:`)
+	user.SendText(`    <ansi fg="white-bold">` + secret + `</ansi>`)
+	user.SendText(`Send it with each request as: <ansi fg="command">Authorization: Bearer ` + secret + `</ansi>`)
+
+	return true, nil
+}
+
+func apitoken_Find(args []string, user *users.UserRecord) (apitokens.Token, bool) {
+
+	if len(args) < 1 {
+		user.SendText(`Which token id?`)
+		return apitokens.Token{}, false
+	}
+
+	tokenId, _ := strconv.Atoi(strings.TrimPrefix(args[0], `#`))
+
+	t, ok := apitokens.Get(tokenId)
+	if !ok {
+		user.SendText(fmt.Sprintf(`API token <ansi fg="red">%s</ansi> not found.`, args[0]))
+		return apitokens.Token{}, false
+	}
+
+	return t, true
+}
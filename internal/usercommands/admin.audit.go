@@ -0,0 +1,118 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/audit"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Role Permissions:
+* audit 				(All)
+ */
+func Audit(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) > 0 && args[0] == `help` {
+		infoOutput, _ := templates.Process("admincommands/help/command.audit", nil, user.UserId)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	filter := audit.Filter{Limit: 20}
+	now := time.Now()
+
+	// Filters come in pairs, e.g. "user bob since 2d"
+	for i := 0; i < len(args); i += 2 {
+
+		if i+1 >= len(args) {
+			user.SendText(fmt.Sprintf(`Missing a value for <ansi fg="command">%s</ansi>. See <ansi fg="command">audit help</ansi>.`, args[i]))
+			return true, nil
+		}
+
+		name, val := strings.ToLower(args[i]), args[i+1]
+
+		switch name {
+		case `user`:
+			filter.Username = val
+		case `cmd`, `command`:
+			filter.Command = val
+		case `since`, `until`:
+			t, err := audit.ParseTime(val, now)
+			if err != nil {
+				user.SendText(fmt.Sprintf(`%s. Try something like <ansi fg="command">2h</ansi>, <ansi fg="command">3d</ansi> or <ansi fg="command">"2025-01-02 15:04"</ansi>.`, err))
+				return true, nil
+			}
+			if name == `since` {
+				filter.Since = t
+			} else {
+				filter.Until = t
+			}
+		case `limit`:
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				user.SendText(`The limit must be a positive number.`)
+				return true, nil
+			}
+			filter.Limit = n
+		default:
+			infoOutput, _ := templates.Process("admincommands/help/command.audit", nil, user.UserId)
+			user.SendText(infoOutput)
+			return true, nil
+		}
+	}
+
+	entries, err := audit.Search(filter)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not read the audit log: %s`, err))
+		return true, nil
+	}
+
+	if len(entries) == 0 {
+		user.SendText(`No matching audit entries.`)
+		return true, nil
+	}
+
+	headers := []string{`Time`, `User`, `Role`, `Room`, `Command`, `Outcome`}
+	rows := [][]string{}
+
+	for _, e := range entries {
+
+		fullCmd := e.FullCommand()
+		if len(fullCmd) > 50 {
+			fullCmd = fullCmd[:47] + `...`
+		}
+
+		outcome := e.Outcome
+		if e.Error != `` {
+			outcome += `: ` + e.Error
+			if len(outcome) > 30 {
+				outcome = outcome[:27] + `...`
+			}
+		}
+
+		rows = append(rows, []string{
+			e.Time.Format(time.DateTime),
+			e.Username,
+			e.Role,
+			strconv.Itoa(e.RoomId),
+			fullCmd,
+			outcome,
+		})
+	}
+
+	auditTableData := templates.GetTable(fmt.Sprintf(`Audit Log (%d most recent)`, len(entries)), headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", auditTableData, user.UserId, user.UserId)
+	user.SendText(tplTxt)
+
+	return true, nil
+}
@@ -0,0 +1,221 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Role Permissions:
+* ban 				(All)
+ */
+func Ban(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		infoOutput, _ := templates.Process("admincommands/help/command.ban", nil, user.UserId)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case `list`:
+		return ban_List(len(args) > 1 && strings.ToLower(args[1]) == `all`, user)
+	case `info`:
+		return ban_Info(args[1:], user)
+	case `lift`:
+		return ban_Lift(args[1:], user)
+	case `add`:
+		return ban_Add(args[1:], user)
+	}
+
+	infoOutput, _ := templates.Process("admincommands/help/command.ban", nil, user.UserId)
+	user.SendText(infoOutput)
+	return true, nil
+}
+
+func ban_List(includeInactive bool, user *users.UserRecord) (bool, error) {
+
+	allBans := bans.GetAll(includeInactive)
+
+	if len(allBans) == 0 {
+		user.SendText(`There are no bans.`)
+		return true, nil
+	}
+
+	headers := []string{`Id`, `Type`, `Target`, `Status`, `Expires`, `Issued By`, `Reason`}
+	rows := [][]string{}
+
+	for _, b := range allBans {
+
+		reason := b.Reason
+		if len(reason) > 30 {
+			reason = reason[:27] + `...`
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(b.BanId),
+			string(b.Type),
+			b.Target,
+			b.Status(),
+			b.ExpiresString(),
+			b.IssuedBy,
+			reason,
+		})
+	}
+
+	title := `Active Bans`
+	if includeInactive {
+		title = `All Bans`
+	}
+
+	banTableData := templates.GetTable(title, headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", banTableData, user.UserId)
+	user.SendText(tplTxt)
+
+	return true, nil
+}
+
+func ban_Info(args []string, user *users.UserRecord) (bool, error) {
+
+	b, ok := ban_Find(args, user)
+	if !ok {
+		return true, nil
+	}
+
+	user.SendText(``)
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">Ban #%d</ansi>`, b.BanId))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Type:      </ansi> %s`, b.Type))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Target:    </ansi> %s`, b.Target))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Status:    </ansi> %s`, b.Status()))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Reason:    </ansi> %s`, b.Reason))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Issued By: </ansi> %s`, b.IssuedBy))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Issued At: </ansi> %s`, b.IssuedAt.Format(time.DateTime)))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Expires:   </ansi> %s`, b.ExpiresString()))
+	if !b.LiftedAt.IsZero() {
+		user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Lifted By: </ansi> %s`, b.LiftedBy))
+		user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Lifted At: </ansi> %s`, b.LiftedAt.Format(time.DateTime)))
+	}
+	user.SendText(``)
+
+	return true, nil
+}
+
+func ban_Lift(args []string, user *users.UserRecord) (bool, error) {
+
+	b, ok := ban_Find(args, user)
+	if !ok {
+		return true, nil
+	}
+
+	b, err := bans.Lift(b.BanId, user.Username)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not lift ban #%d: %s`, b.BanId, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`Ban #%d on <ansi fg="yellow">%s</ansi> (%s) has been <ansi fg="alert-1">lifted</ansi>.`, b.BanId, b.Target, b.Type))
+
+	return true, nil
+}
+
+// ban add <account|ip> <target> <duration> <reason...>
+func ban_Add(args []string, user *users.UserRecord) (bool, error) {
+
+	if len(args) < 4 {
+		user.SendText(`Usage: <ansi fg="command">ban add [account|ip] [username|ip|cidr] [duration] [reason]</ansi>`)
+		return true, nil
+	}
+
+	banType := bans.BanType(strings.ToLower(args[0]))
+	target := args[1]
+
+	duration, err := bans.ParseDuration(args[2])
+	if err != nil {
+		user.SendText(fmt.Sprintf(`%s. Try something like <ansi fg="command">30m</ansi>, <ansi fg="command">12h</ansi>, <ansi fg="command">7d</ansi>, <ansi fg="command">2w</ansi> or <ansi fg="command">perm</ansi>.`, err))
+		return true, nil
+	}
+
+	if banType == bans.BanAccount {
+		if !users.Exists(target) {
+			user.SendText(fmt.Sprintf(`There is no account named <ansi fg="username">%s</ansi>.`, target))
+			return true, nil
+		}
+		if strings.EqualFold(target, user.Username) {
+			user.SendText(`You can't ban yourself.`)
+			return true, nil
+		}
+	}
+
+	b, err := bans.Add(banType, target, strings.Join(args[3:], ` `), user.Username, duration)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not add ban: %s`, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`Ban #%d added on <ansi fg="yellow">%s</ansi> (%s), expires: %s`, b.BanId, b.Target, b.Type, b.ExpiresString()))
+
+	// Remove anyone already online that the ban covers
+	for _, u := range users.GetAllActiveUsers() {
+
+		if u.UserId == user.UserId {
+			continue
+		}
+
+		if b.Type == bans.BanAccount && !strings.EqualFold(u.Username, b.Target) {
+			continue
+		}
+
+		if b.Type == bans.BanIP {
+			cd := connections.Get(u.ConnectionId())
+			if cd == nil {
+				continue
+			}
+			if _, banned := bans.CheckIP(cd.RemoteAddr()); !banned {
+				continue
+			}
+		}
+
+		connections.SendTo([]byte(term.CRLFStr+b.Message()+term.CRLFStr), u.ConnectionId())
+
+		events.AddToQueue(events.System{
+			Command:     `kick`,
+			Data:        u.UserId,
+			Description: fmt.Sprintf(`Banned (#%d)`, b.BanId),
+		})
+
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> was online and has been kicked.`, u.Username))
+	}
+
+	return true, nil
+}
+
+func ban_Find(args []string, user *users.UserRecord) (bans.Ban, bool) {
+
+	if len(args) < 1 {
+		user.SendText(`Which ban id?`)
+		return bans.Ban{}, false
+	}
+
+	banId, _ := strconv.Atoi(strings.TrimPrefix(args[0], `#`))
+
+	b, ok := bans.Get(banId)
+	if !ok {
+		user.SendText(fmt.Sprintf(`Ban <ansi fg="red">%s</ansi> not found.`, args[0]))
+		return bans.Ban{}, false
+	}
+
+	return b, true
+}
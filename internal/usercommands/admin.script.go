@@ -0,0 +1,158 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Role Permissions:
+* script 				(All)
+ */
+func Script(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	// The code is passed on as typed, so only the first word or two are split off
+	subCmd, code, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+
+	switch strings.ToLower(subCmd) {
+
+	case `eval`:
+		return script_Eval(code, 0, user, room)
+
+	case `mob`:
+		args := util.SplitButRespectQuotes(code)
+		if len(args) < 2 {
+			user.SendText(`Which mob, and what code? e.g. <ansi fg="command">script mob guard mob.GetCharacterName(true)</ansi>`)
+			return true, nil
+		}
+
+		_, mobInstanceId := room.FindByName(args[0])
+		if mobInstanceId == 0 {
+			user.SendText(fmt.Sprintf(`There's no mob called <ansi fg="mobname">%s</ansi> here.`, args[0]))
+			return true, nil
+		}
+
+		// Skip past the mob's name, which may have been quoted
+		code = strings.TrimSpace(code)
+		if code[0] == '"' {
+			code = code[strings.IndexByte(code[1:], '"')+2:]
+		} else {
+			code = code[len(args[0]):]
+		}
+
+		return script_Eval(code, mobInstanceId, user, room)
+
+	case `timers`:
+		return script_Timers(code, user)
+
+	case `reset`:
+		scripting.PruneEvalVMs(user.UserId)
+		user.SendText(`Your script variables have been cleared.`)
+		return true, nil
+	}
+
+	infoOutput, _ := templates.Process("admincommands/help/command.script", nil, user.UserId)
+	user.SendText(infoOutput)
+
+	return true, nil
+}
+
+func script_Eval(code string, mobInstanceId int, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	code = strings.TrimSpace(code)
+	if code == `` {
+		user.SendText(`What code? e.g. <ansi fg="command">script eval room.GetExits()</ansi>`)
+		return true, nil
+	}
+
+	result, err := scripting.Eval(code, user.UserId, room.RoomId, mobInstanceId)
+
+	for _, line := range result.Console {
+		user.SendText(line)
+	}
+
+	if err != nil {
+		user.SendText(fmt.Sprintf(`<ansi fg="red">%s</ansi>`, err))
+		return true, nil
+	}
+
+	if result.Value != `` {
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow">=></ansi> %s`, result.Value))
+	}
+
+	return true, nil
+}
+
+// script timers [stop <id>]
+func script_Timers(rest string, user *users.UserRecord) (bool, error) {
+
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	if len(args) > 0 && args[0] == `stop` {
+
+		timerId := 0
+		if len(args) > 1 {
+			timerId, _ = strconv.Atoi(args[1])
+		}
+
+		if timerId == 0 {
+			user.SendText(`Usage: <ansi fg="command">script timers stop [id]</ansi>`)
+			return true, nil
+		}
+
+		if !scripting.StopTimer(timerId) {
+			user.SendText(fmt.Sprintf(`There's no timer <ansi fg="yellow">%d</ansi>.`, timerId))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`Timer <ansi fg="yellow">%d</ansi> has been <ansi fg="alert-1">stopped</ansi>.`, timerId))
+		return true, nil
+	}
+
+	allTimers := scripting.GetTimers()
+
+	if len(allTimers) == 0 {
+		user.SendText(`No script timers are running.`)
+		return true, nil
+	}
+
+	headers := []string{`Id`, `Kind`, `Owner`, `Function`, `Turns Left`, `Repeats`, `Persistent`}
+	rows := [][]string{}
+
+	for _, t := range allTimers {
+
+		repeats := `no`
+		if t.Interval > 0 {
+			repeats = fmt.Sprintf(`every %d turns`, t.Interval)
+		}
+
+		persistent := `no`
+		if t.Persistent {
+			persistent = `yes`
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(t.TimerId),
+			t.Kind,
+			t.Owner,
+			t.Function,
+			strconv.FormatUint(t.TurnsLeft, 10),
+			repeats,
+			persistent,
+		})
+	}
+
+	timerTableData := templates.GetTable(`Script Timers`, headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", timerTableData, user.UserId, user.UserId)
+	user.SendText(tplTxt)
+
+	return true, nil
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
@@ -119,6 +121,23 @@ func Server(rest string, user *users.UserRecord, room *rooms.Room, flags events.
 		return true, nil
 	}
 
+	if rest == "copyover" {
+		// Handled by the world, since it needs the listeners and connections
+		events.AddToQueue(events.System{
+			Command: `copyover`,
+			Data:    user.UserId,
+		})
+		return true, nil
+	}
+
+	if args[0] == "passwords" {
+		return server_Passwords(args[1:], user)
+	}
+
+	if args[0] == "profile" {
+		return server_Profile(args[1:], user)
+	}
+
 	if rest == "reload-ansi" {
 		templates.LoadAliases()
 		user.SendText(`ansi aliases reloaded`)
@@ -293,8 +312,178 @@ func Server(rest string, user *users.UserRecord, room *rooms.Room, flags events.
 		memRepTblData := templates.GetTable(`Specific Memory`, memRepHeaders, memRepRows, memRepFormatting)
 		memRepTxt, _ := templates.Process("tables/generic", memRepTblData, user.UserId)
 		user.SendText(memRepTxt)
+
+		//
+		// Per connection compression stats
+		//
+		connHeaders := []string{"User", "ConnId", "Type", "MCCP", "Raw", "Sent", "Saved"}
+		connFormatting := []string{`<ansi fg="yellow-bold">%s</ansi>`,
+			`<ansi fg="black-bold">%s</ansi>`,
+			`<ansi fg="black-bold">%s</ansi>`,
+			`<ansi fg="cyan-bold">%s</ansi>`,
+			`<ansi fg="red">%s</ansi>`,
+			`<ansi fg="red">%s</ansi>`,
+			`<ansi fg="green">%s</ansi>`}
+
+		connRows := [][]string{}
+		for _, u := range users.GetAllActiveUsers() {
+
+			connId := u.ConnectionId()
+
+			connType := `telnet`
+			if cd := connections.Get(connId); cd != nil {
+				if cd.IsWebSocket() {
+					connType = `websocket`
+				} else if cd.IsTLS() {
+					connType = `telnet-tls`
+				}
+			}
+
+			cStats := connections.GetCompressionStats(connId)
+
+			mccpStatus := `off`
+			if cStats.Enabled {
+				mccpStatus = `on`
+			}
+
+			connRows = append(connRows, []string{
+				u.Username,
+				fmt.Sprintf(`%d`, connId),
+				connType,
+				mccpStatus,
+				util.FormatBytes(cStats.BytesIn),
+				util.FormatBytes(cStats.BytesOut),
+				fmt.Sprintf(`%3.1f%%`, cStats.Savings()),
+			})
+		}
+
+		connTblData := templates.GetTable(`Connection Compression`, connHeaders, connRows, connFormatting)
+		connTxt, _ := templates.Process("tables/generic", connTblData, user.UserId)
+		user.SendText(connTxt)
+	}
+
+	return true, nil
+}
+
+// Starts, stops or shows the event listener profile
+// server profile [start|stop|all|<count>]
+func server_Profile(args []string, user *users.UserRecord) (bool, error) {
+
+	showCount := 20
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case `start`:
+			events.StartProfiling()
+			user.SendText(`Profiling event listeners. Use <ansi fg="command">server profile</ansi> to see the results so far, and <ansi fg="command">server profile stop</ansi> when done.`)
+			return true, nil
+		case `stop`:
+			events.StopProfiling()
+			user.SendText(`Profiling stopped. Use <ansi fg="command">server profile</ansi> to see the results.`)
+			return true, nil
+		case `all`:
+			showCount = 0
+		default:
+			if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+				showCount = n
+			}
+		}
+	}
+
+	running, duration := events.ProfilingStatus()
+	if duration == 0 {
+		user.SendText(`No profile has been taken. Start one with <ansi fg="command">server profile start</ansi>.`)
+		return true, nil
 	}
 
+	profile := events.GetProfile()
+
+	var totalTime time.Duration
+	for _, p := range profile {
+		totalTime += p.Total
+	}
+
+	status := `stopped`
+	if running {
+		status = `running`
+	}
+
+	headers := []string{"Listener", "Event", "Calls", "Total", "Avg", "Max", "Cancel", "Requeue", "%"}
+	formatting := []string{`<ansi fg="yellow-bold">%s</ansi>`, `<ansi fg="black-bold">%s</ansi>`, `<ansi fg="black-bold">%s</ansi>`, `<ansi fg="cyan-bold">%s</ansi>`, `<ansi fg="cyan-bold">%s</ansi>`, `<ansi fg="cyan-bold">%s</ansi>`, `<ansi fg="black-bold">%s</ansi>`, `<ansi fg="black-bold">%s</ansi>`, `<ansi fg="red">%s</ansi>`}
+	rows := [][]string{}
+
+	for i, p := range profile {
+		if showCount > 0 && i >= showCount {
+			break
+		}
+
+		pct := 0.0
+		if totalTime > 0 {
+			pct = float64(p.Total) / float64(totalTime) * 100
+		}
+
+		rows = append(rows, []string{
+			p.Listener,
+			p.EventType,
+			strconv.FormatUint(p.Calls, 10),
+			fmt.Sprintf(`%.3fms`, float64(p.Total)/float64(time.Millisecond)),
+			fmt.Sprintf(`%.3fms`, float64(p.Average())/float64(time.Millisecond)),
+			fmt.Sprintf(`%.3fms`, float64(p.Max)/float64(time.Millisecond)),
+			strconv.FormatUint(p.Cancels, 10),
+			strconv.FormatUint(p.Requeues, 10),
+			fmt.Sprintf(`%.1f`, pct),
+		})
+	}
+
+	title := fmt.Sprintf(`Listener Profile (%s, %s, %.3fms in listeners)`, status, duration.Round(time.Second), float64(totalTime)/float64(time.Millisecond))
+	tblData := templates.GetTable(title, headers, rows, formatting)
+	tplTxt, _ := templates.Process("tables/generic", tblData, user.UserId)
+	user.SendText(tplTxt)
+
+	if showCount > 0 && len(profile) > showCount {
+		user.SendText(fmt.Sprintf(`Showing the top %d of %d. Use <ansi fg="command">server profile all</ansi> to see them all.`, showCount, len(profile)))
+	}
+
+	return true, nil
+}
+
+// Reports accounts still on legacy (unsalted/plaintext) passwords, or forces a reset
+func server_Passwords(args []string, user *users.UserRecord) (bool, error) {
+
+	if len(args) == 0 {
+
+		currentCt, legacyUsernames := users.LegacyPasswordReport()
+		slices.Sort(legacyUsernames)
+
+		user.SendText(``)
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">Salted passwords:</ansi> <ansi fg="green">%d</ansi>`, currentCt))
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">Legacy passwords:</ansi> <ansi fg="red">%d</ansi>`, len(legacyUsernames)))
+
+		if len(legacyUsernames) > 0 {
+			user.SendText(`  ` + util.SplitStringNL(strings.Join(legacyUsernames, `, `), 76, `  `))
+			user.SendText(``)
+			user.SendText(`Legacy passwords are upgraded the next time their owner logs in.`)
+		}
+		user.SendText(``)
+
+		return true, nil
+	}
+
+	if args[0] != "reset" || len(args) < 2 {
+		infoOutput, _ := templates.Process("admincommands/help/command.server", nil, user.UserId)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	tempPw, err := users.ResetPassword(args[1])
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not reset the password for <ansi fg="username">%s</ansi>: %s`, args[1], err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`Password for <ansi fg="username">%s</ansi> reset to: <ansi fg="red-bold">%s</ansi>`, args[1], tempPw))
+	user.SendText(`Pass it on to them and ask them to change it with the <ansi fg="command">password</ansi> command.`)
+
 	return true, nil
 }
 
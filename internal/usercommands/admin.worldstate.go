@@ -0,0 +1,190 @@
+package usercommands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
+)
+
+/*
+* Role Permissions:
+* worldstate 				(All)
+ */
+func WorldState(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	// Values are taken as typed, so only the first few words are split off
+	subCmd, rest, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+	namespace, rest, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+	key, value, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(subCmd) {
+	case `list`:
+		return worldstate_List(namespace, user)
+	case `get`:
+		return worldstate_Get(namespace, key, user)
+	case `set`:
+		return worldstate_Set(namespace, key, value, user)
+	case `ttl`:
+		return worldstate_TTL(namespace, key, value, user)
+	case `delete`:
+		return worldstate_Delete(namespace, key, user)
+	}
+
+	infoOutput, _ := templates.Process("admincommands/help/command.worldstate", nil, user.UserId)
+	user.SendText(infoOutput)
+	return true, nil
+}
+
+func worldstate_List(namespace string, user *users.UserRecord) (bool, error) {
+
+	allValues := worldstate.GetAll(namespace)
+
+	if len(allValues) == 0 {
+		if namespace == `` {
+			user.SendText(`The world state is empty.`)
+		} else {
+			user.SendText(fmt.Sprintf(`Nothing is set in <ansi fg="yellow">%s</ansi>.`, namespace))
+		}
+		return true, nil
+	}
+
+	headers := []string{`Namespace`, `Key`, `Value`, `Expires`}
+	rows := [][]string{}
+
+	for _, kv := range allValues {
+
+		valueStr := worldstate_Format(kv.Value)
+		if len(valueStr) > 40 {
+			valueStr = valueStr[:37] + `...`
+		}
+
+		rows = append(rows, []string{
+			kv.Namespace,
+			kv.Key,
+			valueStr,
+			worldstate_Expires(kv.Namespace, kv.Key),
+		})
+	}
+
+	title := `World State`
+	if namespace != `` {
+		title = `World State: ` + namespace
+	}
+
+	stateTableData := templates.GetTable(title, headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", stateTableData, user.UserId, user.UserId)
+	user.SendText(tplTxt)
+
+	return true, nil
+}
+
+func worldstate_Get(namespace string, key string, user *users.UserRecord) (bool, error) {
+
+	if key == `` {
+		user.SendText(`Usage: <ansi fg="command">worldstate get [namespace] [key]</ansi>`)
+		return true, nil
+	}
+
+	value := worldstate.Get(namespace, key)
+	if value == nil {
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> is not set.`, namespace, key))
+		return true, nil
+	}
+
+	user.SendText(``)
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">%s %s</ansi>`, namespace, key))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Value:   </ansi> %s`, worldstate_Format(value)))
+	user.SendText(fmt.Sprintf(`  <ansi fg="yellow">Expires: </ansi> %s`, worldstate_Expires(namespace, key)))
+	user.SendText(``)
+
+	return true, nil
+}
+
+// worldstate set <namespace> <key> <value...>
+func worldstate_Set(namespace string, key string, valueStr string, user *users.UserRecord) (bool, error) {
+
+	if valueStr == `` {
+		user.SendText(`Usage: <ansi fg="command">worldstate set [namespace] [key] [value]</ansi>`)
+		return true, nil
+	}
+
+	// JSON such as 5, true, [1,2] or {"a":1}, otherwise a plain string
+	var value any
+	if err := json.Unmarshal([]byte(valueStr), &value); err != nil || value == nil {
+		value = valueStr
+	}
+
+	if err := worldstate.Set(namespace, key, value, 0); err != nil {
+		user.SendText(fmt.Sprintf(`Could not set <ansi fg="yellow">%s %s</ansi>: %s`, namespace, key, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> set to %s`, namespace, key, worldstate_Format(worldstate.Get(namespace, key))))
+
+	return true, nil
+}
+
+// worldstate ttl <namespace> <key> <duration>
+func worldstate_TTL(namespace string, key string, durationStr string, user *users.UserRecord) (bool, error) {
+
+	if durationStr == `` {
+		user.SendText(`Usage: <ansi fg="command">worldstate ttl [namespace] [key] [duration]</ansi>`)
+		return true, nil
+	}
+
+	duration, err := bans.ParseDuration(durationStr)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`%s. Try something like <ansi fg="command">30m</ansi>, <ansi fg="command">12h</ansi>, <ansi fg="command">7d</ansi> or <ansi fg="command">perm</ansi>.`, err))
+		return true, nil
+	}
+
+	if !worldstate.SetTTL(namespace, key, duration) {
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> is not set.`, namespace, key))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> expires: %s`, namespace, key, worldstate_Expires(namespace, key)))
+
+	return true, nil
+}
+
+func worldstate_Delete(namespace string, key string, user *users.UserRecord) (bool, error) {
+
+	if key == `` {
+		user.SendText(`Usage: <ansi fg="command">worldstate delete [namespace] [key]</ansi>`)
+		return true, nil
+	}
+
+	if !worldstate.Delete(namespace, key) {
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> is not set.`, namespace, key))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow">%s %s</ansi> has been <ansi fg="alert-1">deleted</ansi>.`, namespace, key))
+
+	return true, nil
+}
+
+func worldstate_Format(value any) string {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueBytes)
+}
+
+func worldstate_Expires(namespace string, key string) string {
+	ttl, ok := worldstate.GetTTL(namespace, key)
+	if !ok || ttl == 0 {
+		return `never`
+	}
+	return fmt.Sprintf(`in %s`, bans.FormatDuration(ttl))
+}
@@ -62,7 +62,9 @@ func Help(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 				continue
 			}
 
-			hlpCmd := helpCommand{Command: command.Command, Type: command.Type, Missing: !templates.Exists(templateFile)}
+			_, hasScriptHelp := getScriptCommandHelp(command.Command)
+
+			hlpCmd := helpCommand{Command: command.Command, Type: command.Type, Missing: !hasScriptHelp && !templates.Exists(templateFile)}
 
 			if command.Type == `skill` {
 				helpCommandList.Skills[category] = append(helpCommandList.Skills[category], hlpCmd)
@@ -152,6 +154,10 @@ func GetHelpContents(input string) (string, error) {
 		helpName = keywords.TryHelpAlias(helpName)
 	}
 
+	if scriptHelp, ok := getScriptCommandHelp(helpName); ok {
+		return scriptHelp, nil
+	}
+
 	var helpVars any = nil
 
 	if helpName == `emote` {
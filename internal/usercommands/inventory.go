@@ -17,6 +17,7 @@ func Inventory(rest string, user *users.UserRecord, room *rooms.Room, flags even
 
 	itemNames := []string{}
 	itemNamesFormatted := []string{}
+	itemKeys := []string{} // Plain names used for clickable (MXP) links
 
 	itemList := []items.Item{}
 
@@ -126,6 +127,7 @@ func Inventory(rest string, user *users.UserRecord, room *rooms.Room, flags even
 		}
 		itemNames = append(itemNames, iName)
 		itemNamesFormatted = append(itemNamesFormatted, iNameFormatted)
+		itemKeys = append(itemKeys, item.Name())
 	}
 
 	raceInfo := races.GetRace(user.Character.RaceId)
@@ -140,6 +142,7 @@ func Inventory(rest string, user *users.UserRecord, room *rooms.Room, flags even
 		`Equipment`:          &user.Character.Equipment,
 		`ItemNames`:          itemNames,
 		`ItemNamesFormatted`: itemNamesFormatted,
+		`ItemKeys`:           itemKeys,
 		`AttackDamage`:       diceRoll,
 		`RaceInfo`:           raceInfo,
 		`Searching`:          len(rest) > 0,
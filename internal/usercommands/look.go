@@ -548,6 +548,7 @@ func lookRoom(user *users.UserRecord, roomId int, secretLook bool) {
 	}
 
 	groundStuff := []string{}
+	groundNames := []string{} // Plain names for clickable (MXP) links, empty if not clickable
 	for containerName, container := range room.Containers {
 
 		chestName := fmt.Sprintf(`<ansi fg="container">%s</ansi>`, containerName)
@@ -561,11 +562,13 @@ func lookRoom(user *users.UserRecord, roomId int, secretLook bool) {
 		}
 
 		groundStuff = append(groundStuff, chestName)
+		groundNames = append(groundNames, ``)
 
 	}
 
 	if room.Gold > 0 {
 		groundStuff = append(groundStuff, fmt.Sprintf(`<ansi fg="gold">%d gold</ansi>`, room.Gold))
+		groundNames = append(groundNames, ``)
 	}
 
 	for _, item := range room.Items {
@@ -574,6 +577,7 @@ func lookRoom(user *users.UserRecord, roomId int, secretLook bool) {
 			continue
 		}
 		groundStuff = append(groundStuff, item.DisplayName())
+		groundNames = append(groundNames, item.Name())
 	}
 
 	// Find stashed items
@@ -586,12 +590,15 @@ func lookRoom(user *users.UserRecord, roomId int, secretLook bool) {
 		}
 		name := item.DisplayName() + ` <ansi fg="item-stashed">(stashed)</ansi>`
 		groundStuff = append(groundStuff, name)
+		groundNames = append(groundNames, item.Name())
 	}
 
 	groundStuff = append(groundStuff, details.VisibleCorpses...)
+	groundNames = append(groundNames, make([]string, len(details.VisibleCorpses))...)
 
 	groundDetails := map[string]any{
 		`GroundStuff`: groundStuff,
+		`GroundNames`: groundNames,
 		`IsDark`:      room.GetBiome().IsDark(),
 		`IsNight`:     gametime.IsNight(),
 	}
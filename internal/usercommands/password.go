@@ -4,10 +4,19 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
 	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
+// Users with a password change being hashed. Guarded by the MUD lock.
+var passwordChanges = map[int]struct{}{}
+
 func Password(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
 
+	if _, ok := passwordChanges[user.UserId]; ok {
+		user.SendText(`<ansi fg="alert-3">Your last password change is still being processed.</ansi>`)
+		return true, nil
+	}
+
 	// Get if already exists, otherwise create new
 	cmdPrompt, _ := user.StartPrompt(`password`, rest)
 
@@ -16,11 +25,7 @@ func Password(rest string, user *users.UserRecord, room *rooms.Room, flags event
 		return true, nil
 	}
 
-	if !user.PasswordMatches(question.Response) {
-		user.SendText(`<ansi fg="alert-5">Sorry, your password was incorrect.</ansi>`)
-		user.ClearPrompt()
-		return true, nil
-	}
+	currentPW := question.Response
 
 	question = cmdPrompt.Ask(`What new password would you like?`, []string{})
 	if !question.Done {
@@ -36,21 +41,58 @@ func Password(rest string, user *users.UserRecord, room *rooms.Room, flags event
 
 	newPWConfirm := question.Response
 
+	user.ClearPrompt()
+
 	if newPW != newPWConfirm {
 		user.SendText(`<ansi fg="alert-5">Sorry, your new password and the confirmation password did not match.</ansi>`)
-		user.ClearPrompt()
 		return true, nil
 	}
 
-	if err := user.SetPassword(newPW); err != nil {
+	if err := users.ValidatePassword(newPW); err != nil {
 		user.SendText(`<ansi fg="alert-5">` + err.Error() + `</ansi>`)
-		user.ClearPrompt()
 		return true, nil
 	}
 
-	users.SaveUser(*user)
+	// Checking and hashing are slow on purpose, so they happen off the game loop
+	// and only the result is applied under the MUD lock.
+	passwordChanges[user.UserId] = struct{}{}
+	userCopy := *user
+
+	go func() {
+
+		ok, _ := userCopy.CheckPassword(currentPW)
+
+		newHash := ``
+		var err error
+		if ok {
+			newHash, err = users.NewPasswordHash(newPW)
+		}
+
+		util.LockMud()
+		defer util.UnlockMud()
+
+		delete(passwordChanges, userCopy.UserId)
+
+		user := users.GetByUserId(userCopy.UserId)
+		if user == nil {
+			return
+		}
+
+		if !ok {
+			user.SendText(`<ansi fg="alert-5">Sorry, your password was incorrect.</ansi>`)
+			return
+		}
+
+		if err != nil {
+			user.SendText(`<ansi fg="alert-5">` + err.Error() + `</ansi>`)
+			return
+		}
+
+		user.Password = newHash
+		users.SaveUser(*user)
 
-	user.SendText(`<ansi fg="alert-1">Your password has been changed!</ansi>`)
+		user.SendText(`<ansi fg="alert-1">Your password has been changed!</ansi>`)
+	}()
 
 	return true, nil
 }
@@ -0,0 +1,81 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+var (
+	// Commands defined by scripts in the world's commands folder
+	scriptCommands = map[string]scripting.CommandInfo{}
+)
+
+// Loads (or reloads) the commands scripts define, and adds them to help and the command suggestions.
+// Has to run after the keywords are loaded. A script can't replace a command defined in Go.
+// The help topics and aliases of the last load are removed first, so deleted or renamed scripts don't leave any behind.
+func LoadScriptCommands() {
+
+	for name := range scriptCommands {
+		delete(userCommands, name)
+	}
+	clear(scriptCommands)
+	keywords.RemoveAddedCommands()
+
+	for _, info := range scripting.LoadCommands() {
+
+		if _, ok := userCommands[info.Name]; ok {
+			mudlog.Warn("LoadScriptCommands", "command", info.Name, "error", "a command with this name already exists")
+			continue
+		}
+
+		aliases := []string{}
+		for _, alias := range info.Aliases {
+			if _, ok := userCommands[alias]; ok {
+				mudlog.Warn("LoadScriptCommands", "command", info.Name, "alias", alias, "error", "a command with this name already exists")
+				continue
+			}
+			aliases = append(aliases, alias)
+		}
+
+		RegisterCommand(info.Name, scriptCommand(info.Name), !info.DisabledWhenDowned, info.AdminOnly)
+		scriptCommands[info.Name] = info
+
+		keywords.AddCommand(info.Name, info.Category, info.AdminOnly, aliases...)
+	}
+}
+
+func scriptCommand(name string) UserCommand {
+	return func(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+		return scripting.TryCommandScript(name, rest, user.UserId)
+	}
+}
+
+// The help text of a script command, styled like the help templates
+func getScriptCommandHelp(name string) (string, bool) {
+
+	info, ok := scriptCommands[name]
+	if !ok || info.Help == `` {
+		return ``, false
+	}
+
+	helpTxt := strings.Builder{}
+	helpTxt.WriteString(fmt.Sprintf(`<ansi fg="black-bold">.:</ansi> <ansi fg="magenta">Help for </ansi><ansi fg="command">%s</ansi>`, info.Name))
+	helpTxt.WriteString("\n\n")
+	helpTxt.WriteString(strings.TrimSpace(info.Help))
+	helpTxt.WriteString("\n")
+
+	if len(info.Aliases) > 0 {
+		helpTxt.WriteString("\n")
+		helpTxt.WriteString(fmt.Sprintf(`<ansi fg="yellow">Aliases: </ansi><ansi fg="command">%s</ansi>`, strings.Join(info.Aliases, `</ansi>, <ansi fg="command">`)))
+		helpTxt.WriteString("\n")
+	}
+
+	return helpTxt.String(), true
+}
@@ -103,6 +103,7 @@ func Peep(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 					`Equipment`:          &u.Character.Equipment,
 					`ItemNames`:          itemNames,
 					`ItemNamesFormatted`: itemNamesFormatted,
+					`ItemKeys`:           make([]string, len(itemNames)), // Not clickable, they aren't ours
 					`AttackDamage`:       diceRoll,
 					`RaceInfo`:           raceInfo,
 					`Count`:              fmt.Sprintf(`(%d/%d)`, len(u.Character.Items), u.Character.CarryCapacity()),
@@ -168,6 +169,7 @@ func Peep(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 					`Equipment`:          &m.Character.Equipment,
 					`ItemNames`:          itemNames,
 					`ItemNamesFormatted`: itemNamesFormatted,
+					`ItemKeys`:           make([]string, len(itemNames)), // Not clickable, they aren't ours
 					`AttackDamage`:       diceRoll,
 					`RaceInfo`:           raceInfo,
 				}
@@ -72,12 +72,14 @@ func Search(rest string, user *users.UserRecord, room *rooms.Room, flags events.
 	if skillLevel > 2 {
 		// Find stashed items
 		stashedItems := []string{}
+		stashedNames := []string{}
 		for _, item := range room.Stash {
 			if !item.IsValid() {
 				room.RemoveItem(item, true)
 			}
 			name := item.DisplayName() + ` <ansi fg="item-stashed">(stashed)</ansi>`
 			stashedItems = append(stashedItems, name)
+			stashedNames = append(stashedNames, item.Name())
 		}
 
 		hiddenPlayers := []string{}
@@ -159,6 +161,7 @@ func Search(rest string, user *users.UserRecord, room *rooms.Room, flags events.
 
 		groundDetails := map[string]any{
 			`GroundStuff`: stashedItems,
+			`GroundNames`: stashedNames,
 			`IsDark`:      room.GetBiome().IsDark(),
 			`IsNight`:     gametime.IsNight(),
 		}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/audit"
 	"github.com/GoMudEngine/GoMud/internal/buffs"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/keywords"
@@ -45,9 +46,11 @@ var (
 	userCommands map[string]CommandAccess = map[string]CommandAccess{
 		`aid`:         {Aid, false, false},
 		`alias`:       {Alias, true, false},
+		`apitoken`:    {ApiToken, true, true}, // Admin only
 		`appraise`:    {Appraise, false, false},
 		`ask`:         {Ask, false, false},
 		`attack`:      {Attack, false, false},
+		`audit`:       {Audit, true, true}, // Admin only
 		`backstab`:    {Backstab, false, false},
 		`badcommands`: {BadCommands, true, true}, // Admin only
 		`biome`:       {Biome, true, false},
@@ -57,6 +60,7 @@ var (
 		`bank`:        {Bank, false, false},
 		`break`:       {Break, false, false},
 		`build`:       {Build, false, true}, // Admin only
+		`ban`:         {Ban, true, true},    // Admin only
 		`buff`:        {Buff, false, true},  // Admin only
 		`bump`:        {Bump, false, false},
 		`buy`:         {Buy, false, false},
@@ -132,6 +136,7 @@ var (
 		`save`:        {Save, true, false},
 		`say`:         {Say, true, false},
 		`scribe`:      {Scribe, false, false},
+		`script`:      {Script, true, true}, // Admin only
 		`search`:      {Search, false, false},
 		`sell`:        {Sell, false, false},
 		`server`:      {Server, false, true}, // Admin only
@@ -165,8 +170,9 @@ var (
 		`dual-wield`:  {DualWield, true, false},
 		`whisper`:     {Whisper, true, false},
 		`who`:         {Who, true, false},
-		`zap`:         {Zap, true, true},   // Admin only
-		`zone`:        {Zone, false, true}, // Admin only
+		`worldstate`:  {WorldState, true, true}, // Admin only
+		`zap`:         {Zap, true, true},        // Admin only
+		`zone`:        {Zone, false, true},      // Admin only
 		// Special command only used upon creating a new account
 		`start`:     {Start, false, false},
 		`zombieact`: {ZombieAct, false, false},
@@ -381,9 +387,20 @@ func TryCommand(cmd string, rest string, userId int, flags events.EventFlag) (bo
 
 			// Run the command here
 			handled, err := cmdInfo.Func(rest, user, room, flags)
+
+			if cmdInfo.AdminOnly {
+				recordAudit(user, room.RoomId, cmd, rest, audit.OutcomeOf(handled, err), err)
+			}
+
 			return handled, err
 
 		}
+
+		// Staff with a limited role trying something outside of it.
+		// Regular players typing an admin command word aren't worth recording.
+		if cmdInfo.AdminOnly && user.Role != users.RoleUser {
+			recordAudit(user, room.RoomId, cmd, rest, audit.OutcomeDenied, nil)
+		}
 	}
 
 	if _, ok := emoteAliases[cmd]; ok {
@@ -413,13 +430,34 @@ func TryCommand(cmd string, rest string, userId int, flags events.EventFlag) (bo
 	return false, nil
 }
 
+func recordAudit(user *users.UserRecord, roomId int, cmd string, rest string, outcome string, err error) {
+
+	entry := audit.Entry{
+		UserId:   user.UserId,
+		Username: user.Username,
+		Role:     user.Role,
+		RoomId:   roomId,
+		Command:  cmd,
+		Args:     rest,
+		Outcome:  outcome,
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	audit.Record(entry)
+}
+
 // Register mob commands from outside of the package
-func RegisterCommand(command string, handlerFunc UserCommand, disabledWhenDowned bool, isAdminOnly bool) {
+// Replaces any script command of the same name.
+func RegisterCommand(command string, handlerFunc UserCommand, allowedWhenDowned bool, isAdminOnly bool) {
 	userCommands[command] = CommandAccess{
 		handlerFunc,
-		disabledWhenDowned,
+		allowedWhenDowned,
 		isAdminOnly,
 	}
+	delete(scriptCommands, command)
 }
 
 // TryRoomScripts is called to try both the onCommand_X direct route and also onCommand with a 'cmd' parameter.
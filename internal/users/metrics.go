@@ -0,0 +1,25 @@
+package users
+
+import "github.com/GoMudEngine/GoMud/internal/metrics"
+
+func collectMetrics() []metrics.Metric {
+
+	zombieCt := 0
+	for _, u := range userManager.Users {
+		if u.isZombie {
+			zombieCt++
+		}
+	}
+
+	online := metrics.NewGauge(`gomud_users_online`, `Users in the game, not counting zombies.`)
+	online.Add(float64(len(userManager.Users) - zombieCt))
+
+	zombies := metrics.NewGauge(`gomud_users_zombie`, `Users who lost their connection and can still reconnect.`)
+	zombies.Add(float64(zombieCt))
+
+	return []metrics.Metric{online, zombies}
+}
+
+func init() {
+	metrics.AddCollector(collectMetrics)
+}
@@ -1,13 +1,13 @@
 package users
 
 type OnlineInfo struct {
-	Username      string
-	CharacterName string
-	Level         int
-	Alignment     string
-	Profession    string
-	OnlineTime    int64
-	OnlineTimeStr string
-	IsAFK         bool
-	Role          string
+	Username      string `json:"username"`
+	CharacterName string `json:"charactername"`
+	Level         int    `json:"level"`
+	Alignment     string `json:"alignment"`
+	Profession    string `json:"profession"`
+	OnlineTime    int64  `json:"onlinetime"`
+	OnlineTimeStr string `json:"onlinetimestr"`
+	IsAFK         bool   `json:"isafk"`
+	Role          string `json:"role"`
 }
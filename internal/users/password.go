@@ -0,0 +1,266 @@
+package users
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// Passwords are stored as salted PBKDF2-SHA256:
+//
+//	$pbkdf2-sha256$<iterations>$<base64 salt>$<base64 key>
+//
+// Anything without the prefix is a legacy value: an unsalted SHA-256 hex digest,
+// or a plaintext password typed into the user file by hand. Legacy values are
+// upgraded the next time the password is entered correctly.
+//
+
+const (
+	passwordHashPrefix     = `$pbkdf2-sha256$`
+	passwordHashIterations = 600000 // OWASP recommendation for PBKDF2-HMAC-SHA256
+	passwordSaltBytes      = 16
+	passwordKeyBytes       = 32
+
+	// Easy to read out loud, no 0/O or 1/l/I
+	tempPasswordChars = `abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789`
+	tempPasswordSize  = 12
+)
+
+var (
+	ErrMalformedPasswordHash = errors.New("malformed password hash")
+)
+
+func hashPassword(pw string) (string, error) {
+	return hashPasswordWith(pw, passwordHashIterations)
+}
+
+func hashPasswordWith(pw string, iterations int) (string, error) {
+
+	salt := make([]byte, passwordSaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return ``, err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, pw, salt, iterations, passwordKeyBytes)
+	if err != nil {
+		return ``, err
+	}
+
+	return fmt.Sprintf(`%s%d$%s$%s`,
+		passwordHashPrefix,
+		iterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Compares a password against a stored PBKDF2 hash.
+// outdated is true when the hash uses fewer iterations than we currently do.
+func checkPasswordHash(stored string, pw string) (match bool, outdated bool, err error) {
+
+	parts := strings.Split(strings.TrimPrefix(stored, passwordHashPrefix), `$`)
+	if len(parts) != 3 {
+		return false, false, ErrMalformedPasswordHash
+	}
+
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations < 1 {
+		return false, false, ErrMalformedPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false, false, ErrMalformedPasswordHash
+	}
+
+	expected, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil || len(expected) == 0 {
+		return false, false, ErrMalformedPasswordHash
+	}
+
+	key, err := pbkdf2.Key(sha256.New, pw, salt, iterations, len(expected))
+	if err != nil {
+		return false, false, err
+	}
+
+	if subtle.ConstantTimeCompare(key, expected) != 1 {
+		return false, false, nil
+	}
+
+	return true, iterations < passwordHashIterations, nil
+}
+
+// Whether a stored password value predates salted hashing
+func IsLegacyPassword(stored string) bool {
+	return !strings.HasPrefix(stored, passwordHashPrefix)
+}
+
+// Checks a legacy stored value. Returns the plaintext password to rehash with if it matched.
+func checkLegacyPassword(stored string, pw string) (plaintext string, match bool) {
+
+	// Unsalted SHA-256. Only the real password may match it, otherwise anyone
+	// holding the user file could log in by sending the digest itself.
+	if isLegacyDigest(stored) {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(util.Hash(pw))) == 1 {
+			return pw, true
+		}
+		return ``, false
+	}
+
+	// Plaintext set by hand in the user file
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(pw)) == 1 {
+		return pw, true
+	}
+
+	// Plaintext in the file, but the client sent its SHA-256
+	if subtle.ConstantTimeCompare([]byte(util.Hash(stored)), []byte(pw)) == 1 {
+		return stored, true
+	}
+
+	return ``, false
+}
+
+// Whether a legacy stored value is an unsalted SHA-256 hex digest
+func isLegacyDigest(stored string) bool {
+	if len(stored) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(stored)
+	return err == nil
+}
+
+// Stores a hash from CheckPassword() in place of an outdated password and writes it out.
+// If the user is online under a different record (such as a zombie being
+// reclaimed at login), that record is updated too so the upgrade isn't lost.
+// Needs the MUD lock, but is quick since the hash is already computed.
+func (u *UserRecord) UpgradePassword(newHash string) {
+
+	u.Password = newHash
+
+	if live, ok := userManager.Users[u.UserId]; ok && live != u {
+		live.Password = newHash
+		return
+	}
+
+	if err := SaveUser(*u); err != nil {
+		mudlog.Error("Password Upgrade", "username", u.Username, "error", err)
+		return
+	}
+
+	mudlog.Info("Password Upgrade", "username", u.Username)
+}
+
+// Validates and hashes a new password. Slow on purpose, so call it without the
+// MUD lock held and only assign the result to Password under it.
+func NewPasswordHash(pw string) (string, error) {
+
+	if err := ValidatePassword(pw); err != nil {
+		return ``, err
+	}
+
+	return hashPassword(pw)
+}
+
+var timingDummy = sync.OnceValue(func() *UserRecord {
+	hashed, _ := hashPassword(`no user has this password`)
+	return &UserRecord{Username: `(no such user)`, Password: hashed}
+})
+
+// Takes as long as checking a real password but never matches. Used when the
+// username doesn't exist, so the response time doesn't give that away.
+func CheckNoPassword(input string) {
+	timingDummy().CheckPassword(input)
+}
+
+func generateTempPassword() (string, error) {
+
+	size := tempPasswordSize
+	validation := configs.GetValidationConfig()
+	if size < int(validation.PasswordSizeMin) {
+		size = int(validation.PasswordSizeMin)
+	}
+	if validation.PasswordSizeMax > 0 && size > int(validation.PasswordSizeMax) {
+		size = int(validation.PasswordSizeMax)
+	}
+
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return ``, err
+	}
+
+	for i := range b {
+		b[i] = tempPasswordChars[int(b[i])%len(tempPasswordChars)]
+	}
+
+	return string(b), nil
+}
+
+// Replaces a user's password with a random temporary one, which is returned so
+// it can be passed on to the owner. Works whether or not the user is online.
+func ResetPassword(username string) (string, error) {
+
+	tempPw, err := generateTempPassword()
+	if err != nil {
+		return ``, err
+	}
+
+	u := GetByUserId(FindUserId(username))
+	if u == nil {
+		if u, err = LoadUser(username, true); err != nil {
+			return ``, err
+		}
+	}
+
+	if err := u.SetPassword(tempPw); err != nil {
+		return ``, err
+	}
+
+	u.EventLog.Add(`conn`, `Password reset by an admin`)
+
+	if err := SaveUser(*u); err != nil {
+		return ``, err
+	}
+
+	mudlog.Warn("Password Reset", "username", u.Username)
+
+	return tempPw, nil
+}
+
+// Counts how many accounts still have legacy (unsalted or plaintext) passwords.
+// Slow, since it reads every user file.
+func LegacyPasswordReport() (currentCt int, legacyUsernames []string) {
+
+	legacyUsernames = []string{}
+
+	for _, u := range userManager.Users {
+		if IsLegacyPassword(u.Password) {
+			legacyUsernames = append(legacyUsernames, u.Username)
+		} else {
+			currentCt++
+		}
+	}
+
+	SearchOfflineUsers(func(u *UserRecord) bool {
+		if IsLegacyPassword(u.Password) {
+			legacyUsernames = append(legacyUsernames, u.Username)
+		} else {
+			currentCt++
+		}
+		return true
+	})
+
+	return currentCt, legacyUsernames
+}
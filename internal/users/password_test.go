@@ -0,0 +1,129 @@
+package users
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func TestHashPassword_RoundTrip(t *testing.T) {
+
+	hashed, err := hashPassword(`hunter22`)
+	if err != nil {
+		t.Fatalf("hashPassword() error: %v", err)
+	}
+
+	if !strings.HasPrefix(hashed, passwordHashPrefix) || IsLegacyPassword(hashed) {
+		t.Fatalf("hashPassword() = %q; missing prefix", hashed)
+	}
+
+	if strings.Contains(hashed, `hunter22`) || strings.Contains(hashed, util.Hash(`hunter22`)) {
+		t.Fatalf("hashPassword() = %q; leaks the password", hashed)
+	}
+
+	if again, _ := hashPassword(`hunter22`); again == hashed {
+		t.Errorf("two hashes of the same password are identical; salt not applied")
+	}
+
+	if ok, outdated, err := checkPasswordHash(hashed, `hunter22`); !ok || outdated || err != nil {
+		t.Errorf("checkPasswordHash(correct) = %v, %v, %v; want true, false, nil", ok, outdated, err)
+	}
+
+	if ok, _, err := checkPasswordHash(hashed, `hunter23`); ok || err != nil {
+		t.Errorf("checkPasswordHash(wrong) = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestCheckPasswordHash_Outdated(t *testing.T) {
+
+	hashed, err := hashPasswordWith(`hunter22`, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, outdated, _ := checkPasswordHash(hashed, `hunter22`); !ok || !outdated {
+		t.Errorf("checkPasswordHash() = %v, %v; want true, true", ok, outdated)
+	}
+}
+
+func TestCheckPasswordHash_Malformed(t *testing.T) {
+
+	tests := []string{
+		passwordHashPrefix,
+		passwordHashPrefix + `abc$c2FsdA$a2V5`,
+		passwordHashPrefix + `1000$!!!$a2V5`,
+		passwordHashPrefix + `1000$c2FsdA$`,
+		passwordHashPrefix + `1000$c2FsdA`,
+	}
+
+	for _, stored := range tests {
+		if ok, _, err := checkPasswordHash(stored, `x`); ok || err == nil {
+			t.Errorf("checkPasswordHash(%q) = %v, %v; want false, error", stored, ok, err)
+		}
+	}
+}
+
+func TestCheckLegacyPassword(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		stored        string
+		input         string
+		wantMatch     bool
+		wantPlaintext string
+	}{
+		{`sha256`, util.Hash(`hunter22`), `hunter22`, true, `hunter22`},
+		{`sha256 wrong`, util.Hash(`hunter22`), `hunter23`, false, ``},
+		{`sha256, client sent the stored digest`, util.Hash(`hunter22`), util.Hash(`hunter22`), false, ``},
+		{`plaintext`, `hunter22`, `hunter22`, true, `hunter22`},
+		{`plaintext, client sent sha256`, `hunter22`, util.Hash(`hunter22`), true, `hunter22`},
+		{`plaintext wrong`, `hunter22`, `Hunter22`, false, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, ok := checkLegacyPassword(tt.stored, tt.input)
+			if ok != tt.wantMatch || plaintext != tt.wantPlaintext {
+				t.Errorf("checkLegacyPassword() = %q, %v; want %q, %v", plaintext, ok, tt.wantPlaintext, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestPasswordMatches_StoredDigestRejected(t *testing.T) {
+
+	stored := util.Hash(`hunter22`)
+	u := &UserRecord{UserId: 1, Username: `leaked`, Password: stored}
+
+	if u.PasswordMatches(stored) {
+		t.Errorf("PasswordMatches(stored digest) = true; want false")
+	}
+
+	if u.Password != stored {
+		t.Errorf("Password = %q after failed login; want %q", u.Password, stored)
+	}
+}
+
+func TestCheckPassword_NoSideEffects(t *testing.T) {
+
+	stored := util.Hash(`hunter22`)
+	u := &UserRecord{UserId: 1, Username: `legacy`, Password: stored}
+
+	ok, newHash := u.CheckPassword(`hunter22`)
+	if !ok || IsLegacyPassword(newHash) {
+		t.Errorf("CheckPassword(correct) = %v, %q; want true and a new hash", ok, newHash)
+	}
+
+	if match, outdated, err := checkPasswordHash(newHash, `hunter22`); !match || outdated || err != nil {
+		t.Errorf("checkPasswordHash(newHash) = %v, %v, %v; want true, false, nil", match, outdated, err)
+	}
+
+	if u.Password != stored {
+		t.Errorf("Password = %q after CheckPassword; want it unchanged", u.Password)
+	}
+
+	if ok, newHash := u.CheckPassword(`hunter23`); ok || newHash != `` {
+		t.Errorf("CheckPassword(wrong) = %v, %q; want false, %q", ok, newHash, ``)
+	}
+}
@@ -88,22 +88,52 @@ func (u *UserRecord) ClientSettings() connections.ClientSettings {
 	return connections.GetClientSettings(u.connectionId)
 }
 
+// Checks a password attempt. Legacy (unsalted or plaintext) passwords are
+// transparently upgraded to a salted hash when they match.
+// Needs the MUD lock, so hashes in the game loop. Prefer CheckPassword() elsewhere.
 func (u *UserRecord) PasswordMatches(input string) bool {
 
-	if input == u.Password {
-		return true
+	ok, newHash := u.CheckPassword(input)
+	if ok && newHash != `` {
+		u.UpgradePassword(newHash)
 	}
 
-	if u.Password == util.Hash(input) {
-		return true
+	return ok
+}
+
+// Checks a password attempt without changing anything, so it can be called on a copy of
+// the record without holding the MUD lock. If it matched but the stored value is outdated,
+// newHash is a current hash of it to pass to UpgradePassword().
+func (u *UserRecord) CheckPassword(input string) (ok bool, newHash string) {
+
+	upgrade := ``
+
+	if IsLegacyPassword(u.Password) {
+		if upgrade, ok = checkLegacyPassword(u.Password, input); !ok {
+			return false, ``
+		}
+	} else {
+		match, outdated, err := checkPasswordHash(u.Password, input)
+		if err != nil {
+			mudlog.Error("CheckPassword", "username", u.Username, "error", err)
+			return false, ``
+		}
+		if !match {
+			return false, ``
+		}
+		if !outdated {
+			return true, ``
+		}
+		upgrade = input
 	}
 
-	// In case we reset the password to a plaintext string
-	if input == util.Hash(u.Password) {
-		return true
+	newHash, err := hashPassword(upgrade)
+	if err != nil {
+		mudlog.Error("CheckPassword", "username", u.Username, "error", err)
+		return true, ``
 	}
 
-	return false
+	return true, newHash
 }
 
 func (u *UserRecord) AddCommandAlias(input string, output string) (addedAlias string, deletedAlias string) {
@@ -415,6 +445,10 @@ func (u *UserRecord) HasRolePermission(permissionId string, simpleMatch ...bool)
 		return false
 	}
 
+	if len(simpleMatch) == 0 || !simpleMatch[0] {
+		return RoleHasPermission(u.Role, permissionId)
+	}
+
 	roles := configs.GetRolesConfig()
 	commandList, ok := roles[u.Role]
 	if !ok {
@@ -461,6 +495,32 @@ func (u *UserRecord) HasRolePermission(permissionId string, simpleMatch ...bool)
 	return false
 }
 
+// Checks a role (rather than a user) against the Roles config.
+// "room" in a role's list permits "room.info", but not the other way around.
+func RoleHasPermission(role string, permissionId string) bool {
+
+	if role == RoleAdmin {
+		return true
+	}
+
+	if role == RoleUser {
+		return false
+	}
+
+	commandList, ok := configs.GetRolesConfig()[role]
+	if !ok {
+		return false
+	}
+
+	for _, cmdAccessId := range commandList {
+		if strings.HasPrefix(permissionId, cmdAccessId) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (u *UserRecord) SetConfigOption(key string, value any) {
 	if u.ConfigOptions == nil {
 		u.ConfigOptions = make(map[string]any)
@@ -553,13 +613,12 @@ func (u *UserRecord) SetCharacterName(cn string) error {
 
 func (u *UserRecord) SetPassword(pw string) error {
 
-	validation := configs.GetValidationConfig()
-
-	if len(pw) < int(validation.PasswordSizeMin) || len(pw) > int(validation.PasswordSizeMax) {
-		return fmt.Errorf("password must be between %d and %d characters long", validation.PasswordSizeMin, validation.PasswordSizeMax)
+	hashedPw, err := NewPasswordHash(pw)
+	if err != nil {
+		return err
 	}
 
-	u.Password = util.Hash(pw)
+	u.Password = hashedPw
 	return nil
 }
 
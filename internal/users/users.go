@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/bans"
 	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
@@ -158,6 +159,18 @@ func LoginUser(user *UserRecord, connectionId connections.ConnectionId) (*UserRe
 
 	mudlog.Info("LoginUser()", "username", user.Username, "connectionId", connectionId)
 
+	if ban, banned := bans.CheckAccount(user.Username); banned {
+		mudlog.Warn("LoginUser()", "username", user.Username, "connectionId", connectionId, "banId", ban.BanId)
+		return nil, ban.Message(), errors.New("account is banned")
+	}
+
+	if cd := connections.Get(connectionId); cd != nil {
+		if ban, banned := bans.CheckIP(cd.RemoteAddr()); banned {
+			mudlog.Warn("LoginUser()", "username", user.Username, "connectionId", connectionId, "banId", ban.BanId)
+			return nil, ban.Message(), errors.New("address is banned")
+		}
+	}
+
 	user.Character.SetAdjective(`zombie`, false)
 
 	// If they're already logged in
@@ -351,6 +364,18 @@ func LoadUser(username string, skipValidation ...bool) (*UserRecord, error) {
 // Loads all user recvords and runs against a function.
 // Stops searching if false is returned.
 func SearchOfflineUsers(searchFunc func(u *UserRecord) bool) {
+	SearchUserFiles(func(u *UserRecord) bool {
+		// If this is an online user, skip it
+		if _, ok := userManager.Usernames[u.Username]; ok {
+			return true
+		}
+		return searchFunc(u)
+	})
+}
+
+// The same as SearchOfflineUsers(), but includes the files of online users.
+// Only reads files, so it doesn't need the MUD lock.
+func SearchUserFiles(searchFunc func(u *UserRecord) bool) {
 
 	basePath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`)
 
@@ -383,11 +408,6 @@ func SearchOfflineUsers(searchFunc func(u *UserRecord) bool) {
 				return err
 			}
 
-			// If this is an online user, skip it
-			if _, ok := userManager.Usernames[uRecord.Username]; ok {
-				return nil
-			}
-
 			if res := searchFunc(&uRecord); !res {
 				return errors.New(`done searching`)
 			}
@@ -31,6 +31,12 @@ var (
 	roundCount   uint64 = RoundCountMinimum
 	timeTrackers        = map[string]*Accumulator{}
 	serverAddr   string = `Unknown`
+	serverStart         = time.Now()
+
+	// Everything random in the game comes from here, see SeedRand()
+	rngSeed = time.Now().UnixNano()
+	rng     = rand.New(rand.NewSource(rngSeed))
+	rngLock sync.Mutex
 
 	strippablePrepositions = []string{
 		`onto`,
@@ -101,6 +107,18 @@ func GetServerAddress() string {
 	return serverAddr
 }
 
+func SetServerStartTime(t time.Time) {
+	serverStart = t
+}
+
+func GetServerStartTime() time.Time {
+	return serverStart
+}
+
+func SetTurnCount(newTurnCount uint64) {
+	turnCount = newTurnCount
+}
+
 func SetRoundCount(newRoundCount uint64) {
 	roundCount = newRoundCount
 }
@@ -132,6 +150,13 @@ func TrackTime(name string, timePassed float64) {
 	timeTrackers[name].Record(timePassed)
 }
 
+func GetTimeTracker(name string) (Accumulator, bool) {
+	if t, ok := timeTrackers[name]; ok {
+		return *t, true
+	}
+	return Accumulator{}, false
+}
+
 func GetTimeTrackers() []Accumulator {
 
 	result := []Accumulator{}
@@ -170,11 +195,41 @@ func (t *Accumulator) Record(nextValue float64) {
 	}
 }
 
+// Starts the random number source over from a seed.
+// The same seed and the same calls give the same results, which is what lets a journal be replayed.
+func SeedRand(seed int64) {
+	rngLock.Lock()
+	defer rngLock.Unlock()
+
+	rngSeed = seed
+	rng = rand.New(rand.NewSource(seed))
+}
+
+// The seed of the random number source
+func GetRandSeed() int64 {
+	rngLock.Lock()
+	defer rngLock.Unlock()
+
+	return rngSeed
+}
+
 func Rand(maxInt int) int {
 	if maxInt < 1 {
 		return 0
 	}
-	return rand.Intn(maxInt)
+
+	rngLock.Lock()
+	defer rngLock.Unlock()
+
+	return rng.Intn(maxInt)
+}
+
+// A random number in [0.0,1.0)
+func RandFloat() float64 {
+	rngLock.Lock()
+	defer rngLock.Unlock()
+
+	return rng.Float64()
 }
 
 func LogRoll(name string, rollResult int, targetNumber int) {
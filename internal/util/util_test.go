@@ -206,6 +206,32 @@ func TestRand(t *testing.T) {
 	}
 }
 
+func TestSeedRand(t *testing.T) {
+	roll := func() []int {
+		result := []int{}
+		for i := 0; i < 20; i++ {
+			result = append(result, Rand(1000))
+		}
+		return append(result, int(RandFloat()*1000))
+	}
+
+	SeedRand(42)
+	first := roll()
+
+	SeedRand(42)
+	second := roll()
+
+	if GetRandSeed() != 42 {
+		t.Fatalf("Expected GetRandSeed() = 42, got %d", GetRandSeed())
+	}
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same rolls from the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
 func TestSplitString(t *testing.T) {
 	type args struct {
 		input string
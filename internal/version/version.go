@@ -7,11 +7,17 @@ import (
 )
 
 const (
+	Codebase = `GoMud` // Name of the codebase, as reported to MUD listing crawlers etc.
+
 	Older = -1
 	Newer = 1
 	Equal = 0
 )
 
+var (
+	serverVersion = Version{}
+)
+
 type Version struct {
 	Major int
 	Minor int
@@ -96,3 +102,13 @@ func Parse(v string) (Version, error) {
 
 	return Version{Major: major, Minor: minor, Patch: patch}, nil
 }
+
+// Sets the version of the running binary
+func SetServerVersion(v Version) {
+	serverVersion = v
+}
+
+// Gets the version of the running binary
+func ServerVersion() Version {
+	return serverVersion
+}
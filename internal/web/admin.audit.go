@@ -0,0 +1,75 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/audit"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func auditIndex(w http.ResponseWriter, r *http.Request) {
+
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/audit/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+	}
+
+	urlVals := r.URL.Query()
+
+	filter := audit.Filter{
+		Username: urlVals.Get(`user`),
+		Command:  urlVals.Get(`cmd`),
+		Limit:    100,
+	}
+
+	errMsgs := []string{}
+	now := time.Now()
+
+	if since := urlVals.Get(`since`); since != `` {
+		if filter.Since, err = audit.ParseTime(since, now); err != nil {
+			errMsgs = append(errMsgs, err.Error())
+		}
+	}
+
+	if until := urlVals.Get(`until`); until != `` {
+		if filter.Until, err = audit.ParseTime(until, now); err != nil {
+			errMsgs = append(errMsgs, err.Error())
+		}
+	}
+
+	if limit, _ := strconv.Atoi(urlVals.Get(`limit`)); limit > 0 {
+		filter.Limit = limit
+	}
+
+	entries, err := audit.Search(filter)
+	if err != nil {
+		errMsgs = append(errMsgs, err.Error())
+	}
+
+	auditIndexData := struct {
+		Entries []audit.Entry
+		Errors  []string
+		User    string
+		Cmd     string
+		Since   string
+		Until   string
+		Limit   int
+	}{
+		entries,
+		errMsgs,
+		filter.Username,
+		filter.Command,
+		urlVals.Get(`since`),
+		urlVals.Get(`until`),
+		filter.Limit,
+	}
+
+	if err := tmpl.Execute(w, auditIndexData); err != nil {
+		mudlog.Error("HTML Execute", "error", err)
+	}
+
+}
@@ -0,0 +1,33 @@
+package web
+
+import (
+	"net/http"
+	"text/template"
+
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func bansIndex(w http.ResponseWriter, r *http.Request) {
+
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/bans/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+	}
+
+	showAll := r.URL.Query().Get(`all`) != ``
+
+	banIndexData := struct {
+		Bans    []bans.Ban
+		ShowAll bool
+	}{
+		bans.GetAll(showAll),
+		showAll,
+	}
+
+	if err := tmpl.Execute(w, banIndexData); err != nil {
+		mudlog.Error("HTML Execute", "error", err)
+	}
+
+}
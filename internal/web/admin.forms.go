@@ -0,0 +1,258 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/GoMudEngine/GoMud/internal/audit"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+//
+// Helpers for the admin edit forms.
+// Only fields that are posted get changed, so a form can leave out anything it doesn't edit.
+// Checkbox groups post an empty hidden value first so that "none checked" still shows up.
+//
+
+type adminForm struct {
+	values url.Values
+	errs   []string
+}
+
+// The message shown above a data form after a save or delete
+type saveResult struct {
+	Message string
+	Error   string
+}
+
+func newAdminForm(r *http.Request) (*adminForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return &adminForm{values: r.PostForm}, nil
+}
+
+func (f *adminForm) has(name string) bool {
+	_, ok := f.values[name]
+	return ok
+}
+
+// Last value wins, which lets a hidden "false" sit in front of a checkbox.
+func (f *adminForm) get(name string) string {
+	vals := f.values[name]
+	if len(vals) == 0 {
+		return ``
+	}
+	return vals[len(vals)-1]
+}
+
+func (f *adminForm) String(name string, dst *string) {
+	if f.has(name) {
+		*dst = strings.TrimSpace(f.get(name))
+	}
+}
+
+// Textareas keep their line breaks
+func (f *adminForm) Text(name string, dst *string) {
+	if f.has(name) {
+		*dst = strings.TrimSpace(strings.ReplaceAll(f.get(name), "\r\n", "\n"))
+	}
+}
+
+func (f *adminForm) Bool(name string, dst *bool) {
+	if f.has(name) {
+		*dst = f.get(name) == `true`
+	}
+}
+
+// One entry per non-empty line of a textarea
+func (f *adminForm) Lines(name string, dst *[]string) {
+	if !f.has(name) {
+		return
+	}
+	lines := []string{}
+	for _, line := range strings.Split(f.get(name), "\n") {
+		if line = strings.TrimSpace(line); line != `` {
+			lines = append(lines, line)
+		}
+	}
+	*dst = lines
+}
+
+// One entry per non-empty value of a repeated field, e.g. idlemessages[]
+func (f *adminForm) StringList(name string, dst *[]string) {
+	if !f.has(name) {
+		return
+	}
+	list := []string{}
+	for _, v := range f.values[name] {
+		if v = strings.TrimSpace(v); v != `` {
+			list = append(list, v)
+		}
+	}
+	*dst = list
+}
+
+func (f *adminForm) IntList(name string, dst *[]int) {
+	if !f.has(name) {
+		return
+	}
+	list := []int{}
+	for _, v := range f.values[name] {
+		if v = strings.TrimSpace(v); v == `` {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			f.errs = append(f.errs, fmt.Sprintf(`%s: "%s" is not a number`, name, v))
+			continue
+		}
+		list = append(list, n)
+	}
+	*dst = list
+}
+
+func (f *adminForm) Err() error {
+	if len(f.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf(`%s`, strings.Join(f.errs, `; `))
+}
+
+// An empty field is treated as zero
+func formInt[T ~int | ~int8 | ~uint8](f *adminForm, name string, dst *T) {
+	if !f.has(name) {
+		return
+	}
+	v := strings.TrimSpace(f.get(name))
+	if v == `` {
+		*dst = 0
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || int(T(n)) != n {
+		f.errs = append(f.errs, fmt.Sprintf(`%s: "%s" is not a valid number`, name, v))
+		return
+	}
+	*dst = T(n)
+}
+
+func formFloat(f *adminForm, name string, dst *float32) {
+	if !f.has(name) {
+		return
+	}
+	v := strings.TrimSpace(f.get(name))
+	if v == `` {
+		*dst = 0
+		return
+	}
+	n, err := strconv.ParseFloat(v, 32)
+	if err != nil {
+		f.errs = append(f.errs, fmt.Sprintf(`%s: "%s" is not a valid number`, name, v))
+		return
+	}
+	*dst = float32(n)
+}
+
+// Fields named like statmod[strength]. A blank or zero value removes the key.
+// Returns a copy so the spec in memory isn't touched until it's saved.
+func formIntMap[M ~map[string]int](f *adminForm, prefix string, dst *M) {
+
+	newMap := M{}
+	for k, v := range *dst {
+		newMap[k] = v
+	}
+
+	for name := range f.values {
+		if !strings.HasPrefix(name, prefix+`[`) || !strings.HasSuffix(name, `]`) {
+			continue
+		}
+		key := name[len(prefix)+1 : len(name)-1]
+		n := 0
+		formInt(f, name, &n)
+		if n == 0 {
+			delete(newMap, key)
+		} else {
+			newMap[key] = n
+		}
+	}
+
+	*dst = newMap
+}
+
+// Renders just the result message, used after a delete
+func renderSaveResult(w http.ResponseWriter, result saveResult) {
+
+	tmpl, err := template.New("_result.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/_result.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+		return
+	}
+
+	if err := tmpl.ExecuteTemplate(w, "result", result); err != nil {
+		mudlog.Error("HTML Execute", "error", err)
+	}
+}
+
+// Who made the change, for the logs
+func adminUsername(r *http.Request) string {
+	if s, ok := getAdminSession(r); ok {
+		return s.Username
+	}
+	return `unknown`
+}
+
+// Web edits go to the audit log the same as in-game admin commands.
+// e.g. command "webadmin", args "update item 10001"
+func recordAdminChange(r *http.Request, action string, kind string, id any, err error) {
+
+	username := adminUsername(r)
+
+	entry := audit.Entry{
+		Username: username,
+		Command:  `webadmin`,
+		Args:     fmt.Sprintf(`%s %s %v`, action, kind, id),
+		Outcome:  audit.OutcomeOf(true, err),
+	}
+
+	if s, ok := getAdminSession(r); ok {
+		entry.UserId = s.UserId
+		entry.Role = s.Role
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+		mudlog.Warn("Web Admin", "user", username, "action", action, kind, id, "error", err)
+	} else {
+		mudlog.Info("Web Admin", "user", username, "action", action, kind, id)
+	}
+
+	audit.Record(entry)
+}
+
+// Used to refuse deleting things that are still in use
+func anyUser(match func(u *users.UserRecord) bool) bool {
+
+	for _, u := range users.GetAllActiveUsers() {
+		if match(u) {
+			return true
+		}
+	}
+
+	found := false
+	users.SearchOfflineUsers(func(u *users.UserRecord) bool {
+		if match(u) {
+			found = true
+			return false
+		}
+		return true
+	})
+
+	return found
+}
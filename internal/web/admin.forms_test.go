@@ -0,0 +1,116 @@
+package web
+
+import (
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func postForm(t *testing.T, body string) *adminForm {
+	t.Helper()
+
+	r := httptest.NewRequest(`POST`, `/admin/items/itemdata/`, strings.NewReader(body))
+	r.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form
+}
+
+func TestAdminForm_OnlyPostedFieldsChange(t *testing.T) {
+	form := postForm(t, `name=+new+name+&description=line1%0D%0Aline2`)
+
+	name, desc, untouched := `old`, `old`, `keep me`
+	form.String(`name`, &name)
+	form.Text(`description`, &desc)
+	form.String(`displayname`, &untouched)
+
+	if name != `new name` {
+		t.Errorf("name = %q", name)
+	}
+	if desc != "line1\nline2" {
+		t.Errorf("description = %q", desc)
+	}
+	if untouched != `keep me` {
+		t.Errorf("unposted field changed to %q", untouched)
+	}
+}
+
+func TestAdminForm_Checkboxes(t *testing.T) {
+	tests := []struct {
+		body string
+		want bool
+	}{
+		{`cursed=false`, false},
+		{`cursed=false&cursed=true`, true},
+		{``, true}, // not posted, keeps current value
+	}
+
+	for _, tt := range tests {
+		got := true
+		postForm(t, tt.body).Bool(`cursed`, &got)
+		if got != tt.want {
+			t.Errorf("Bool(%q) = %v; want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestAdminForm_Lists(t *testing.T) {
+	form := postForm(t, `buffids[]=&buffids[]=3&buffids[]=7&wornbuffids[]=&idle=a%0D%0A%0D%0A+b+`)
+
+	buffIds := []int{1}
+	form.IntList(`buffids[]`, &buffIds)
+	if !slices.Equal(buffIds, []int{3, 7}) {
+		t.Errorf("buffids = %v", buffIds)
+	}
+
+	// Only the hidden empty value means "none checked"
+	wornBuffIds := []int{1}
+	form.IntList(`wornbuffids[]`, &wornBuffIds)
+	if len(wornBuffIds) != 0 {
+		t.Errorf("wornbuffids = %v", wornBuffIds)
+	}
+
+	lines := []string{}
+	form.Lines(`idle`, &lines)
+	if !slices.Equal(lines, []string{`a`, `b`}) {
+		t.Errorf("lines = %q", lines)
+	}
+}
+
+func TestAdminForm_Numbers(t *testing.T) {
+	form := postForm(t, `value=12&uses=&level=abc&alignment=300&scale=1.5&statmod[strength]=2&statmod[speed]=0&statmod[smarts]=`)
+
+	value, uses, level := 0, 5, 1
+	formInt(form, `value`, &value)
+	formInt(form, `uses`, &uses)
+	formInt(form, `level`, &level)
+
+	var alignment int8 = 10
+	formInt(form, `alignment`, &alignment)
+
+	var scale float32
+	formFloat(form, `scale`, &scale)
+
+	if value != 12 || uses != 0 || level != 1 || alignment != 10 || scale != 1.5 {
+		t.Errorf("value=%d uses=%d level=%d alignment=%d scale=%v", value, uses, level, alignment, scale)
+	}
+
+	if err := form.Err(); err == nil || !strings.Contains(err.Error(), `level`) || !strings.Contains(err.Error(), `alignment`) {
+		t.Errorf("Err() = %v; want level and alignment errors", err)
+	}
+
+	original := map[string]int{`speed`: 3, `smarts`: 4, `vitality`: 5}
+	statMods := original
+	formIntMap(form, `statmod`, &statMods)
+
+	if len(statMods) != 2 || statMods[`strength`] != 2 || statMods[`vitality`] != 5 {
+		t.Errorf("statmod = %v", statMods)
+	}
+	if original[`speed`] != 3 {
+		t.Errorf("formIntMap changed the original map: %v", original)
+	}
+}
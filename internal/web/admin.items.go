@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"html"
 	"net/http"
 	"sort"
@@ -8,14 +9,19 @@ import (
 	"text/template"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
 func itemsIndex(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("index.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/items/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/items/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
 	if err != nil {
 		mudlog.Error("HTML Template", "error", err)
 	}
@@ -73,11 +79,6 @@ func itemsIndex(w http.ResponseWriter, r *http.Request) {
 
 func itemData(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("item.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/items/item.data.html")
-	if err != nil {
-		mudlog.Error("HTML Template", "error", err)
-	}
-
 	urlVals := r.URL.Query()
 
 	itemInt, _ := strconv.Atoi(urlVals.Get(`itemid`))
@@ -87,8 +88,247 @@ func itemData(w http.ResponseWriter, r *http.Request) {
 		itemSpec = &items.ItemSpec{}
 	}
 
+	renderItemData(w, *itemSpec, saveResult{})
+}
+
+// Creates (itemid 0) or updates an item, then shows the form again
+func itemSave(w http.ResponseWriter, r *http.Request) {
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	itemId := 0
+	formInt(form, `itemid`, &itemId)
+
+	itemSpec := items.ItemSpec{}
+	if itemId > 0 {
+		existing := items.GetItemSpec(itemId)
+		if existing == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Item %d not found.`, itemId)})
+			return
+		}
+		itemSpec = *existing
+	}
+
+	form.String(`name`, &itemSpec.Name)
+	form.String(`displayname`, &itemSpec.DisplayName)
+	form.String(`namesimple`, &itemSpec.NameSimple)
+	form.Text(`description`, &itemSpec.Description)
+	if form.has(`type`) {
+		itemSpec.Type = items.ItemType(form.get(`type`))
+	}
+	if form.has(`subtype`) {
+		itemSpec.Subtype = items.ItemSubType(form.get(`subtype`))
+	}
+	formInt(form, `value`, &itemSpec.Value)
+	formInt(form, `uses`, &itemSpec.Uses)
+	form.String(`questtoken`, &itemSpec.QuestToken)
+	form.String(`keylockid`, &itemSpec.KeyLockId)
+	form.Bool(`cursed`, &itemSpec.Cursed)
+	form.String(`damage`, &itemSpec.Damage.DiceRoll)
+	formInt(form, `waitrounds`, &itemSpec.WaitRounds)
+	formInt(form, `hands`, &itemSpec.Hands)
+	formInt(form, `damagereduction`, &itemSpec.DamageReduction)
+	formInt(form, `breakchance`, &itemSpec.BreakChance)
+	formIntMap(form, `statmod`, &itemSpec.StatMods)
+	form.IntList(`critbuffids[]`, &itemSpec.Damage.CritBuffIds)
+	form.IntList(`buffids[]`, &itemSpec.BuffIds)
+	form.IntList(`wornbuffids[]`, &itemSpec.WornBuffIds)
+
+	result := saveResult{}
+
+	if err := form.Err(); err != nil {
+		result.Error = err.Error()
+	} else if itemSpec.Name == `` {
+		result.Error = `Name cannot be empty.`
+	} else if itemSpec.ItemId == 0 {
+		newItemId, err := items.CreateNewItemFile(itemSpec)
+		recordAdminChange(r, `create`, `item`, newItemId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			itemSpec = *items.GetItemSpec(newItemId)
+			result.Message = fmt.Sprintf(`Item %d created.`, newItemId)
+		}
+	} else {
+		err := items.UpdateItemFile(itemSpec)
+		recordAdminChange(r, `update`, `item`, itemSpec.ItemId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			itemSpec = *items.GetItemSpec(itemSpec.ItemId)
+			result.Message = fmt.Sprintf(`Item %d saved.`, itemSpec.ItemId)
+		}
+	}
+
+	renderItemData(w, itemSpec, result)
+}
+
+// Takes the MUD lock itself, so that reading every room and user file to check
+// whether the item is in use doesn't stall the game.
+func itemDelete(w http.ResponseWriter, r *http.Request) {
+
+	itemId, _ := strconv.Atoi(r.URL.Query().Get(`itemid`))
+
+	util.RLockMud()
+	roomFiles, onlineUserIds, err := itemInUse(itemId)
+	util.RUnlockMud()
+
+	if err == nil {
+		err = itemInUseOnDisk(itemId, roomFiles, onlineUserIds)
+	}
+
+	if err == nil {
+		// Check memory again, in case a room was loaded or a player logged in meanwhile
+		util.LockMud()
+		if _, _, err = itemInUse(itemId); err == nil {
+			err = items.DeleteItemFile(itemId)
+		}
+		util.UnlockMud()
+	}
+	recordAdminChange(r, `delete`, `item`, itemId, err)
+
+	if err != nil {
+		renderSaveResult(w, saveResult{Error: err.Error()})
+		return
+	}
+
+	renderSaveResult(w, saveResult{Message: fmt.Sprintf(`Item %d deleted.`, itemId)})
+}
+
+func itemListsHave(itemId int, itemLists ...[]items.Item) bool {
+	for _, itemList := range itemLists {
+		for _, itm := range itemList {
+			if itm.ItemId == itemId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func characterHasItem(itemId int, c *characters.Character) bool {
+	if c == nil {
+		return false
+	}
+	for _, shopItem := range c.Shop {
+		if shopItem.ItemId == itemId || shopItem.TradeItemId == itemId {
+			return true
+		}
+	}
+	return itemListsHave(itemId, c.GetAllBackpackItems(), c.GetAllWornItems())
+}
+
+func roomHasItem(itemId int, room *rooms.Room) error {
+
+	for _, spawnInfo := range room.SpawnInfo {
+		if spawnInfo.ItemId == itemId {
+			return fmt.Errorf(`item %d is spawned in room %d`, itemId, room.RoomId)
+		}
+	}
+
+	if itemListsHave(itemId, room.Items, room.Stash) {
+		return fmt.Errorf(`item %d is in room %d`, itemId, room.RoomId)
+	}
+
+	for containerName, container := range room.Containers {
+		if itemListsHave(itemId, container.Items) {
+			return fmt.Errorf(`item %d is in the %s in room %d`, itemId, containerName, room.RoomId)
+		}
+	}
+
+	return nil
+}
+
+func userHasItem(itemId int, u *users.UserRecord) bool {
+	return characterHasItem(itemId, u.Character) || itemListsHave(itemId, u.ItemStorage.Items)
+}
+
+// Items look up their spec whenever they're used, so a spec can't go away while any copy of it is still around.
+// Checks what's in memory: mobs, loaded rooms and online users. Needs the MUD lock.
+// Returns the unloaded room files and the online user ids for itemInUseOnDisk().
+func itemInUse(itemId int) (roomFiles map[int]string, onlineUserIds map[int]struct{}, err error) {
+
+	for _, mobInfo := range mobs.GetAllMobInfo() {
+		if characterHasItem(itemId, &mobInfo.Character) {
+			return nil, nil, fmt.Errorf(`item %d is used by mob %d (%s)`, itemId, mobInfo.MobId, mobInfo.Character.Name)
+		}
+	}
+
+	for _, instanceId := range mobs.GetAllMobInstanceIds() {
+		if mob := mobs.GetInstance(instanceId); mob != nil && characterHasItem(itemId, &mob.Character) {
+			return nil, nil, fmt.Errorf(`item %d is carried by %s (%s)`, itemId, mob.Character.Name, mob.ShorthandId())
+		}
+	}
+
+	roomFiles = rooms.GetUnloadedRoomFiles()
+
+	for _, roomId := range rooms.GetAllRoomIds() {
+		if _, ok := roomFiles[roomId]; ok {
+			continue
+		}
+		if room := rooms.LoadRoom(roomId); room != nil {
+			if err := roomHasItem(itemId, room); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	onlineUserIds = map[int]struct{}{}
+
+	for _, u := range users.GetAllActiveUsers() {
+		if userHasItem(itemId, u) {
+			return nil, nil, fmt.Errorf(`item %d is held by a player`, itemId)
+		}
+		onlineUserIds[u.UserId] = struct{}{}
+	}
+
+	return roomFiles, onlineUserIds, nil
+}
+
+// Checks the saved rooms and offline users that itemInUse() left out. Only reads files, so it runs without the MUD lock.
+func itemInUseOnDisk(itemId int, roomFiles map[int]string, onlineUserIds map[int]struct{}) error {
+
+	for _, filename := range roomFiles {
+		if room := rooms.LoadRoomInstanceFile(filename); room != nil {
+			if err := roomHasItem(itemId, room); err != nil {
+				return err
+			}
+		}
+	}
+
+	found := false
+	users.SearchUserFiles(func(u *users.UserRecord) bool {
+		if _, online := onlineUserIds[u.UserId]; online {
+			return true
+		}
+		if userHasItem(itemId, u) {
+			found = true
+			return false
+		}
+		return true
+	})
+
+	if found {
+		return fmt.Errorf(`item %d is held by a player`, itemId)
+	}
+
+	return nil
+}
+
+func renderItemData(w http.ResponseWriter, itemSpec items.ItemSpec, result saveResult) {
+
+	tmpl, err := template.New("item.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_result.html", configs.GetFilePathsConfig().AdminHtml.String()+"/items/item.data.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+	}
+
 	tplData := map[string]any{}
-	tplData[`itemSpec`] = *itemSpec
+	tplData[`itemSpec`] = itemSpec
+	tplData[`saveResult`] = result
 
 	buffSpecs := []buffs.BuffSpec{}
 	for _, buffId := range buffs.GetAllBuffIds() {
@@ -1,7 +1,9 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"text/template"
@@ -17,7 +19,7 @@ import (
 
 func mobsIndex(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("index.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mobs/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mobs/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
 	if err != nil {
 		mudlog.Error("HTML Template", "error", err)
 	}
@@ -41,11 +43,6 @@ func mobsIndex(w http.ResponseWriter, r *http.Request) {
 
 func mobData(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("mob.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/mobs/mob.data.html")
-	if err != nil {
-		mudlog.Error("HTML Template", "error", err)
-	}
-
 	urlVals := r.URL.Query()
 
 	mobIdInt, _ := strconv.Atoi(urlVals.Get(`mobid`))
@@ -55,6 +52,103 @@ func mobData(w http.ResponseWriter, r *http.Request) {
 		mobInfo = &mobs.Mob{}
 	}
 
+	renderMobData(w, mobInfo, saveResult{})
+}
+
+// Creates (mobid 0) or updates a mob, then shows the form again
+func mobSave(w http.ResponseWriter, r *http.Request) {
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mobId := 0
+	formInt(form, `mobid`, &mobId)
+
+	mobInfo := &mobs.Mob{}
+	if mobId > 0 {
+		if mobInfo = mobs.GetMobSpec(mobs.MobId(mobId)); mobInfo == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Mob %d not found.`, mobId)})
+			return
+		}
+	}
+
+	form.String(`zone`, &mobInfo.Zone)
+	formInt(form, `activitylevel`, &mobInfo.ActivityLevel)
+	formInt(form, `dropchance`, &mobInfo.ItemDropChance)
+	form.Bool(`hostile`, &mobInfo.Hostile)
+	formInt(form, `maxwander`, &mobInfo.MaxWander)
+	form.Lines(`questflags`, &mobInfo.QuestFlags)
+	form.Lines(`idlecommands`, &mobInfo.IdleCommands)
+	form.Lines(`angrycommands`, &mobInfo.AngryCommands)
+	form.Lines(`combatcommands`, &mobInfo.CombatCommands)
+	form.Lines(`groups`, &mobInfo.Groups)
+	form.Lines(`hates`, &mobInfo.Hates)
+	form.IntList(`buffids[]`, &mobInfo.BuffIds)
+	form.String(`character-name`, &mobInfo.Character.Name)
+	form.Text(`character-description`, &mobInfo.Character.Description)
+	formInt(form, `character-raceid`, &mobInfo.Character.RaceId)
+	formInt(form, `character-level`, &mobInfo.Character.Level)
+	formInt(form, `character-alignment`, &mobInfo.Character.Alignment)
+	formInt(form, `character-gold`, &mobInfo.Character.Gold)
+
+	result := saveResult{}
+
+	if err := form.Err(); err != nil {
+		result.Error = err.Error()
+	} else if mobInfo.Character.Name == `` {
+		result.Error = `Name cannot be empty.`
+	} else if mobInfo.Zone == `` {
+		result.Error = `Zone cannot be empty.`
+	} else if races.GetRace(mobInfo.Character.RaceId) == nil {
+		result.Error = fmt.Sprintf(`Race %d does not exist.`, mobInfo.Character.RaceId)
+	} else if mobInfo.MobId == 0 {
+		newMobId, err := mobs.CreateNewMobFile(*mobInfo, ``)
+		recordAdminChange(r, `create`, `mob`, newMobId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			mobInfo = mobs.GetMobSpec(newMobId)
+			result.Message = fmt.Sprintf(`Mob %d created.`, newMobId)
+		}
+	} else {
+		err := mobs.UpdateMobFile(*mobInfo)
+		recordAdminChange(r, `update`, `mob`, mobInfo.MobId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			mobInfo = mobs.GetMobSpec(mobInfo.MobId)
+			result.Message = fmt.Sprintf(`Mob %d saved. Mobs already spawned keep their old data until they respawn.`, mobInfo.MobId)
+		}
+	}
+
+	renderMobData(w, mobInfo, result)
+}
+
+func mobDelete(w http.ResponseWriter, r *http.Request) {
+
+	mobId, _ := strconv.Atoi(r.URL.Query().Get(`mobid`))
+
+	err := mobs.DeleteMobFile(mobs.MobId(mobId))
+	recordAdminChange(r, `delete`, `mob`, mobId, err)
+
+	if err != nil {
+		renderSaveResult(w, saveResult{Error: err.Error()})
+		return
+	}
+
+	renderSaveResult(w, saveResult{Message: fmt.Sprintf(`Mob %d deleted. Rooms that spawn it will skip it.`, mobId)})
+}
+
+func renderMobData(w http.ResponseWriter, mobInfo *mobs.Mob, result saveResult) {
+
+	tmpl, err := template.New("mob.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_result.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mobs/mob.data.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+	}
+
 	mobGroupSet := map[string]struct{}{}
 	allMobGroups := []string{}
 	for _, m := range mobs.GetAllMobInfo() {
@@ -104,6 +198,7 @@ func mobData(w http.ResponseWriter, r *http.Request) {
 	tplData := map[string]any{}
 
 	tplData[`mobInfo`] = *mobInfo
+	tplData[`saveResult`] = result
 
 	shopData := map[string]characters.Shop{
 		`Items`:       {},
@@ -138,6 +233,7 @@ func mobData(w http.ResponseWriter, r *http.Request) {
 
 	tplData[`characterInfo`] = &mobInfo.Character
 	tplData[`allZoneNames`] = allZoneNames
+	tplData[`unlistedZone`] = mobInfo.Zone != `` && !slices.Contains(allZoneNames, mobInfo.Zone)
 	tplData[`allRaces`] = allRaces
 	tplData[`activityLevels`] = activityLevels
 	tplData[`dropChances`] = dropChances
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
 	"text/template"
@@ -10,11 +11,12 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/mutators"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
 )
 
 func mutatorsIndex(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("index.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mutators/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mutators/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
 	if err != nil {
 		mudlog.Error("HTML Template", "error", err)
 	}
@@ -39,22 +41,185 @@ func mutatorsIndex(w http.ResponseWriter, r *http.Request) {
 
 func mutatorData(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("mutator.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/mutators/mutator.data.html")
-	if err != nil {
-		mudlog.Error("HTML Template", "error", err)
-	}
-
 	urlVals := r.URL.Query()
 
 	mutatorId := urlVals.Get(`mutatorid`)
 
+	if mutatorId == `0` {
+		renderMutatorData(w, mutators.MutatorSpec{}, true, saveResult{})
+		return
+	}
+
 	mutSpec := mutators.GetMutatorSpec(mutatorId)
 	if mutSpec == nil {
-		mutSpec = &mutators.MutatorSpec{}
+		renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Mutator %s not found.`, mutatorId)})
+		return
+	}
+
+	renderMutatorData(w, *mutSpec, false, saveResult{})
+}
+
+// Creates (isnew=true) or updates a mutator, then shows the form again
+func mutatorSave(w http.ResponseWriter, r *http.Request) {
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	isNew := form.get(`isnew`) == `true`
+
+	mutSpec := mutators.MutatorSpec{}
+	form.String(`mutatorid`, &mutSpec.MutatorId)
+
+	if !isNew {
+		existing := mutators.GetMutatorSpec(mutSpec.MutatorId)
+		if existing == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Mutator %s not found.`, mutSpec.MutatorId)})
+			return
+		}
+		mutSpec = *existing
+	}
+
+	mutSpec.NameModifier = formTextModifier(form, `namemodifier`, mutSpec.NameModifier)
+	mutSpec.DescriptionModifier = formTextModifier(form, `descriptionmodifier`, mutSpec.DescriptionModifier)
+	mutSpec.AlertModifier = formTextModifier(form, `alertmodifier`, mutSpec.AlertModifier)
+	form.String(`respawnrate`, &mutSpec.RespawnRate)
+	form.String(`decayrate`, &mutSpec.DecayRate)
+	form.String(`decayintoid`, &mutSpec.DecayIntoId)
+	form.IntList(`playerbuffids[]`, &mutSpec.PlayerBuffIds)
+	form.IntList(`mobbuffids[]`, &mutSpec.MobBuffIds)
+	form.IntList(`nativebuffids[]`, &mutSpec.NativeBuffIds)
+	form.Bool(`pvp-enabled`, &mutSpec.Pvp.Enabled)
+	form.Bool(`pvp-disabled`, &mutSpec.Pvp.Disabled)
+	formInt(form, `lightmod`, &mutSpec.LightMod)
+
+	result := saveResult{}
+
+	if err := form.Err(); err != nil {
+		result.Error = err.Error()
+	} else if mutSpec.DecayIntoId != `` && mutSpec.DecayIntoId != mutSpec.MutatorId && !mutators.IsMutator(mutSpec.DecayIntoId) {
+		result.Error = fmt.Sprintf(`mutator %s does not exist`, mutSpec.DecayIntoId)
+	} else if isNew {
+		err := mutators.CreateNewMutatorFile(mutSpec)
+		recordAdminChange(r, `create`, `mutator`, mutSpec.MutatorId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			isNew = false
+			result.Message = fmt.Sprintf(`Mutator %s created.`, mutSpec.MutatorId)
+		}
+	} else {
+		err := mutators.UpdateMutatorFile(mutSpec)
+		recordAdminChange(r, `update`, `mutator`, mutSpec.MutatorId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Message = fmt.Sprintf(`Mutator %s saved.`, mutSpec.MutatorId)
+		}
+	}
+
+	if !isNew {
+		mutSpec = *mutators.GetMutatorSpec(mutSpec.MutatorId)
+	}
+
+	renderMutatorData(w, mutSpec, isNew, result)
+}
+
+func mutatorDelete(w http.ResponseWriter, r *http.Request) {
+
+	mutatorId := r.URL.Query().Get(`mutatorid`)
+
+	err := mutatorInUse(mutatorId)
+	if err == nil {
+		err = mutators.DeleteMutatorFile(mutatorId)
+	}
+	recordAdminChange(r, `delete`, `mutator`, mutatorId, err)
+
+	if err != nil {
+		renderSaveResult(w, saveResult{Error: err.Error()})
+		return
+	}
+
+	renderSaveResult(w, saveResult{Message: fmt.Sprintf(`Mutator %s deleted.`, mutatorId)})
+}
+
+// Live mutators look up their spec every round, so a spec can't go away while anything still uses it.
+func mutatorInUse(mutatorId string) error {
+
+	for _, mutSpec := range mutators.GetAllMutatorSpecs() {
+		if mutSpec.MutatorId != mutatorId && mutSpec.DecayIntoId == mutatorId {
+			return fmt.Errorf(`mutator %s decays into %s`, mutSpec.MutatorId, mutatorId)
+		}
+	}
+
+	for _, zoneName := range rooms.GetAllZoneNames() {
+		if zoneConfig := rooms.GetZoneConfig(zoneName); zoneConfig != nil {
+			for _, mut := range zoneConfig.Mutators {
+				if mut.MutatorId == mutatorId {
+					return fmt.Errorf(`mutator %s is used by zone %s`, mutatorId, zoneName)
+				}
+			}
+		}
+	}
+
+	for _, roomId := range rooms.GetAllRoomIds() {
+
+		var room *rooms.Room
+		if rooms.IsRoomLoaded(roomId) {
+			room = rooms.LoadRoom(roomId)
+		} else {
+			room = rooms.LoadRoomTemplate(roomId)
+		}
+
+		if room == nil {
+			continue
+		}
+
+		for _, mut := range room.Mutators {
+			if mut.MutatorId == mutatorId {
+				return fmt.Errorf(`mutator %s is used by room %d`, mutatorId, roomId)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fields named like namemodifier-text. Empty text means no modifier at all.
+func formTextModifier(f *adminForm, prefix string, current *mutators.TextModifier) *mutators.TextModifier {
+
+	if !f.has(prefix + `-text`) {
+		return current
+	}
+
+	mod := &mutators.TextModifier{}
+	f.Text(prefix+`-text`, &mod.Text)
+	if mod.Text == `` {
+		return nil
+	}
+
+	mod.Behavior = mutators.TextDefault
+	if f.has(prefix + `-behavior`) {
+		mod.Behavior = mutators.TextBehavior(f.get(prefix + `-behavior`))
+	}
+	f.String(prefix+`-colorpattern`, &mod.ColorPattern)
+
+	return mod
+}
+
+func renderMutatorData(w http.ResponseWriter, mutSpec mutators.MutatorSpec, isNew bool, result saveResult) {
+
+	tmpl, err := template.New("mutator.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_result.html", configs.GetFilePathsConfig().AdminHtml.String()+"/mutators/mutator.data.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
 	}
 
 	tplData := map[string]any{}
-	tplData[`mutatorSpec`] = *mutSpec
+	tplData[`mutatorSpec`] = mutSpec
+	tplData[`isNew`] = isNew
+	tplData[`saveResult`] = result
 
 	buffSpecs := []buffs.BuffSpec{}
 	for _, buffId := range buffs.GetAllBuffIds() {
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
@@ -9,13 +10,15 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/buffs"
 	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/races"
+	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
 func racesIndex(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("index.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/races/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/races/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
 	if err != nil {
 		mudlog.Error("HTML Template", "error", err)
 	}
@@ -40,22 +43,139 @@ func racesIndex(w http.ResponseWriter, r *http.Request) {
 
 func raceData(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("race.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/races/race.data.html")
-	if err != nil {
-		mudlog.Error("HTML Template", "error", err)
-	}
-
 	urlVals := r.URL.Query()
 
+	// Race 0 exists, so new races are asked for by name
+	if urlVals.Get(`raceid`) == `new` {
+		renderRaceData(w, races.Race{}, true, saveResult{})
+		return
+	}
+
 	raceIdInt, _ := strconv.Atoi(urlVals.Get(`raceid`))
 
 	raceInfo := races.GetRace(raceIdInt)
 	if raceInfo == nil {
-		raceInfo = &races.Race{}
+		renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Race %d not found.`, raceIdInt)})
+		return
+	}
+
+	renderRaceData(w, *raceInfo, false, saveResult{})
+}
+
+// Creates (raceid "new") or updates a race, then shows the form again
+func raceSave(w http.ResponseWriter, r *http.Request) {
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	isNew := form.get(`raceid`) == `new`
+
+	raceInfo := races.Race{}
+	if !isNew {
+		raceId := 0
+		formInt(form, `raceid`, &raceId)
+		existing := races.GetRace(raceId)
+		if existing == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Race %d not found.`, raceId)})
+			return
+		}
+		raceInfo = *existing
+	}
+
+	form.String(`name`, &raceInfo.Name)
+	form.Text(`description`, &raceInfo.Description)
+	if form.has(`size`) {
+		raceInfo.Size = races.Size(form.get(`size`))
+	}
+	form.Bool(`selectable`, &raceInfo.Selectable)
+	form.Bool(`tameable`, &raceInfo.Tameable)
+	form.Bool(`knowsfirstaid`, &raceInfo.KnowsFirstAid)
+	formInt(form, `stats[strength]`, &raceInfo.Stats.Strength.Base)
+	formInt(form, `stats[speed]`, &raceInfo.Stats.Speed.Base)
+	formInt(form, `stats[smarts]`, &raceInfo.Stats.Smarts.Base)
+	formInt(form, `stats[vitality]`, &raceInfo.Stats.Vitality.Base)
+	formInt(form, `stats[mysticism]`, &raceInfo.Stats.Mysticism.Base)
+	formInt(form, `stats[perception]`, &raceInfo.Stats.Perception.Base)
+	form.String(`unarmedname`, &raceInfo.UnarmedName)
+	form.String(`damage`, &raceInfo.Damage.DiceRoll)
+	form.Lines(`angrycommands`, &raceInfo.AngryCommands)
+	form.IntList(`buffids[]`, &raceInfo.BuffIds)
+	form.StringList(`disabledslots[]`, &raceInfo.DisabledSlots)
+	formInt(form, `defaultalignment`, &raceInfo.DefaultAlignment)
+	formFloat(form, `tnlscale`, &raceInfo.TNLScale)
+
+	result := saveResult{}
+
+	if err := form.Err(); err != nil {
+		result.Error = err.Error()
+	} else if isNew {
+		newRaceId, err := races.CreateNewRaceFile(raceInfo)
+		recordAdminChange(r, `create`, `race`, newRaceId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			raceInfo = *races.GetRace(newRaceId)
+			isNew = false
+			result.Message = fmt.Sprintf(`Race %d created.`, newRaceId)
+		}
+	} else {
+		err := races.UpdateRaceFile(raceInfo)
+		recordAdminChange(r, `update`, `race`, raceInfo.RaceId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			raceInfo = *races.GetRace(raceInfo.RaceId)
+			result.Message = fmt.Sprintf(`Race %d saved.`, raceInfo.RaceId)
+		}
+	}
+
+	renderRaceData(w, raceInfo, isNew, result)
+}
+
+func raceDelete(w http.ResponseWriter, r *http.Request) {
+
+	raceId, _ := strconv.Atoi(r.URL.Query().Get(`raceid`))
+
+	var err error
+
+	for _, mobInfo := range mobs.GetAllMobInfo() {
+		if mobInfo.Character.RaceId == raceId {
+			err = fmt.Errorf(`race %d is used by mob %d (%s)`, raceId, mobInfo.MobId, mobInfo.Character.Name)
+			break
+		}
+	}
+
+	if err == nil && anyUser(func(u *users.UserRecord) bool { return u.Character != nil && u.Character.RaceId == raceId }) {
+		err = fmt.Errorf(`race %d is used by a player character`, raceId)
+	}
+
+	if err == nil {
+		err = races.DeleteRaceFile(raceId)
+	}
+	recordAdminChange(r, `delete`, `race`, raceId, err)
+
+	if err != nil {
+		renderSaveResult(w, saveResult{Error: err.Error()})
+		return
+	}
+
+	renderSaveResult(w, saveResult{Message: fmt.Sprintf(`Race %d deleted.`, raceId)})
+}
+
+func renderRaceData(w http.ResponseWriter, raceInfo races.Race, isNew bool, result saveResult) {
+
+	tmpl, err := template.New("race.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_result.html", configs.GetFilePathsConfig().AdminHtml.String()+"/races/race.data.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
 	}
 
 	tplData := map[string]any{}
-	tplData[`raceInfo`] = *raceInfo
+	tplData[`raceInfo`] = raceInfo
+	tplData[`isNew`] = isNew
+	tplData[`saveResult`] = result
 
 	buffSpecs := []buffs.BuffSpec{}
 	for _, buffId := range buffs.GetAllBuffIds() {
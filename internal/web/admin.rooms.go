@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
@@ -25,7 +26,7 @@ type ZoneDetails struct {
 
 func roomsIndex(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("index.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/rooms/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
+	tmpl, err := template.New("index.html").Funcs(adminFuncMap(r)).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_header.html", configs.GetFilePathsConfig().AdminHtml.String()+"/rooms/index.html", configs.GetFilePathsConfig().AdminHtml.String()+"/_footer.html")
 	if err != nil {
 		mudlog.Error("HTML Template", "error", err)
 	}
@@ -144,19 +145,141 @@ func roomsIndex(w http.ResponseWriter, r *http.Request) {
 
 func roomData(w http.ResponseWriter, r *http.Request) {
 
-	tmpl, err := template.New("room.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/rooms/room.data.html")
-	if err != nil {
-		mudlog.Error("HTML Template", "error", err)
-	}
-
 	urlVals := r.URL.Query()
 
 	roomIdInt, _ := strconv.Atoi(urlVals.Get(`roomid`))
 
+	// LoadRoom(0) is the start room, so 0 has to be handled first
+	if roomIdInt == 0 {
+		renderRoomData(w, &rooms.Room{}, true, saveResult{})
+		return
+	}
+
 	roomInfo := rooms.LoadRoom(roomIdInt)
+	if roomInfo == nil {
+		renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Room %d not found.`, roomIdInt)})
+		return
+	}
+
+	renderRoomData(w, roomInfo, false, saveResult{})
+}
+
+// Creates (roomid 0) or updates a room template, then shows the form again
+func roomSave(w http.ResponseWriter, r *http.Request) {
+
+	form, err := newAdminForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	roomId := 0
+	formInt(form, `roomid`, &roomId)
+
+	isNew := roomId == 0
+
+	var roomTpl *rooms.Room
+
+	if isNew {
+		zone := form.get(`zone`)
+		if rooms.GetZoneConfig(zone) == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Zone "%s" does not exist.`, zone)})
+			return
+		}
+		roomTpl = rooms.NewRoom(zone)
+	} else {
+		// The room has to be in memory before its template can be saved
+		if room := rooms.LoadRoom(roomId); room == nil || room.RoomId != roomId {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Room %d not found.`, roomId)})
+			return
+		}
+		if roomTpl = rooms.LoadRoomTemplate(roomId); roomTpl == nil {
+			renderSaveResult(w, saveResult{Error: fmt.Sprintf(`Room %d has no template file.`, roomId)})
+			return
+		}
+	}
+
+	form.String(`title`, &roomTpl.Title)
+	form.Text(`description`, &roomTpl.Description)
+	if form.has(`biome`) {
+		roomTpl.Biome = strings.ToLower(form.get(`biome`))
+	}
+	form.String(`mapsymbol`, &roomTpl.MapSymbol)
+	form.String(`maplegend`, &roomTpl.MapLegend)
+	form.Bool(`isbank`, &roomTpl.IsBank)
+	form.Bool(`isstorage`, &roomTpl.IsStorage)
+	form.Bool(`ischaracterroom`, &roomTpl.IsCharacterRoom)
+	form.Bool(`ispvp`, &roomTpl.Pvp)
+	form.StringList(`idlemessages[]`, &roomTpl.IdleMessages)
+
+	result := saveResult{}
+
+	if err := form.Err(); err != nil {
+		result.Error = err.Error()
+	} else if isNew {
+		err := rooms.AddNewRoom(roomTpl)
+		recordAdminChange(r, `create`, `room`, roomTpl.RoomId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			isNew = false
+			result.Message = fmt.Sprintf(`Room %d created in %s. It has no exits yet.`, roomTpl.RoomId, roomTpl.Zone)
+		}
+	} else if err := roomTpl.Validate(); err != nil {
+		result.Error = err.Error()
+	} else {
+		err := rooms.SaveRoomTemplate(*roomTpl)
+		recordAdminChange(r, `update`, `room`, roomTpl.RoomId, err)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Message = fmt.Sprintf(`Room %d saved.`, roomTpl.RoomId)
+		}
+	}
+
+	if isNew {
+		// Nothing was saved, so don't show the id NewRoom() picked
+		roomTpl.RoomId = 0
+		renderRoomData(w, roomTpl, true, result)
+		return
+	}
+
+	renderRoomData(w, rooms.LoadRoom(roomTpl.RoomId), false, result)
+}
+
+func roomDelete(w http.ResponseWriter, r *http.Request) {
+
+	roomId, _ := strconv.Atoi(r.URL.Query().Get(`roomid`))
+
+	err := rooms.DeleteRoom(roomId)
+	recordAdminChange(r, `delete`, `room`, roomId, err)
+
+	if err != nil {
+		renderSaveResult(w, saveResult{Error: err.Error()})
+		return
+	}
+
+	renderSaveResult(w, saveResult{Message: fmt.Sprintf(`Room %d deleted.`, roomId)})
+}
+
+func renderRoomData(w http.ResponseWriter, roomInfo *rooms.Room, isNew bool, result saveResult) {
+
+	tmpl, err := template.New("room.data.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String()+"/_result.html", configs.GetFilePathsConfig().AdminHtml.String()+"/rooms/room.data.html")
+	if err != nil {
+		mudlog.Error("HTML Template", "error", err)
+	}
 
 	tplData := map[string]any{}
 	tplData[`roomInfo`] = roomInfo
+	tplData[`zoneConfig`] = rooms.GetZoneConfig(roomInfo.Zone)
+	tplData[`isNew`] = isNew
+	tplData[`saveResult`] = result
+
+	if isNew {
+		allZoneNames := rooms.GetAllZoneNames()
+		sort.Strings(allZoneNames)
+		tplData[`allZoneNames`] = allZoneNames
+	}
 
 	buffSpecs := []buffs.BuffSpec{}
 	for _, buffId := range buffs.GetAllBuffIds() {
@@ -0,0 +1,253 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/apitokens"
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// JSON api for external tools (bots, dashboards, companion apps).
+// Every request needs an "Authorization: Bearer <token>" header. Tokens are made with the apitoken admin command.
+// A role token acts as that role. A user token acts as the user's current role, and can always read its own data.
+//
+
+type apiClientKey struct{}
+
+// Who is making an api request
+type apiClient struct {
+	Token apitokens.Token
+	Role  string
+	User  *users.UserRecord // A copy of the token's user, nil for role tokens
+}
+
+// Checks a permission such as "api.characters" against the Roles config
+func (c *apiClient) Can(permissionId string) bool {
+	return users.RoleHasPermission(c.Role, permissionId)
+}
+
+// Whether the request is about the client's own account
+func (c *apiClient) IsSelf(username string) bool {
+	return c.User != nil && strings.EqualFold(c.User.Username, username)
+}
+
+func getApiClient(r *http.Request) *apiClient {
+	client, _ := r.Context().Value(apiClientKey{}).(*apiClient)
+	return client
+}
+
+var (
+	apiLimiter = newRateLimiter()
+)
+
+// A token bucket per key. Each bucket holds up to limit requests, and refills over a minute.
+type rateLimiter struct {
+	lock    sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: map[string]*rateBucket{}}
+}
+
+// Takes one request from the bucket for key.
+// Returns whether it was allowed, how many are left, and how long until the next one if not.
+func (rl *rateLimiter) Allow(key string, limit int, now time.Time) (ok bool, remaining int, retryAfter time.Duration) {
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	perSecond := float64(limit) / 60
+
+	b, found := rl.buckets[key]
+	if !found {
+		// Forget idle buckets so that the map doesn't grow forever
+		if len(rl.buckets) >= 1024 {
+			for k, old := range rl.buckets {
+				if old.tokens+now.Sub(old.last).Seconds()*perSecond >= float64(limit) {
+					delete(rl.buckets, k)
+				}
+			}
+		}
+		b = &rateBucket{tokens: float64(limit), last: now}
+		rl.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+		return false, 0, wait
+	}
+
+	b.tokens--
+
+	return true, int(b.tokens), 0
+}
+
+// Returns false (and writes a 429) if the key is over the limit
+func apiRateLimit(w http.ResponseWriter, key string) bool {
+
+	limit := int(configs.GetNetworkConfig().ApiRateLimit)
+
+	ok, remaining, retryAfter := apiLimiter.Allow(key, limit, time.Now())
+
+	w.Header().Set(`X-RateLimit-Limit`, strconv.Itoa(limit))
+	w.Header().Set(`X-RateLimit-Remaining`, strconv.Itoa(remaining))
+
+	if !ok {
+		w.Header().Set(`Retry-After`, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		writeApiError(w, http.StatusTooManyRequests, `rate limit exceeded`)
+		return false
+	}
+
+	return true
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(`Authorization`), ` `)
+	if !ok || !strings.EqualFold(scheme, `Bearer`) {
+		return ``, false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ``
+}
+
+// Finds a user record without changing anything on disk
+func apiLoadUser(username string) *users.UserRecord {
+
+	for _, u := range users.GetAllActiveUsers() {
+		if strings.EqualFold(u.Username, username) {
+			return u
+		}
+	}
+
+	u, err := users.LoadUser(username, true)
+	if err != nil {
+		return nil
+	}
+
+	// Fill in the calculated values (max health, stat totals) of offline users
+	u.Character.Validate()
+
+	return u
+}
+
+// Finds the user behind a token: a copy of the online record, taken under the MUD read lock,
+// or the user file read with no lock held.
+func apiTokenUser(username string) (*users.UserRecord, error) {
+
+	util.RLockMud()
+	var uCopy users.UserRecord
+	online := false
+	for _, u := range users.GetAllActiveUsers() {
+		if strings.EqualFold(u.Username, username) {
+			uCopy, online = *u, true
+			break
+		}
+	}
+	util.RUnlockMud()
+
+	if online {
+		return &uCopy, nil
+	}
+
+	return users.LoadUser(username, true)
+}
+
+// Takes the MUD read lock to look up user tokens, so it must wrap RunWithMUDLocked() and not the other way around.
+func doTokenAuth(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		secret, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set(`WWW-Authenticate`, `Bearer realm="api"`)
+			writeApiError(w, http.StatusUnauthorized, `missing bearer token`)
+			return
+		}
+
+		token, err := apitokens.Check(secret)
+		if err != nil {
+			// Failed attempts share a bucket per address, to slow down guessing
+			if !apiRateLimit(w, `ip:`+clientIP(r)) {
+				return
+			}
+			mudlog.Warn("API", "ip", clientIP(r), "path", r.URL.Path, "error", err)
+			w.Header().Set(`WWW-Authenticate`, `Bearer realm="api", error="invalid_token"`)
+			writeApiError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		if !apiRateLimit(w, `token:`+strconv.Itoa(token.TokenId)) {
+			return
+		}
+
+		client := &apiClient{Token: token, Role: token.Subject}
+
+		if token.Kind == apitokens.KindUser {
+
+			client.User, err = apiTokenUser(token.Subject)
+			if err != nil {
+				writeApiError(w, http.StatusUnauthorized, `token user no longer exists`)
+				return
+			}
+
+			if _, banned := bans.CheckAccount(client.User.Username); banned {
+				writeApiError(w, http.StatusForbidden, `account is banned`)
+				return
+			}
+
+			client.Role = client.User.Role
+		}
+
+		mudlog.Debug("API", "ip", clientIP(r), "path", r.URL.Path, "tokenId", token.TokenId, "role", client.Role)
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiClientKey{}, client)))
+	})
+}
+
+// Wraps a handler with a permission check.
+// An empty permissionId lets any valid token through.
+func requireApiPermission(permissionId string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if permissionId != `` && !getApiClient(r).Can(permissionId) {
+			writeApiError(w, http.StatusForbidden, `token lacks permission: `+permissionId)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeApiJSON(w http.ResponseWriter, status int, data any) {
+
+	w.Header().Set(`Content-Type`, `application/json`)
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		mudlog.Error("API", "error", err)
+	}
+}
+
+func writeApiError(w http.ResponseWriter, status int, message string) {
+	writeApiJSON(w, status, map[string]string{`error`: message})
+}
@@ -0,0 +1,476 @@
+package web
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/quests"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/usercommands"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/ansitags"
+)
+
+//
+// /api/v1 endpoints. Everything here is read only.
+//
+
+const (
+	apiPermCharacters = `api.characters` // Any user's character, inventory and quests (your own are always allowed)
+	apiPermRooms      = `api.rooms`      // Room details
+)
+
+type apiMe struct {
+	TokenId   int       `json:"tokenid"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expiresat,omitzero"`
+}
+
+type apiCharacter struct {
+	Username      string         `json:"username"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	Race          string         `json:"race"`
+	Profession    string         `json:"profession"`
+	Alignment     string         `json:"alignment"`
+	Level         int            `json:"level"`
+	Experience    int            `json:"experience"`
+	ExperienceTNL int            `json:"experiencetnl"`
+	Health        int            `json:"health"`
+	HealthMax     int            `json:"healthmax"`
+	Mana          int            `json:"mana"`
+	ManaMax       int            `json:"manamax"`
+	Gold          int            `json:"gold"`
+	Bank          int            `json:"bank"`
+	Zone          string         `json:"zone"`
+	RoomId        int            `json:"roomid"`
+	Stats         map[string]int `json:"stats"`
+	Skills        map[string]int `json:"skills"`
+	Kills         int            `json:"kills"`
+	Deaths        int            `json:"deaths"`
+	Online        bool           `json:"online"`
+	Created       time.Time      `json:"created"`
+}
+
+type apiItem struct {
+	ItemId     int      `json:"itemid"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Subtype    string   `json:"subtype"`
+	Uses       int      `json:"uses,omitempty"`
+	Cursed     bool     `json:"cursed,omitempty"`
+	Adjectives []string `json:"adjectives,omitempty"`
+}
+
+type apiInventory struct {
+	Gold      int                `json:"gold"`
+	Backpack  []apiItem          `json:"backpack"`
+	Equipment map[string]apiItem `json:"equipment"`
+}
+
+type apiQuest struct {
+	QuestId     int    `json:"questid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Step        string `json:"step"`
+	StepNumber  int    `json:"stepnumber"`
+	TotalSteps  int    `json:"totalsteps"`
+	Completed   bool   `json:"completed"`
+}
+
+type apiZone struct {
+	Name         string `json:"name"`
+	RootRoomId   int    `json:"rootroomid"`
+	RoomCount    int    `json:"roomcount"`
+	DefaultBiome string `json:"defaultbiome"`
+	MinLevel     int    `json:"minlevel,omitempty"`
+	MaxLevel     int    `json:"maxlevel,omitempty"`
+}
+
+type apiExit struct {
+	RoomId int  `json:"roomid"`
+	Secret bool `json:"secret,omitempty"`
+	Locked bool `json:"locked,omitempty"`
+}
+
+type apiRoom struct {
+	RoomId      int                `json:"roomid"`
+	Zone        string             `json:"zone"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Biome       string             `json:"biome"`
+	MapSymbol   string             `json:"mapsymbol,omitempty"`
+	MapLegend   string             `json:"maplegend,omitempty"`
+	IsBank      bool               `json:"isbank,omitempty"`
+	IsStorage   bool               `json:"isstorage,omitempty"`
+	IsPvp       bool               `json:"ispvp,omitempty"`
+	Exits       map[string]apiExit `json:"exits"`
+	PlayerCount int                `json:"playercount"`
+}
+
+type apiHelpTopic struct {
+	Topic    string `json:"topic"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Contents string `json:"contents,omitempty"`
+}
+
+// GET /api/v1/me
+func apiV1Me(w http.ResponseWriter, r *http.Request) {
+
+	client := getApiClient(r)
+
+	writeApiJSON(w, http.StatusOK, apiMe{
+		TokenId:   client.Token.TokenId,
+		Kind:      string(client.Token.Kind),
+		Subject:   client.Token.Subject,
+		Role:      client.Role,
+		ExpiresAt: client.Token.ExpiresAt,
+	})
+}
+
+// GET /api/v1/online
+func apiV1Online(w http.ResponseWriter, r *http.Request) {
+	writeApiJSON(w, http.StatusOK, GetStats().OnlineUsers)
+}
+
+// Loads the user named in the path, if the client may see it.
+// Writes the error response and returns nil otherwise.
+func apiV1PathUser(w http.ResponseWriter, r *http.Request) *users.UserRecord {
+
+	username := r.PathValue(`username`)
+
+	if client := getApiClient(r); !client.IsSelf(username) && !client.Can(apiPermCharacters) {
+		writeApiError(w, http.StatusForbidden, `token lacks permission: `+apiPermCharacters)
+		return nil
+	}
+
+	u := apiLoadUser(username)
+	if u == nil {
+		writeApiError(w, http.StatusNotFound, `user not found`)
+		return nil
+	}
+
+	return u
+}
+
+// GET /api/v1/users/{username}/character
+func apiV1Character(w http.ResponseWriter, r *http.Request) {
+
+	u := apiV1PathUser(w, r)
+	if u == nil {
+		return
+	}
+
+	c := u.Character
+
+	writeApiJSON(w, http.StatusOK, apiCharacter{
+		Username:      u.Username,
+		Name:          c.Name,
+		Description:   c.Description,
+		Race:          c.Race(),
+		Profession:    skills.GetProfession(c.GetAllSkillRanks()),
+		Alignment:     c.AlignmentName(),
+		Level:         c.Level,
+		Experience:    c.Experience,
+		ExperienceTNL: c.XPTNL(),
+		Health:        c.Health,
+		HealthMax:     c.HealthMax.Value,
+		Mana:          c.Mana,
+		ManaMax:       c.ManaMax.Value,
+		Gold:          c.Gold,
+		Bank:          c.Bank,
+		Zone:          c.Zone,
+		RoomId:        c.RoomId,
+		Stats: map[string]int{
+			`strength`:   c.Stats.Strength.ValueAdj,
+			`speed`:      c.Stats.Speed.ValueAdj,
+			`smarts`:     c.Stats.Smarts.ValueAdj,
+			`vitality`:   c.Stats.Vitality.ValueAdj,
+			`mysticism`:  c.Stats.Mysticism.ValueAdj,
+			`perception`: c.Stats.Perception.ValueAdj,
+		},
+		Skills:  c.GetSkills(),
+		Kills:   c.KD.TotalKills,
+		Deaths:  c.KD.TotalDeaths,
+		Online:  users.GetByUserId(u.UserId) != nil,
+		Created: c.Created,
+	})
+}
+
+func newApiItem(itm items.Item) apiItem {
+	return apiItem{
+		ItemId:     itm.ItemId,
+		Name:       itm.Name(),
+		Type:       string(itm.GetSpec().Type),
+		Subtype:    string(itm.GetSpec().Subtype),
+		Uses:       itm.Uses,
+		Cursed:     itm.IsCursed(),
+		Adjectives: itm.Adjectives,
+	}
+}
+
+// GET /api/v1/users/{username}/inventory
+func apiV1Inventory(w http.ResponseWriter, r *http.Request) {
+
+	u := apiV1PathUser(w, r)
+	if u == nil {
+		return
+	}
+
+	inv := apiInventory{
+		Gold:      u.Character.Gold,
+		Backpack:  []apiItem{},
+		Equipment: map[string]apiItem{},
+	}
+
+	for _, itm := range u.Character.GetAllBackpackItems() {
+		inv.Backpack = append(inv.Backpack, newApiItem(itm))
+	}
+
+	worn := u.Character.Equipment
+	for slot, itm := range map[string]items.Item{
+		`weapon`: worn.Weapon, `offhand`: worn.Offhand, `head`: worn.Head, `neck`: worn.Neck, `body`: worn.Body,
+		`belt`: worn.Belt, `gloves`: worn.Gloves, `ring`: worn.Ring, `legs`: worn.Legs, `feet`: worn.Feet,
+	} {
+		if itm.ItemId > 0 {
+			inv.Equipment[slot] = newApiItem(itm)
+		}
+	}
+
+	writeApiJSON(w, http.StatusOK, inv)
+}
+
+// GET /api/v1/users/{username}/quests
+// Secret quests are left out, the same as the in-game quests command.
+func apiV1Quests(w http.ResponseWriter, r *http.Request) {
+
+	u := apiV1PathUser(w, r)
+	if u == nil {
+		return
+	}
+
+	result := []apiQuest{}
+
+	for questId, questStep := range u.Character.GetQuestProgress() {
+
+		questInfo := quests.GetQuest(quests.PartsToToken(questId, questStep))
+		if questInfo == nil || questInfo.Secret {
+			continue
+		}
+
+		q := apiQuest{
+			QuestId:     questInfo.QuestId,
+			Name:        questInfo.Name,
+			Description: questInfo.Description,
+			Step:        questStep,
+			TotalSteps:  len(questInfo.Steps),
+		}
+
+		for i, step := range questInfo.Steps {
+			if step.Id == questStep {
+				q.StepNumber = i + 1
+				q.Description = step.Description
+				break
+			}
+		}
+
+		q.Completed = q.StepNumber == q.TotalSteps
+
+		result = append(result, q)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QuestId < result[j].QuestId
+	})
+
+	writeApiJSON(w, http.StatusOK, result)
+}
+
+func newApiZone(zoneName string) (apiZone, bool) {
+
+	rootRoomId, roomCount, err := rooms.ZoneStats(zoneName)
+	if err != nil {
+		return apiZone{}, false
+	}
+
+	z := apiZone{
+		Name:         zoneName,
+		RootRoomId:   rootRoomId,
+		RoomCount:    roomCount,
+		DefaultBiome: rooms.GetZoneBiome(zoneName),
+	}
+
+	if zoneConfig := rooms.GetZoneConfig(zoneName); zoneConfig != nil {
+		z.MinLevel = zoneConfig.MobAutoScale.Minimum
+		z.MaxLevel = zoneConfig.MobAutoScale.Maximum
+	}
+
+	return z, true
+}
+
+// GET /api/v1/zones
+func apiV1Zones(w http.ResponseWriter, r *http.Request) {
+
+	result := []apiZone{}
+
+	zoneNames := rooms.GetAllZoneNames()
+	sort.Strings(zoneNames)
+
+	for _, zoneName := range zoneNames {
+		if z, ok := newApiZone(zoneName); ok {
+			result = append(result, z)
+		}
+	}
+
+	writeApiJSON(w, http.StatusOK, result)
+}
+
+// GET /api/v1/zones/{zone}
+func apiV1Zone(w http.ResponseWriter, r *http.Request) {
+
+	zoneName := r.PathValue(`zone`)
+	for _, name := range rooms.GetAllZoneNames() {
+		if strings.EqualFold(name, zoneName) {
+			zoneName = name
+			break
+		}
+	}
+
+	z, ok := newApiZone(zoneName)
+	if !ok {
+		writeApiError(w, http.StatusNotFound, `zone not found`)
+		return
+	}
+
+	writeApiJSON(w, http.StatusOK, z)
+}
+
+// GET /api/v1/rooms/{roomId}
+// Secret exits are only listed for tokens that can see them in game (admins).
+func apiV1Room(w http.ResponseWriter, r *http.Request) {
+
+	roomId, _ := strconv.Atoi(r.PathValue(`roomId`))
+
+	room := rooms.LoadRoom(roomId)
+	if room == nil {
+		writeApiError(w, http.StatusNotFound, `room not found`)
+		return
+	}
+
+	showSecret := getApiClient(r).Role == users.RoleAdmin
+
+	result := apiRoom{
+		RoomId:      room.RoomId,
+		Zone:        room.Zone,
+		Title:       room.Title,
+		Description: room.Description,
+		Biome:       room.GetBiome().Name,
+		MapSymbol:   room.MapSymbol,
+		MapLegend:   room.MapLegend,
+		IsBank:      room.IsBank,
+		IsStorage:   room.IsStorage,
+		IsPvp:       room.IsPvp(),
+		Exits:       map[string]apiExit{},
+		PlayerCount: len(room.GetPlayers()),
+	}
+
+	for exitName, exitInfo := range room.Exits {
+		if exitInfo.Secret && !showSecret {
+			continue
+		}
+		result.Exits[exitName] = apiExit{
+			RoomId: exitInfo.RoomId,
+			Secret: exitInfo.Secret,
+			Locked: exitInfo.HasLock(),
+		}
+	}
+
+	writeApiJSON(w, http.StatusOK, result)
+}
+
+// GET /api/v1/leaderboards
+// The data comes from the leaderboards module, if it is loaded.
+func apiV1Leaderboards(w http.ResponseWriter, r *http.Request) {
+
+	if webPlugins != nil {
+		if data, ok := webPlugins.ApiRequest(`leaderboards`, r); ok {
+			writeApiJSON(w, http.StatusOK, data)
+			return
+		}
+	}
+
+	writeApiError(w, http.StatusNotFound, `leaderboards are not enabled`)
+}
+
+// Admin only topics are listed for roles that could read them in game
+func apiHelpTopicAllowed(client *apiClient, topic keywords.HelpTopic) bool {
+	return !topic.AdminOnly || client.Can(topic.Command)
+}
+
+// GET /api/v1/help
+func apiV1Help(w http.ResponseWriter, r *http.Request) {
+
+	client := getApiClient(r)
+	result := []apiHelpTopic{}
+
+	for _, topic := range keywords.GetAllHelpTopicInfo() {
+		if !apiHelpTopicAllowed(client, topic) {
+			continue
+		}
+		result = append(result, apiHelpTopic{
+			Topic:    topic.Command,
+			Type:     topic.Type,
+			Category: topic.Category,
+		})
+	}
+
+	writeApiJSON(w, http.StatusOK, result)
+}
+
+// GET /api/v1/help/{topic}
+// Contents are plain text, with color tags removed.
+func apiV1HelpTopic(w http.ResponseWriter, r *http.Request) {
+
+	client := getApiClient(r)
+	search := strings.TrimSpace(r.PathValue(`topic`))
+	searchName := keywords.TryHelpAlias(strings.ToLower(search))
+
+	for _, topic := range keywords.GetAllHelpTopicInfo() {
+
+		if topic.Command != searchName || !apiHelpTopicAllowed(client, topic) {
+			continue
+		}
+
+		// Admin commands keep their help with the command
+		var contents string
+		var err error
+		if topic.AdminOnly {
+			contents, err = templates.Process(`admincommands/help/command.`+topic.Command, nil, 0)
+		} else {
+			contents, err = usercommands.GetHelpContents(search)
+		}
+		if err != nil {
+			break
+		}
+
+		writeApiJSON(w, http.StatusOK, apiHelpTopic{
+			Topic:    topic.Command,
+			Type:     topic.Type,
+			Category: topic.Category,
+			Contents: ansitags.Parse(contents, ansitags.StripTags),
+		})
+		return
+	}
+
+	writeApiError(w, http.StatusNotFound, `help topic not found`)
+}
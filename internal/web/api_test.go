@@ -0,0 +1,114 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/apitokens"
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func TestRateLimiter(t *testing.T) {
+	rl := newRateLimiter()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// The full limit is available as a burst
+	for i := 0; i < 3; i++ {
+		if ok, remaining, _ := rl.Allow(`a`, 3, now); !ok || remaining != 2-i {
+			t.Fatalf("request %d: ok=%v remaining=%d", i+1, ok, remaining)
+		}
+	}
+
+	ok, _, retryAfter := rl.Allow(`a`, 3, now)
+	if ok || retryAfter != 20*time.Second {
+		t.Errorf("over limit: ok=%v retryAfter=%v; want false, 20s", ok, retryAfter)
+	}
+
+	// Other keys have their own bucket
+	if ok, _, _ := rl.Allow(`b`, 3, now); !ok {
+		t.Error("second key was limited")
+	}
+
+	// 3 per minute refills one every 20 seconds
+	if ok, _, _ := rl.Allow(`a`, 3, now.Add(20*time.Second)); !ok {
+		t.Error("bucket did not refill")
+	}
+	if ok, _, _ := rl.Allow(`a`, 3, now.Add(20*time.Second)); ok {
+		t.Error("bucket refilled too much")
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		wantOk bool
+	}{
+		{`Bearer abc123`, `abc123`, true},
+		{`bearer  abc123 `, `abc123`, true},
+		{`Basic YWRtaW46cGFzcw==`, ``, false},
+		{`Bearer `, ``, false},
+		{``, ``, false},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(`GET`, `/api/v1/me`, nil)
+		r.Header.Set(`Authorization`, tt.header)
+
+		got, ok := bearerToken(r)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("bearerToken(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	datafilestest.TempDir(t)
+	apitokens.LoadTokens()
+
+	_, adminSecret, _ := apitokens.Create(apitokens.KindRole, `admin`, ``, `test`, 0)
+	_, userSecret, _ := apitokens.Create(apitokens.KindRole, `user`, ``, `test`, 0)
+
+	handler := doTokenAuth(requireApiPermission(apiPermRooms, func(w http.ResponseWriter, r *http.Request) {
+		writeApiJSON(w, http.StatusOK, getApiClient(r).Role)
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{`no token`, ``, http.StatusUnauthorized},
+		{`bad token`, `Bearer gmapi_nope`, http.StatusUnauthorized},
+		{`no permission`, `Bearer ` + userSecret, http.StatusForbidden},
+		{`admin`, `Bearer ` + adminSecret, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(`GET`, `/api/v1/rooms/1`, nil)
+			if tt.header != `` {
+				r.Header.Set(`Authorization`, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d; want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+			if ct := w.Header().Get(`Content-Type`); ct != `application/json` {
+				t.Errorf("Content-Type = %q", ct)
+			}
+		})
+	}
+}
@@ -1,78 +1,524 @@
 package web
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
+//
+// Logins for the web admin.
+// The login form checks the in-game username and password, then sets a signed cookie pointing at a session kept in memory.
+// Restarting the server logs everyone out.
+// Every admin page needs a permission from the user's current role, e.g. "web.items.view" to look and "web.items.edit" to change.
+//
+
+const (
+	adminCookieName = `gomud_admin`
+	adminCSRFHeader = `X-CSRF-Token`
+	adminCSRFField  = `csrf_token`
+
+	// Login attempts allowed per minute from one address
+	adminLoginRateLimit = 10
+
+	// How long a session trusts the role it has, before checking the user record again
+	adminRoleCheckInterval = time.Minute
+)
+
+// Permission ids for the admin pages.
+// Viewing needs the id + ".view", saving and deleting need the id + ".edit".
+// Roles are prefix matched, so "web.items" allows both and "web" allows every page.
+const (
+	webPermItems    = `web.items`
+	webPermRaces    = `web.races`
+	webPermMobs     = `web.mobs`
+	webPermMutators = `web.mutators`
+	webPermRooms    = `web.rooms`
+	webPermBans     = `web.bans`
+	webPermAudit    = `web.audit`
+)
+
+type adminSessionKey struct{}
+
+// Who is logged in to the web admin
+type adminSession struct {
+	Id          string
+	UserId      int
+	Username    string
+	CSRFToken   string
+	Expires     time.Time
+	Role        string    // The user's role, checked every adminRoleCheckInterval
+	RoleChecked time.Time // When Role was last read from the user record
+}
+
+// Checks a permission such as "web.items.edit" against the Roles config
+func (s adminSession) Can(permissionId string) bool {
+	return users.RoleHasPermission(s.Role, permissionId)
+}
+
+func getAdminSession(r *http.Request) (adminSession, bool) {
+	s, ok := r.Context().Value(adminSessionKey{}).(adminSession)
+	return s, ok
+}
+
 var (
-	authCache = map[string]time.Time{}
+	adminSessions = newSessionStore()
+	loginLimiter  = newRateLimiter()
 )
 
-func handlerToHandlerFunc(h http.Handler) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		h.ServeHTTP(w, r)
+type sessionStore struct {
+	lock     sync.Mutex
+	key      []byte
+	sessions map[string]adminSession
+}
+
+func newSessionStore() *sessionStore {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
 	}
+	return &sessionStore{key: key, sessions: map[string]adminSession{}}
 }
 
-func doBasicAuth(next http.HandlerFunc) http.HandlerFunc {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func randomHex(byteCt int) string {
+	b := make([]byte, byteCt)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
 
-		authHeader := r.Header.Get("Authorization")
+func (ss *sessionStore) sign(value string) string {
+	mac := hmac.New(sha256.New, ss.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
 
-		if t, ok := authCache[authHeader]; ok {
+// Starts a session and returns it along with the cookie value that refers to it.
+// The cookie value is "<session id>.<expires unix time>.<signature>"
+func (ss *sessionStore) Create(userId int, username string, role string, length time.Duration, now time.Time) (adminSession, string) {
 
-			if t.After(time.Now()) {
-				next.ServeHTTP(w, r)
-				return
-			}
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	// Forget expired sessions while we're here
+	for id, s := range ss.sessions {
+		if !now.Before(s.Expires) {
+			delete(ss.sessions, id)
+		}
+	}
+
+	s := adminSession{
+		Id:          randomHex(16),
+		UserId:      userId,
+		Username:    username,
+		CSRFToken:   randomHex(16),
+		Expires:     now.Add(length).Truncate(time.Second),
+		Role:        role,
+		RoleChecked: now,
+	}
+	ss.sessions[s.Id] = s
+
+	value := s.Id + `.` + strconv.FormatInt(s.Expires.Unix(), 10)
+
+	return s, value + `.` + ss.sign(value)
+}
+
+// Finds the session a cookie value refers to.
+// Fails if the signature is wrong, the session has expired or it was logged out.
+func (ss *sessionStore) Get(cookieValue string, now time.Time) (adminSession, bool) {
+
+	lastDot := strings.LastIndex(cookieValue, `.`)
+	if lastDot < 0 {
+		return adminSession{}, false
+	}
+
+	value, signature := cookieValue[:lastDot], cookieValue[lastDot+1:]
+	if !hmac.Equal([]byte(signature), []byte(ss.sign(value))) {
+		return adminSession{}, false
+	}
+
+	id, expiresStr, _ := strings.Cut(value, `.`)
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil || now.Unix() >= expires {
+		return adminSession{}, false
+	}
+
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	s, ok := ss.sessions[id]
+	if !ok || !now.Before(s.Expires) {
+		delete(ss.sessions, id)
+		return adminSession{}, false
+	}
+
+	return s, true
+}
+
+// Records a freshly checked role for a session
+func (ss *sessionStore) SetRole(id string, role string, now time.Time) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	if s, ok := ss.sessions[id]; ok {
+		s.Role = role
+		s.RoleChecked = now
+		ss.sessions[id] = s
+	}
+}
+
+func (ss *sessionStore) Delete(id string) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	delete(ss.sessions, id)
+}
+
+// Whether a role can use any part of the web admin
+func canUseWebAdmin(role string) bool {
+	if role == users.RoleAdmin {
+		return true
+	}
+	if role == users.RoleUser {
+		return false
+	}
+	for _, permissionId := range configs.GetRolesConfig()[role] {
+		if permissionId == `web` || strings.HasPrefix(permissionId, `web.`) {
+			return true
+		}
+	}
+	return false
+}
+
+// The permission a request needs for a page, e.g. "web.items" becomes "web.items.view" for a GET
+func adminPermission(permissionId string, method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return permissionId + `.view`
+	}
+	return permissionId + `.edit`
+}
+
+// Prefers the online record so that a login never saves over a player's unsaved progress
+func loadAdminUser(username string) (*users.UserRecord, error) {
+
+	for _, u := range users.GetAllActiveUsers() {
+		if strings.EqualFold(u.Username, username) {
+			return u, nil
+		}
+	}
+
+	return users.LoadUser(username, true)
+}
+
+// Refuses requests posted from another site.
+// Browsers send Origin with every POST and DELETE, so if it's there it must match.
+func sameOrigin(r *http.Request) bool {
+
+	origin := r.Header.Get(`Origin`)
+	if origin == `` {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// Mutating requests must send the session's token in the X-CSRF-Token header (htmx) or a csrf_token form field
+func checkCSRF(r *http.Request, s adminSession) bool {
+
+	if !sameOrigin(r) {
+		return false
+	}
+
+	token := r.Header.Get(adminCSRFHeader)
+	if token == `` {
+		token = r.PostFormValue(adminCSRFField)
+	}
+
+	return token != `` && subtle.ConstantTimeCompare([]byte(token), []byte(s.CSRFToken)) == 1
+}
+
+// Only redirect back to admin pages after logging in
+func safeAdminPath(next string) string {
+	if !strings.HasPrefix(next, `/admin/`) || strings.HasPrefix(next, `/admin/login`) || strings.ContainsAny(next, "\\\r\n") || strings.Contains(next, `//`) {
+		return `/admin/`
+	}
+	return next
+}
+
+func setAdminCookie(w http.ResponseWriter, r *http.Request, value string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     adminCookieName,
+		Value:    value,
+		Path:     `/admin/`,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Finds the logged in user for a request, with their role as of the last check.
+// Sessions of users who were deleted or banned since logging in are ended.
+// Takes the MUD read lock now and then to check the role, so it must not be called with the MUD lock held.
+func currentAdminSession(r *http.Request) (adminSession, bool) {
 
-			delete(authCache, authHeader)
+	cookie, err := r.Cookie(adminCookieName)
+	if err != nil {
+		return adminSession{}, false
+	}
+
+	now := time.Now()
+
+	s, ok := adminSessions.Get(cookie.Value, now)
+	if !ok {
+		return adminSession{}, false
+	}
+
+	if now.Sub(s.RoleChecked) >= adminRoleCheckInterval {
+		role, err := lookupRole(s.UserId, s.Username)
+		if err != nil {
+			adminSessions.Delete(s.Id)
+			return adminSession{}, false
 		}
+		s.Role, s.RoleChecked = role, now
+		adminSessions.SetRole(s.Id, role, now)
+	}
 
-		// Extract the username and password from the request
-		// Authorization header. If no Authentication header is present
-		// or the header value is invalid, then the 'ok' return value
-		// will be false.
-		username, password, ok := r.BasicAuth()
-		if ok {
+	if _, banned := bans.CheckAccount(s.Username); banned {
+		adminSessions.Delete(s.Id)
+		return adminSession{}, false
+	}
 
-			// Authorize against actual user record
-			uRecord, err := users.LoadUser(username, true)
-			if err == nil {
+	return s, true
+}
 
-				if uRecord.PasswordMatches(password) {
+// Reads a user's role from their online record under the MUD read lock,
+// or from their file with no lock held if they're offline.
+func lookupRole(userId int, username string) (string, error) {
 
-					if uRecord.Role != users.RoleUser {
+	util.RLockMud()
+	role, online := ``, false
+	if u := users.GetByUserId(userId); u != nil {
+		role, online = u.Role, true
+	}
+	util.RUnlockMud()
+
+	if online {
+		return role, nil
+	}
 
-						mudlog.Warn("ADMIN LOGIN", "username", username, "success", true)
+	uRecord, err := users.LoadUser(username, true)
+	if err != nil {
+		return ``, err
+	}
 
-						// Cache auth for 30 minutes to avoid re-auth every load
-						authCache[authHeader] = time.Now().Add(time.Minute * 30)
+	return uRecord.Role, nil
+}
 
-						next.ServeHTTP(w, r)
-						return
+// Wraps an admin handler.
+// Sends visitors who aren't logged in to the login form, checks the CSRF token of anything but a GET,
+// and checks the permission for the page. An empty permissionId allows anyone who can use the web admin.
+func doAdminAuth(permissionId string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-					} else {
+		s, ok := currentAdminSession(r)
+		if !ok {
+			// Full page loads go to the login form. htmx requests can't follow it, so they just get a 401.
+			if r.Method == http.MethodGet && r.Header.Get(`HX-Request`) == `` {
+				http.Redirect(w, r, `/admin/login?next=`+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+				return
+			}
+			http.Error(w, "Unauthorized: please log in again", http.StatusUnauthorized)
+			return
+		}
 
-						mudlog.Error("ADMIN LOGIN", "username", username, "success", false, "error", `Role=`+uRecord.Role)
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !checkCSRF(r, s) {
+			mudlog.Warn("Web Admin", "user", s.Username, "ip", clientIP(r), "path", r.URL.Path, "error", "bad CSRF token")
+			http.Error(w, "Forbidden: invalid CSRF token, reload the page and try again", http.StatusForbidden)
+			return
+		}
 
-					}
-				}
+		if !canUseWebAdmin(s.Role) {
+			http.Error(w, "Forbidden: your role has no web admin access", http.StatusForbidden)
+			return
+		}
 
-			} else {
-				mudlog.Error("ADMIN LOGIN", "username", username, "success", false, "error", err)
+		if permissionId != `` {
+			if needs := adminPermission(permissionId, r.Method); !s.Can(needs) {
+				http.Error(w, "Forbidden: needs the "+needs+" permission", http.StatusForbidden)
+				return
 			}
 		}
 
-		// If the Authentication header is not present, is invalid, or the
-		// username or password is wrong, then set a WWW-Authenticate
-		// header to inform the client that we expect them to use basic
-		// authentication and send a 401 Unauthorized response.
-		w.Header().Set("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adminSessionKey{}, s)))
 	})
 }
+
+// The admin template functions, plus some that depend on who is logged in:
+//
+//	{{ if can "web.items.edit" }}  {{ csrfToken }}  {{ adminUser }}  {{ adminRole }}
+func adminFuncMap(r *http.Request) template.FuncMap {
+
+	s, _ := getAdminSession(r)
+
+	fMap := template.FuncMap{}
+	for name, fn := range funcMap {
+		fMap[name] = fn
+	}
+
+	fMap["can"] = s.Can
+	fMap["csrfToken"] = func() string { return s.CSRFToken }
+	fMap["adminUser"] = func() string { return s.Username }
+	fMap["adminRole"] = func() string { return s.Role }
+
+	return fMap
+}
+
+type adminLoginData struct {
+	Username string
+	Next     string
+	Error    string
+}
+
+func renderAdminLogin(w http.ResponseWriter, status int, data adminLoginData) {
+
+	tmpl, err := template.New("login.html").Funcs(funcMap).ParseFiles(configs.GetFilePathsConfig().AdminHtml.String() + "/login.html")
+	if err != nil {
+		mudlog.Error("HTML ERROR", "error", err)
+		http.Error(w, "Error parsing template", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+
+	if err := tmpl.Execute(w, data); err != nil {
+		mudlog.Error("HTML ERROR", "action", "Execute", "error", err)
+	}
+}
+
+func adminLoginPage(w http.ResponseWriter, r *http.Request) {
+
+	next := safeAdminPath(r.URL.Query().Get(`next`))
+
+	if _, ok := currentAdminSession(r); ok {
+		http.Redirect(w, r, next, http.StatusSeeOther)
+		return
+	}
+
+	renderAdminLogin(w, http.StatusOK, adminLoginData{Next: next})
+}
+
+func adminLogin(w http.ResponseWriter, r *http.Request) {
+
+	data := adminLoginData{
+		Username: strings.TrimSpace(r.PostFormValue(`username`)),
+		Next:     safeAdminPath(r.PostFormValue(`next`)),
+	}
+
+	if !sameOrigin(r) {
+		data.Error = `Login refused.`
+		renderAdminLogin(w, http.StatusForbidden, data)
+		return
+	}
+
+	// Every attempt counts, so a correct password can't be found by guessing faster
+	if ok, _, retryAfter := loginLimiter.Allow(clientIP(r), adminLoginRateLimit, time.Now()); !ok {
+		mudlog.Error("ADMIN LOGIN", "username", data.Username, "ip", clientIP(r), "success", false, "error", "too many attempts")
+		w.Header().Set(`Retry-After`, strconv.Itoa(int(retryAfter.Seconds())+1))
+		data.Error = `Too many failed logins. Try again in a minute.`
+		renderAdminLogin(w, http.StatusTooManyRequests, data)
+		return
+	}
+
+	fail := func(reason any) {
+		mudlog.Error("ADMIN LOGIN", "username", data.Username, "ip", clientIP(r), "success", false, "error", reason)
+		data.Error = `Invalid username or password.`
+		renderAdminLogin(w, http.StatusUnauthorized, data)
+	}
+
+	// Only the lookup needs the MUD lock. Checking the password is slow on purpose,
+	// and holding the lock through it would let anyone stall the game by trying to log in.
+	util.RLockMud()
+	var uRecord users.UserRecord
+	found, err := loadAdminUser(data.Username)
+	if err == nil {
+		uRecord = *found
+	}
+	util.RUnlockMud()
+
+	if err != nil {
+		users.CheckNoPassword(r.PostFormValue(`password`))
+		fail(err)
+		return
+	}
+
+	// Also hashes an outdated password, so only storing it needs the lock
+	ok, newHash := uRecord.CheckPassword(r.PostFormValue(`password`))
+	if !ok {
+		fail(`bad password`)
+		return
+	}
+
+	if newHash != `` {
+		util.LockMud()
+		uRecord.UpgradePassword(newHash)
+		util.UnlockMud()
+	}
+
+	if _, banned := bans.CheckAccount(uRecord.Username); banned {
+		fail(`banned`)
+		return
+	}
+
+	if !canUseWebAdmin(uRecord.Role) {
+		fail(`Role=` + uRecord.Role)
+		return
+	}
+
+	sessionLength := time.Duration(configs.GetNetworkConfig().AdminSessionMinutes) * time.Minute
+	s, cookieValue := adminSessions.Create(uRecord.UserId, uRecord.Username, uRecord.Role, sessionLength, time.Now())
+
+	mudlog.Warn("ADMIN LOGIN", "username", uRecord.Username, "ip", clientIP(r), "role", uRecord.Role, "success", true)
+
+	setAdminCookie(w, r, cookieValue, s.Expires)
+	http.Redirect(w, r, data.Next, http.StatusSeeOther)
+}
+
+func adminLogout(w http.ResponseWriter, r *http.Request) {
+
+	if s, ok := getAdminSession(r); ok {
+		adminSessions.Delete(s.Id)
+		mudlog.Info("ADMIN LOGOUT", "username", s.Username)
+	}
+
+	setAdminCookie(w, r, ``, time.Unix(1, 0))
+	http.Redirect(w, r, `/admin/login`, http.StatusSeeOther)
+}
+
+func handlerToHandlerFunc(h http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+func TestSessionStore(t *testing.T) {
+	ss := newSessionStore()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s, cookieValue := ss.Create(1, `admin`, users.RoleAdmin, time.Hour, now)
+
+	got, ok := ss.Get(cookieValue, now.Add(time.Minute))
+	if !ok || got.Id != s.Id || got.Username != `admin` || got.CSRFToken == `` || got.Role != users.RoleAdmin {
+		t.Fatalf("Get() = %+v, %v", got, ok)
+	}
+
+	ss.SetRole(s.Id, users.RoleUser, now.Add(time.Minute))
+	if got, _ := ss.Get(cookieValue, now.Add(time.Minute)); got.Role != users.RoleUser || !got.RoleChecked.Equal(now.Add(time.Minute)) {
+		t.Errorf("after SetRole() Role = %q, RoleChecked = %v", got.Role, got.RoleChecked)
+	}
+
+	if _, ok := ss.Get(cookieValue, now.Add(time.Hour)); ok {
+		t.Error("session did not expire")
+	}
+
+	// Pushing the expiry out breaks the signature
+	id, rest, _ := strings.Cut(cookieValue, `.`)
+	_, signature, _ := strings.Cut(rest, `.`)
+	tampered := id + `.` + "9999999999" + `.` + signature
+	if _, ok := ss.Get(tampered, now); ok {
+		t.Error("tampered cookie was accepted")
+	}
+
+	// Cookies signed by another server (or before a restart) don't work
+	if _, ok := newSessionStore().Get(cookieValue, now); ok {
+		t.Error("cookie from another store was accepted")
+	}
+
+	ss.Delete(s.Id)
+	if _, ok := ss.Get(cookieValue, now); ok {
+		t.Error("logged out session still works")
+	}
+}
+
+func TestCheckCSRF(t *testing.T) {
+	s := adminSession{CSRFToken: `abc123`}
+
+	tests := []struct {
+		name   string
+		header string
+		form   string
+		origin string
+		want   bool
+	}{
+		{`header`, `abc123`, ``, ``, true},
+		{`form field`, ``, `abc123`, ``, true},
+		{`same origin`, `abc123`, ``, `http://example.com`, true},
+		{`missing`, ``, ``, ``, false},
+		{`wrong`, `abc124`, ``, ``, false},
+		{`other origin`, `abc123`, ``, `http://evil.example`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := url.Values{}
+			if tt.form != `` {
+				body.Set(adminCSRFField, tt.form)
+			}
+			r := httptest.NewRequest(`POST`, `http://example.com/admin/items/itemdata/`, strings.NewReader(body.Encode()))
+			r.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+			if tt.header != `` {
+				r.Header.Set(adminCSRFHeader, tt.header)
+			}
+			if tt.origin != `` {
+				r.Header.Set(`Origin`, tt.origin)
+			}
+
+			if got := checkCSRF(r, s); got != tt.want {
+				t.Errorf("checkCSRF() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeAdminPath(t *testing.T) {
+	tests := map[string]string{
+		`/admin/items/?itemid=1`:    `/admin/items/?itemid=1`,
+		``:                          `/admin/`,
+		`https://evil.example/`:     `/admin/`,
+		`//evil.example/admin/`:     `/admin/`,
+		`/admin//evil.example`:      `/admin/`,
+		`/admin/\evil.example`:      `/admin/`,
+		`/admin/login?next=/admin/`: `/admin/`,
+	}
+
+	for next, want := range tests {
+		if got := safeAdminPath(next); got != want {
+			t.Errorf("safeAdminPath(%q) = %q; want %q", next, got, want)
+		}
+	}
+}
+
+func TestAdminPermission(t *testing.T) {
+	if got := adminPermission(webPermItems, http.MethodGet); got != `web.items.view` {
+		t.Errorf("GET = %q", got)
+	}
+	if got := adminPermission(webPermItems, http.MethodDelete); got != `web.items.edit` {
+		t.Errorf("DELETE = %q", got)
+	}
+}
+
+func TestAdminAuthNoSession(t *testing.T) {
+	handler := doAdminAuth(webPermItems, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler ran without a session")
+	})
+
+	// Page loads are sent to the login form
+	r := httptest.NewRequest(`GET`, `/admin/items/?itemid=5`, nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+	if w.Code != http.StatusSeeOther || w.Header().Get(`Location`) != `/admin/login?next=`+url.QueryEscape(`/admin/items/?itemid=5`) {
+		t.Errorf("GET: status %d, location %q", w.Code, w.Header().Get(`Location`))
+	}
+
+	// htmx requests and edits just get a 401
+	r = httptest.NewRequest(`GET`, `/admin/items/itemdata/`, nil)
+	r.Header.Set(`HX-Request`, `true`)
+	w = httptest.NewRecorder()
+	handler(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("htmx GET: status %d", w.Code)
+	}
+
+	r = httptest.NewRequest(`POST`, `/admin/items/itemdata/`, nil)
+	r.AddCookie(&http.Cookie{Name: adminCookieName, Value: `forged.9999999999.abcdef`})
+	w = httptest.NewRecorder()
+	handler(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("POST with forged cookie: status %d", w.Code)
+	}
+}
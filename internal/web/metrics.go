@@ -0,0 +1,43 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/metrics"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+// Serves Prometheus metrics when Network.MetricsEnabled is on, otherwise it's a 404 like any unknown page.
+// If Network.MetricsToken is set it must be sent as a bearer token.
+// Only holds the MUD read lock while the collectors run, and not at all for refused requests.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+
+	networkConfig := configs.GetNetworkConfig()
+
+	if !networkConfig.MetricsEnabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	if want := configs.GetSecret(networkConfig.MetricsToken); want != `` {
+		got, _ := bearerToken(r)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			w.Header().Set(`WWW-Authenticate`, `Bearer realm="metrics"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	w.Header().Set(`Content-Type`, `text/plain; version=0.0.4; charset=utf-8`)
+
+	util.RLockMud()
+	gathered := metrics.Gather()
+	util.RUnlockMud()
+
+	if err := metrics.Write(w, gathered); err != nil {
+		mudlog.Error("Metrics", "error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+)
+
+func TestMetricsHandler(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		enabled bool
+		token   string
+		header  string
+		want    int
+	}{
+		{`disabled`, false, ``, ``, http.StatusNotFound},
+		{`enabled`, true, ``, ``, http.StatusOK},
+		{`missing token`, true, `s3cret`, ``, http.StatusUnauthorized},
+		{`wrong token`, true, `s3cret`, `Bearer nope`, http.StatusUnauthorized},
+		{`token`, true, `s3cret`, `Bearer s3cret`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(configs.Snapshot())
+			if err := configs.AddOverlayOverrides(map[string]any{
+				`Network.MetricsEnabled`: tt.enabled,
+				`Network.MetricsToken`:   tt.token,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			r := httptest.NewRequest(`GET`, `/metrics`, nil)
+			if tt.header != `` {
+				r.Header.Set(`Authorization`, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			metricsHandler(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d; want %d", w.Code, tt.want)
+			}
+			if w.Code == http.StatusOK && !strings.Contains(w.Body.String(), "# TYPE gomud_go_goroutines gauge\n") {
+				t.Errorf("body is missing metrics:\n%s", w.Body.String())
+			}
+		})
+	}
+}
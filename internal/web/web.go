@@ -10,12 +10,14 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/gorilla/websocket"
@@ -45,12 +47,66 @@ type WebNav struct {
 type WebPlugin interface {
 	NavLinks() map[string]string                                                    // Name=>Path pairs
 	WebRequest(r *http.Request) (html string, templateData map[string]any, ok bool) // Get the first handler of a given request
+	ApiRequest(name string, r *http.Request) (data any, ok bool)                    // Get data a module exposes to the JSON api
 }
 
 func SetWebPlugin(wp WebPlugin) {
 	webPlugins = wp
 }
 
+// Returns the real client address of a request, and whether it came from X-Forwarded-For.
+// The header is only honored when the request comes directly from one of the TrustedProxies.
+func clientAddr(r *http.Request) (addr net.Addr, forwarded bool) {
+
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peerIP := net.ParseIP(host)
+	peerPort, _ := strconv.Atoi(port)
+	peerAddr := &net.TCPAddr{IP: peerIP, Port: peerPort}
+
+	trusted := []string(configs.GetNetworkConfig().TrustedProxies)
+	if !connections.IsTrustedProxyIP(peerIP, trusted) {
+		return peerAddr, false
+	}
+
+	hops := []string{}
+	for _, header := range r.Header.Values(`X-Forwarded-For`) {
+		for _, hop := range strings.Split(header, `,`) {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	// Walk back from the nearest hop, skipping our own proxies.
+	// The first address we don't trust is the client.
+	var clientIP net.IP
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(hops[i])
+		if ip == nil {
+			break
+		}
+		clientIP = ip
+		if !connections.IsTrustedProxyIP(ip, trusted) {
+			break
+		}
+	}
+
+	if clientIP == nil {
+		return peerAddr, false
+	}
+
+	// X-Forwarded-For doesn't carry the client port
+	return &net.TCPAddr{IP: clientIP}, true
+}
+
+// Returns the real client IP of a request for logging
+func clientIP(r *http.Request) string {
+	addr, _ := clientAddr(r)
+	return addr.(*net.TCPAddr).IP.String()
+}
+
 // serveTemplate searches for the requested file in the HTTP_ROOT,
 // parses it as a template, and serves it.
 func serveTemplate(w http.ResponseWriter, r *http.Request) {
@@ -106,7 +162,7 @@ func serveTemplate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !pageFound || len(fileBase) > 0 && fileBase[0] == '_' {
-		mudlog.Info("Web", "ip", r.RemoteAddr, "ref", r.Header.Get("Referer"), "file path", fullPath, "file extension", fileExt, "error", "Not found")
+		mudlog.Info("Web", "ip", clientIP(r), "ref", r.Header.Get("Referer"), "file path", fullPath, "file extension", fileExt, "error", "Not found")
 
 		fullPath = filepath.Join(httpRoot, `404.html`)
 		fInfo, err = os.Stat(fullPath)
@@ -122,7 +178,7 @@ func serveTemplate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log the request
-	mudlog.Info("Web", "ip", r.RemoteAddr, "ref", r.Header.Get("Referer"), "file path", fullPath, "file extension", fileExt, "file source", source, "size", fmt.Sprintf(`%.2fk`, float64(fSize)/1024))
+	mudlog.Info("Web", "ip", clientIP(r), "ref", r.Header.Get("Referer"), "file path", fullPath, "file extension", fileExt, "file source", source, "size", fmt.Sprintf(`%.2fk`, float64(fSize)/1024))
 
 	// For non-HTML files, serve them statically.
 	if fileExt != ".html" {
@@ -227,7 +283,8 @@ func serveTemplate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func Listen(wg *sync.WaitGroup, webSocketHandler func(*websocket.Conn)) {
+// webSocketHandler receives the real client address when the request came through a trusted proxy, otherwise nil.
+func Listen(wg *sync.WaitGroup, webSocketHandler func(*websocket.Conn, net.Addr)) {
 
 	networkConfig := configs.GetNetworkConfig()
 
@@ -256,61 +313,155 @@ func Listen(wg *sync.WaitGroup, webSocketHandler func(*websocket.Conn)) {
 		}
 		defer conn.Close()
 
-		webSocketHandler(conn)
+		var realAddr net.Addr
+		if addr, forwarded := clientAddr(r); forwarded {
+			realAddr = addr
+		}
+
+		webSocketHandler(conn, realAddr)
 	})
 
-	http.Handle("GET /admin/static/", RunWithMUDLocked(
-		doBasicAuth(
-			handlerToHandlerFunc(
-				http.StripPrefix("/admin/static/", http.FileServer(http.Dir(configs.GetFilePathsConfig().AdminHtml.String()+"/static"))),
-			),
+	// Admin auth takes the MUD lock itself, only while it needs it, so it has to
+	// wrap RunWithMUDLocked() and not the other way around.
+	http.Handle("GET /admin/static/", doAdminAuth(``,
+		handlerToHandlerFunc(
+			http.StripPrefix("/admin/static/", http.FileServer(http.Dir(configs.GetFilePathsConfig().AdminHtml.String()+"/static"))),
 		),
 	))
 
+	// Admin login
+	http.HandleFunc("GET /admin/login", adminLoginPage)
+	http.HandleFunc("POST /admin/login", adminLogin)
+	http.HandleFunc("POST /admin/logout", doAdminAuth(``, adminLogout))
+
 	// Admin tools
-	http.HandleFunc("GET /admin/", RunWithMUDLocked(
-		doBasicAuth(adminIndex),
+	http.HandleFunc("GET /admin/", doAdminAuth(``,
+		RunWithMUDLocked(adminIndex),
 	))
 
 	// Item Admin
-	http.HandleFunc("GET /admin/items/", RunWithMUDLocked(
-		doBasicAuth(itemsIndex),
+	http.HandleFunc("GET /admin/items/", doAdminAuth(webPermItems,
+		RunWithMUDLocked(itemsIndex),
 	))
-	http.HandleFunc("GET /admin/items/itemdata/", RunWithMUDLocked(
-		doBasicAuth(itemData),
+	http.HandleFunc("GET /admin/items/itemdata/", doAdminAuth(webPermItems,
+		RunWithMUDLocked(itemData),
+	))
+	http.HandleFunc("POST /admin/items/itemdata/", doAdminAuth(webPermItems,
+		RunWithMUDLocked(itemSave),
+	))
+	// Takes the MUD lock itself, only while it needs it
+	http.HandleFunc("DELETE /admin/items/itemdata/", doAdminAuth(webPermItems,
+		itemDelete,
 	))
 
 	// Race Admin
-	http.HandleFunc("GET /admin/races/", RunWithMUDLocked(
-		doBasicAuth(racesIndex)),
-	)
-	http.HandleFunc("GET /admin/races/racedata/", RunWithMUDLocked(
-		doBasicAuth(raceData)),
-	)
+	http.HandleFunc("GET /admin/races/", doAdminAuth(webPermRaces,
+		RunWithMUDLocked(racesIndex),
+	))
+	http.HandleFunc("GET /admin/races/racedata/", doAdminAuth(webPermRaces,
+		RunWithMUDLocked(raceData),
+	))
+	http.HandleFunc("POST /admin/races/racedata/", doAdminAuth(webPermRaces,
+		RunWithMUDLocked(raceSave),
+	))
+	http.HandleFunc("DELETE /admin/races/racedata/", doAdminAuth(webPermRaces,
+		RunWithMUDLocked(raceDelete),
+	))
 
 	// Mob Admin
-	http.HandleFunc("GET /admin/mobs/", RunWithMUDLocked(
-		doBasicAuth(mobsIndex),
+	http.HandleFunc("GET /admin/mobs/", doAdminAuth(webPermMobs,
+		RunWithMUDLocked(mobsIndex),
+	))
+	http.HandleFunc("GET /admin/mobs/mobdata/", doAdminAuth(webPermMobs,
+		RunWithMUDLocked(mobData),
 	))
-	http.HandleFunc("GET /admin/mobs/mobdata/", RunWithMUDLocked(
-		doBasicAuth(mobData),
+	http.HandleFunc("POST /admin/mobs/mobdata/", doAdminAuth(webPermMobs,
+		RunWithMUDLocked(mobSave),
+	))
+	http.HandleFunc("DELETE /admin/mobs/mobdata/", doAdminAuth(webPermMobs,
+		RunWithMUDLocked(mobDelete),
 	))
 
 	// Mutator Admin
-	http.HandleFunc("GET /admin/mutators/", RunWithMUDLocked(
-		doBasicAuth(mutatorsIndex),
+	http.HandleFunc("GET /admin/mutators/", doAdminAuth(webPermMutators,
+		RunWithMUDLocked(mutatorsIndex),
+	))
+	http.HandleFunc("GET /admin/mutators/mutatordata/", doAdminAuth(webPermMutators,
+		RunWithMUDLocked(mutatorData),
+	))
+	http.HandleFunc("POST /admin/mutators/mutatordata/", doAdminAuth(webPermMutators,
+		RunWithMUDLocked(mutatorSave),
 	))
-	http.HandleFunc("GET /admin/mutators/mutatordata/", RunWithMUDLocked(
-		doBasicAuth(mutatorData),
+	http.HandleFunc("DELETE /admin/mutators/mutatordata/", doAdminAuth(webPermMutators,
+		RunWithMUDLocked(mutatorDelete),
 	))
 
 	// Room Admin
-	http.HandleFunc("GET /admin/rooms/", RunWithMUDLocked(
-		doBasicAuth(roomsIndex),
+	http.HandleFunc("GET /admin/rooms/", doAdminAuth(webPermRooms,
+		RunWithMUDLocked(roomsIndex),
+	))
+	http.HandleFunc("GET /admin/rooms/roomdata/", doAdminAuth(webPermRooms,
+		RunWithMUDLocked(roomData),
+	))
+	http.HandleFunc("POST /admin/rooms/roomdata/", doAdminAuth(webPermRooms,
+		RunWithMUDLocked(roomSave),
+	))
+	http.HandleFunc("DELETE /admin/rooms/roomdata/", doAdminAuth(webPermRooms,
+		RunWithMUDLocked(roomDelete),
+	))
+
+	// Ban Admin (read only)
+	http.HandleFunc("GET /admin/bans/", doAdminAuth(webPermBans,
+		RunWithMUDLocked(bansIndex),
+	))
+
+	// Audit Log (read only)
+	http.HandleFunc("GET /admin/audit/", doAdminAuth(webPermAudit,
+		RunWithMUDLocked(auditIndex),
+	))
+
+	// Prometheus metrics (see Network.MetricsEnabled)
+	// Takes the MUD read lock itself, only while it needs it
+	http.HandleFunc("GET /metrics", metricsHandler)
+
+	// JSON api (bearer token auth, see api.go)
+	// Auth runs before RunWithMUDLocked(), so refused requests never take the lock.
+	http.HandleFunc("GET /api/v1/me", doTokenAuth(
+		RunWithMUDLocked(apiV1Me),
+	))
+	http.HandleFunc("GET /api/v1/online", doTokenAuth(
+		RunWithMUDLocked(apiV1Online),
+	))
+	http.HandleFunc("GET /api/v1/users/{username}/character", doTokenAuth(
+		RunWithMUDLocked(apiV1Character),
+	))
+	http.HandleFunc("GET /api/v1/users/{username}/inventory", doTokenAuth(
+		RunWithMUDLocked(apiV1Inventory),
 	))
-	http.HandleFunc("GET /admin/rooms/roomdata/", RunWithMUDLocked(
-		doBasicAuth(roomData),
+	http.HandleFunc("GET /api/v1/users/{username}/quests", doTokenAuth(
+		RunWithMUDLocked(apiV1Quests),
 	))
+	http.HandleFunc("GET /api/v1/zones", doTokenAuth(
+		RunWithMUDLocked(apiV1Zones),
+	))
+	http.HandleFunc("GET /api/v1/zones/{zone}", doTokenAuth(
+		RunWithMUDLocked(apiV1Zone),
+	))
+	http.HandleFunc("GET /api/v1/rooms/{roomId}", doTokenAuth(requireApiPermission(apiPermRooms,
+		RunWithMUDLocked(apiV1Room),
+	)))
+	http.HandleFunc("GET /api/v1/leaderboards", doTokenAuth(
+		RunWithMUDLocked(apiV1Leaderboards),
+	))
+	http.HandleFunc("GET /api/v1/help", doTokenAuth(
+		RunWithMUDLocked(apiV1Help),
+	))
+	http.HandleFunc("GET /api/v1/help/{topic}", doTokenAuth(
+		RunWithMUDLocked(apiV1HelpTopic),
+	))
+	http.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeApiError(w, http.StatusNotFound, `no such endpoint`)
+	})
 
 	//
 	// Https server start up
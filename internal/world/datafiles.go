@@ -0,0 +1,57 @@
+package world
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/audio"
+	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/colorpatterns"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/mutators"
+	"github.com/GoMudEngine/GoMud/internal/pets"
+	"github.com/GoMudEngine/GoMud/internal/plugins"
+	"github.com/GoMudEngine/GoMud/internal/quests"
+	"github.com/GoMudEngine/GoMud/internal/races"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/spells"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/usercommands"
+)
+
+// Loads (or reloads) every flat data file: rooms, items, mobs, spells and so on
+func LoadAllDataFiles(isReload bool) {
+
+	if isReload {
+
+		defer func() {
+			if r := recover(); r != nil {
+				mudlog.Error("RELOAD FAILED", "err", r)
+			}
+		}()
+
+	}
+
+	// Force clear all cached VM's
+	scripting.PruneVMs(true)
+
+	// Load biomes before rooms since rooms reference biomes
+	rooms.LoadBiomeDataFiles()
+	spells.LoadSpellFiles()
+	rooms.LoadDataFiles()
+	buffs.LoadDataFiles() // Load buffs before items for cost calculation reasons
+	items.LoadDataFiles()
+	races.LoadDataFiles()
+	mobs.LoadDataFiles()
+	pets.LoadDataFiles()
+	quests.LoadDataFiles()
+	templates.LoadAliases(plugins.GetPluginRegistry())
+	keywords.LoadAliases(plugins.GetPluginRegistry())
+	usercommands.LoadScriptCommands() // This should come after loading keywords.
+	mutators.LoadDataFiles()
+	colorpatterns.LoadColorPatterns()
+	audio.LoadAudioConfig()
+	characters.CompileAdjectiveSwaps() // This should come after loading color patterns.
+}
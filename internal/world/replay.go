@@ -0,0 +1,209 @@
+package world
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/journal"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// Replays a journal written with -record.
+// Turns are stepped one after another instead of on a timer, and each entry is fed in on the turn it was recorded.
+// Players get a virtual connection that writes everything they're sent to the output, so runs can be diffed.
+//
+
+// How many rounds to keep going after the last entry, so that delayed commands and combat can finish
+const replayTrailingRounds = 3
+
+// Plays the journal back and writes what players were sent to out
+func (w *World) Replay(h journal.Header, entries []journal.Entry, out io.Writer) error {
+
+	if h.TurnMs < 1 || h.TurnsPerRound < 1 {
+		return errors.New("journal header has no turn timing")
+	}
+
+	output := newReplayOutput(out)
+	defer output.Flush()
+
+	util.SeedRand(h.Seed)
+	util.SetTurnCount(h.Turn)
+	util.SetRoundCount(h.Round)
+
+	lastTurn := h.Turn
+	if len(entries) > 0 {
+		lastTurn = entries[len(entries)-1].Turn
+	}
+	lastTurn += uint64(replayTrailingRounds * h.TurnsPerRound)
+
+	mudlog.Info("Replay", "entries", len(entries), "fromTurn", h.Turn, "toTurn", lastTurn, "seed", h.Seed)
+
+	// Users are matched up by the id they had when recording
+	replayConnections := map[int]connections.ConnectionId{}
+
+	next := 0
+	applyEntries := func(turn uint64) {
+		for ; next < len(entries) && entries[next].Turn <= turn; next++ {
+			if err := w.replayEntry(entries[next], output, replayConnections); err != nil {
+				mudlog.Warn("Replay", "turn", turn, "kind", entries[next].Kind, "userId", entries[next].UserId, "error", err)
+			}
+			w.EventLoop()
+		}
+	}
+
+	// Anything from before the first turn
+	applyEntries(util.GetTurnCount())
+
+	for util.GetTurnCount() < lastTurn {
+
+		// Made up from the turn number, so that the same journal always sees the same times
+		timeNow := h.Started.Add(time.Duration(util.GetTurnCount()+1-h.Turn) * time.Duration(h.TurnMs) * time.Millisecond)
+
+		turnCt := w.NextTurn(h.TurnsPerRound, timeNow)
+		output.SetTurn(turnCt)
+
+		w.EventLoop()
+
+		applyEntries(turnCt)
+
+		// The live event loop runs every millisecond, so give requeued events as many chances as they'd get in a turn
+		for i := 1; i < h.TurnMs; i++ {
+			w.EventLoop()
+		}
+	}
+
+	mudlog.Info("Replay", "status", "done", "turn", util.GetTurnCount(), "round", util.GetRoundCount())
+
+	return nil
+}
+
+func (w *World) replayEntry(e journal.Entry, output *replayOutput, replayConnections map[int]connections.ConnectionId) error {
+
+	switch e.Kind {
+
+	case journal.KindInput:
+		if e.Input == nil {
+			return errors.New("no input")
+		}
+		events.AddToQueue(*e.Input)
+
+	case journal.KindEnter:
+
+		user, err := users.LoadUser(e.Username)
+		if err != nil {
+			return err
+		}
+
+		connDetails := connections.AddVirtual(output.Writer(user.Username))
+
+		if user, _, err = users.LoginUser(user, connDetails.ConnectionId()); err != nil {
+			connections.Remove(connDetails.ConnectionId())
+			return err
+		}
+
+		if user.UserId != e.UserId {
+			mudlog.Warn("Replay", "username", e.Username, "recordedUserId", e.UserId, "userId", user.UserId)
+		}
+
+		connDetails.SetState(connections.LoggedIn)
+		replayConnections[e.UserId] = connDetails.ConnectionId()
+
+		w.EnterWorld(user.UserId, e.RoomId, false)
+
+	case journal.KindLeave:
+		w.LeaveWorld(e.UserId)
+
+	case journal.KindLogout:
+		if connId, ok := replayConnections[e.UserId]; ok {
+			w.logOutUserByConnectionId(connId)
+			connections.Remove(connId)
+			delete(replayConnections, e.UserId)
+		}
+
+	case journal.KindZombie:
+		users.SetZombieUser(e.UserId)
+		if connId, ok := replayConnections[e.UserId]; ok {
+			connections.Remove(connId)
+			delete(replayConnections, e.UserId)
+		}
+
+	default:
+		return fmt.Errorf("unknown kind %q", e.Kind)
+	}
+
+	return nil
+}
+
+// Collects what every replayed player is sent, one line at a time: "[turn 12] bob: You see a sword."
+type replayOutput struct {
+	lock sync.Mutex
+	w    *bufio.Writer
+	turn uint64
+}
+
+// Colors and cursor movement are left out, they'd only get in the way of a diff
+var replayEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+func newReplayOutput(w io.Writer) *replayOutput {
+	return &replayOutput{w: bufio.NewWriter(w)}
+}
+
+func (o *replayOutput) SetTurn(turn uint64) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.turn = turn
+}
+
+func (o *replayOutput) Write(name string, p []byte) {
+
+	// Telnet commands (sound, GMCP and so on) are always sent on their own, and aren't text
+	if len(p) > 0 && p[0] == term.TELNET_IAC {
+		return
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	text := replayEscapeRegex.ReplaceAllString(string(p), ``)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r", ``), "\n") {
+		if strings.TrimSpace(line) == `` {
+			continue
+		}
+		fmt.Fprintf(o.w, "[turn %d] %s: %s\n", o.turn, name, line)
+	}
+}
+
+func (o *replayOutput) Flush() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.w.Flush()
+}
+
+// A writer for one player's connection
+func (o *replayOutput) Writer(name string) io.Writer {
+	return replayWriter{name: name, output: o}
+}
+
+type replayWriter struct {
+	name   string
+	output *replayOutput
+}
+
+func (rw replayWriter) Write(p []byte) (int, error) {
+	rw.output.Write(rw.name, p)
+	return len(p), nil
+}
@@ -1,4 +1,4 @@
-package main
+package world
 
 import (
 	"fmt"
@@ -14,6 +14,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/journal"
 	"github.com/GoMudEngine/GoMud/internal/keywords"
 	"github.com/GoMudEngine/GoMud/internal/mobcommands"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
@@ -27,6 +28,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/GoMudEngine/GoMud/internal/web"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
 )
 
 type WorldInput struct {
@@ -50,6 +52,8 @@ type World struct {
 	eventRequeue          []events.Event
 	userInputEventTracker map[int]struct{}
 	mobInputEventTracker  map[int]struct{}
+	//
+	copyoverFunc func(requestedBy int) error
 }
 
 func NewWorld(osSignalChan chan os.Signal) *World {
@@ -198,13 +202,26 @@ func (w *World) HandleSystemEvents(e events.Event) events.ListenerReturn {
 			Text: `Reloading flat files...`,
 		})
 
-		loadAllDataFiles(true)
+		LoadAllDataFiles(true)
 
 		events.AddToQueue(events.Broadcast{
 			Text:            `Done.` + term.CRLFStr,
 			SkipLineRefresh: true,
 		})
 
+	} else if sys.Command == `copyover` {
+
+		requestedBy, _ := sys.Data.(int)
+
+		if w.copyoverFunc == nil {
+			mudlog.Error("Copyover", "error", "not supported by this server")
+		} else if err := w.copyoverFunc(requestedBy); err != nil {
+			mudlog.Error("Copyover", "error", err)
+			if user := users.GetByUserId(requestedBy); user != nil {
+				user.SendText(fmt.Sprintf(`<ansi fg="red-bold">Copyover failed:</ansi> %s`, err))
+			}
+		}
+
 	} else if sys.Command == `kick` {
 		w.Kick(sys.Data.(int), sys.Description)
 	} else if sys.Command == `leaveworld` {
@@ -240,6 +257,11 @@ func (w *World) HandleSystemEvents(e events.Event) events.ListenerReturn {
 	return events.Continue
 }
 
+// Sets what runs for the copyover command. It's called with the mud locked, and only returns if the copyover failed.
+func (w *World) SetCopyoverFunc(f func(requestedBy int) error) {
+	w.copyoverFunc = f
+}
+
 // Send input to the world.
 // Just sends via a channel. Will block until read.
 func (w *World) SendInput(i WorldInput) {
@@ -273,7 +295,8 @@ func (w *World) logOutUserByConnectionId(connectionId connections.ConnectionId)
 	}
 }
 
-func (w *World) enterWorld(userId int, roomId int) {
+// isCopyover is true for users being put back after a copyover, rather than logging in
+func (w *World) EnterWorld(userId int, roomId int, isCopyover bool) {
 
 	if userInfo := users.GetByUserId(userId); userInfo != nil {
 		events.AddToQueue(events.PlayerSpawn{
@@ -282,6 +305,7 @@ func (w *World) enterWorld(userId int, roomId int) {
 			RoomId:        userInfo.Character.RoomId,
 			Username:      userInfo.Username,
 			CharacterName: userInfo.Character.Name,
+			Copyover:      isCopyover,
 		})
 	}
 
@@ -291,6 +315,19 @@ func (w *World) enterWorld(userId int, roomId int) {
 	rooms.MoveToRoom(userId, roomId, true)
 }
 
+func (w *World) LeaveWorld(userId int) {
+
+	if userInfo := users.GetByUserId(userId); userInfo != nil {
+		events.AddToQueue(events.PlayerDespawn{
+			UserId:        userInfo.UserId,
+			RoomId:        userInfo.Character.RoomId,
+			Username:      userInfo.Username,
+			CharacterName: userInfo.Character.Name,
+			TimeOnline:    userInfo.GetOnlineInfo().OnlineTimeStr,
+		})
+	}
+}
+
 /*
 users can be:
 Disconnected	+ OutWorld (no presence)	No record in connections.netConnections or users.ZombieConnections	| user object in room
@@ -746,6 +783,7 @@ loop:
 				mudlog.Error("rooms.SaveAllRooms()", "error", err.Error())
 			}
 			users.SaveAllUsers() // Save all user data too.
+			worldstate.SaveState()
 			util.UnlockMud()
 
 			break loop
@@ -794,43 +832,34 @@ loop:
 			util.LockMud()
 			turnTimer.Reset(time.Duration(c.Timing.TurnMs) * time.Millisecond)
 
-			turnCt := util.IncrementTurnCount()
-
-			events.AddToQueue(events.NewTurn{TurnNumber: turnCt, TimeNow: time.Now()})
-
-			// After a full round of turns, we can do a round tick.
-			if turnCt%uint64(c.Timing.TurnsPerRound()) == 0 {
-
-				roundNumber := util.IncrementRoundCount()
-
-				events.AddToQueue(events.NewRound{RoundNumber: roundNumber, TimeNow: time.Now()})
-			}
+			w.NextTurn(c.Timing.TurnsPerRound(), time.Now())
 
 			util.UnlockMud()
 
 		case enterWorldUserId := <-w.enterWorldUserId: // [2]int
 
 			util.LockMud()
-			w.enterWorld(enterWorldUserId[0], enterWorldUserId[1])
+			if journal.Recording() {
+				if user := users.GetByUserId(enterWorldUserId[0]); user != nil {
+					journal.Record(journal.Entry{Kind: journal.KindEnter, UserId: user.UserId, Username: user.Username, RoomId: enterWorldUserId[1]})
+				}
+			}
+			w.EnterWorld(enterWorldUserId[0], enterWorldUserId[1], false)
 			util.UnlockMud()
 
 		case leaveWorldUserId := <-w.leaveWorldUserId: // int
 
 			util.LockMud()
-			if userInfo := users.GetByUserId(leaveWorldUserId); userInfo != nil {
-				events.AddToQueue(events.PlayerDespawn{
-					UserId:        userInfo.UserId,
-					RoomId:        userInfo.Character.RoomId,
-					Username:      userInfo.Username,
-					CharacterName: userInfo.Character.Name,
-					TimeOnline:    userInfo.GetOnlineInfo().OnlineTimeStr,
-				})
-			}
+			journal.Record(journal.Entry{Kind: journal.KindLeave, UserId: leaveWorldUserId})
+			w.LeaveWorld(leaveWorldUserId)
 			util.UnlockMud()
 
 		case logoutConnectionId := <-w.logoutConnectionId: //  connections.ConnectionId
 
 			util.LockMud()
+			if user := users.GetByConnectionId(logoutConnectionId); user != nil {
+				journal.Record(journal.Entry{Kind: journal.KindLogout, UserId: user.UserId})
+			}
 			w.logOutUserByConnectionId(logoutConnectionId)
 			util.UnlockMud()
 
@@ -838,6 +867,7 @@ loop:
 			if zombieFlag[1] == 1 {
 
 				util.LockMud()
+				journal.Record(journal.Entry{Kind: journal.KindZombie, UserId: zombieFlag[0]})
 				users.SetZombieUser(zombieFlag[0])
 				util.UnlockMud()
 
@@ -867,11 +897,15 @@ loop:
 			break loop
 		case wi := <-w.worldInput:
 
-			events.AddToQueue(events.Input{
+			input := events.Input{
 				UserId:    wi.FromId,
 				InputText: wi.InputText,
 				ReadyTurn: util.GetTurnCount(),
-			})
+			}
+
+			journal.Record(journal.Entry{Kind: journal.KindInput, UserId: wi.FromId, Input: &input})
+
+			events.AddToQueue(input)
 
 		}
 	}
@@ -962,8 +996,11 @@ func (w *World) processInput(userId int, inputText string, flags events.EventFla
 		}
 
 	} else {
-		connId := user.ConnectionId()
-		connections.SendTo([]byte(templates.AnsiParse(user.GetCommandPrompt())), connId)
+		pTxt := templates.AnsiParse(user.GetCommandPrompt())
+		if user.ClientSettings().IsMxp() {
+			pTxt = term.MxpEscape(pTxt)
+		}
+		connections.SendTo([]byte(pTxt), user.ConnectionId())
 	}
 
 	if !handled {
@@ -978,8 +1015,11 @@ func (w *World) processInput(userId int, inputText string, flags events.EventFla
 
 	// If they had an input prompt, but now they don't, lets make sure to resend a status prompt
 	if hadPrompt || (!hadPrompt && user.GetPrompt() != nil) {
-		connId := user.ConnectionId()
-		connections.SendTo([]byte(templates.AnsiParse(user.GetCommandPrompt())), connId)
+		pTxt := templates.AnsiParse(user.GetCommandPrompt())
+		if user.ClientSettings().IsMxp() {
+			pTxt = term.MxpEscape(pTxt)
+		}
+		connections.SendTo([]byte(pTxt), user.ConnectionId())
 	}
 	// Removing this as possibly redundant.
 	// Leaving in case I need to remember that I did it...
@@ -1076,6 +1116,24 @@ func (w *World) Kick(userId int, reason string) {
 	connections.Kick(user.ConnectionId(), reason)
 }
 
+// Moves to the next turn and queues its NewTurn event.
+// After a full round of turns, it also moves to the next round and queues a NewRound event.
+func (w *World) NextTurn(turnsPerRound int, timeNow time.Time) uint64 {
+
+	turnCt := util.IncrementTurnCount()
+
+	events.AddToQueue(events.NewTurn{TurnNumber: turnCt, TimeNow: timeNow})
+
+	if turnCt%uint64(turnsPerRound) == 0 {
+
+		roundNumber := util.IncrementRoundCount()
+
+		events.AddToQueue(events.NewRound{RoundNumber: roundNumber, TimeNow: timeNow})
+	}
+
+	return turnCt
+}
+
 // Should only handle sending messages out to users
 func (w *World) EventLoop() {
 
@@ -0,0 +1,447 @@
+package worldstate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+const (
+	WorldStateFile = `world-state.yaml`
+)
+
+var (
+	ErrInvalidName  = errors.New("namespaces and keys must be a single word")
+	ErrInvalidValue = errors.New("values can only be strings, numbers, booleans, lists and objects")
+	ErrNotANumber   = errors.New("value is not a whole number")
+
+	stateLock  = sync.RWMutex{}
+	stateData  = map[string]map[string]Entry{}
+	stateDirty = false
+)
+
+type Entry struct {
+	Value     any       `yaml:"value"`
+	UpdatedAt time.Time `yaml:"updatedat"`
+	ExpiresAt time.Time `yaml:"expiresat,omitempty"` // zero means it never expires
+}
+
+func (e Entry) IsExpired() bool {
+	return !e.ExpiresAt.IsZero() && !time.Now().Before(e.ExpiresAt)
+}
+
+// An entry along with where it's kept, for listing
+type KeyValue struct {
+	Namespace string
+	Key       string
+	Entry
+}
+
+// Returns a value, or nil if it isn't set (or has expired)
+func Get(namespace string, key string) any {
+
+	stateLock.RLock()
+	defer stateLock.RUnlock()
+
+	entry, ok := stateData[namespace][key]
+	if !ok || entry.IsExpired() {
+		return nil
+	}
+
+	value, _ := normalize(entry.Value)
+	return value
+}
+
+// How long until a value expires. Zero if it never expires, false if it isn't set.
+func GetTTL(namespace string, key string) (time.Duration, bool) {
+
+	stateLock.RLock()
+	defer stateLock.RUnlock()
+
+	entry, ok := stateData[namespace][key]
+	if !ok || entry.IsExpired() {
+		return 0, false
+	}
+
+	if entry.ExpiresAt.IsZero() {
+		return 0, true
+	}
+
+	return time.Until(entry.ExpiresAt), true
+}
+
+// Sets a value. A ttl of zero keeps it until it's deleted. Setting nil deletes it.
+func Set(namespace string, key string, value any, ttl time.Duration) error {
+
+	if err := validateName(namespace, key); err != nil {
+		return err
+	}
+
+	if value == nil {
+		Delete(namespace, key)
+		return nil
+	}
+
+	value, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{
+		Value:     value,
+		UpdatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		entry.ExpiresAt = entry.UpdatedAt.Add(ttl)
+	}
+
+	stateLock.Lock()
+	oldValue := setEntry(namespace, key, entry)
+	stateLock.Unlock()
+
+	raiseChanged(namespace, key, value, oldValue)
+
+	return nil
+}
+
+// Adds to a whole number value and returns the result. A value that isn't set starts at zero.
+// Any expiry it has is kept.
+func Increment(namespace string, key string, amount int) (int, error) {
+
+	if err := validateName(namespace, key); err != nil {
+		return 0, err
+	}
+
+	stateLock.Lock()
+
+	entry, ok := stateData[namespace][key]
+	if !ok || entry.IsExpired() {
+		entry = Entry{Value: 0}
+	}
+
+	current, ok := toInt(entry.Value)
+	if !ok {
+		stateLock.Unlock()
+		return 0, fmt.Errorf(`%w: %s %s`, ErrNotANumber, namespace, key)
+	}
+
+	entry.Value = current + amount
+	entry.UpdatedAt = time.Now()
+	oldValue := setEntry(namespace, key, entry)
+
+	stateLock.Unlock()
+
+	raiseChanged(namespace, key, current+amount, oldValue)
+
+	return current + amount, nil
+}
+
+// Changes when a value expires, without changing the value. A ttl of zero keeps it until it's deleted.
+// Returns false if it isn't set.
+func SetTTL(namespace string, key string, ttl time.Duration) bool {
+
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	entry, ok := stateData[namespace][key]
+	if !ok || entry.IsExpired() {
+		return false
+	}
+
+	entry.ExpiresAt = time.Time{}
+	if ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+	stateData[namespace][key] = entry
+	stateDirty = true
+
+	return true
+}
+
+// Removes a value. Returns false if it wasn't set.
+func Delete(namespace string, key string) bool {
+
+	stateLock.Lock()
+
+	entry, ok := stateData[namespace][key]
+	if ok {
+		delete(stateData[namespace], key)
+		if len(stateData[namespace]) == 0 {
+			delete(stateData, namespace)
+		}
+		stateDirty = true
+	}
+
+	stateLock.Unlock()
+
+	if !ok || entry.IsExpired() {
+		return false
+	}
+
+	raiseChanged(namespace, key, nil, entry.Value)
+
+	return true
+}
+
+// Returns the namespaces in use, sorted
+func GetNamespaces() []string {
+
+	stateLock.RLock()
+	defer stateLock.RUnlock()
+
+	result := []string{}
+	for namespace, keys := range stateData {
+		for _, entry := range keys {
+			if !entry.IsExpired() {
+				result = append(result, namespace)
+				break
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Returns every value in a namespace, or in all of them if namespace is empty, sorted by namespace then key
+func GetAll(namespace string) []KeyValue {
+
+	stateLock.RLock()
+	defer stateLock.RUnlock()
+
+	result := []KeyValue{}
+	for ns, keys := range stateData {
+		if namespace != `` && ns != namespace {
+			continue
+		}
+		for key, entry := range keys {
+			if entry.IsExpired() {
+				continue
+			}
+			entry.Value, _ = normalize(entry.Value)
+			result = append(result, KeyValue{Namespace: ns, Key: key, Entry: entry})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// Removes any values that have expired, raising a change event for each so
+// scripts hear about it just as they would a delete.
+func ExpireState() {
+
+	stateLock.Lock()
+	expired := removeExpired()
+	stateLock.Unlock()
+
+	raiseExpired(expired)
+}
+
+// Must be called with stateLock held. Returns what it removed.
+func removeExpired() []KeyValue {
+
+	expired := []KeyValue{}
+	for namespace, keys := range stateData {
+		for key, entry := range keys {
+			if entry.IsExpired() {
+				delete(keys, key)
+				expired = append(expired, KeyValue{Namespace: namespace, Key: key, Entry: entry})
+			}
+		}
+		if len(keys) == 0 {
+			delete(stateData, namespace)
+		}
+	}
+
+	if len(expired) > 0 {
+		stateDirty = true
+	}
+
+	return expired
+}
+
+func raiseExpired(expired []KeyValue) {
+	for _, kv := range expired {
+		raiseChanged(kv.Namespace, kv.Key, nil, kv.Value)
+	}
+}
+
+// Must be called with stateLock held. Returns the value it replaced, if any.
+func setEntry(namespace string, key string, entry Entry) any {
+
+	var oldValue any
+	if old, ok := stateData[namespace][key]; ok && !old.IsExpired() {
+		oldValue = old.Value
+	}
+
+	if _, ok := stateData[namespace]; !ok {
+		stateData[namespace] = map[string]Entry{}
+	}
+	stateData[namespace][key] = entry
+	stateDirty = true
+
+	return oldValue
+}
+
+func raiseChanged(namespace string, key string, value any, oldValue any) {
+
+	value, _ = normalize(value)
+	oldValue, _ = normalize(oldValue)
+
+	events.AddToQueue(events.WorldStateChanged{
+		Namespace: namespace,
+		Key:       key,
+		Value:     value,
+		OldValue:  oldValue,
+	})
+}
+
+func validateName(namespace string, key string) error {
+	for _, name := range []string{namespace, key} {
+		if name == `` || strings.ContainsAny(name, " \t\r\n") {
+			return ErrInvalidName
+		}
+	}
+	return nil
+}
+
+// Copies a value, turning it into the few types that save and load the same way.
+// Maps from the yaml file come back as map[any]any, and numbers from scripts as int64.
+func normalize(value any) (any, error) {
+
+	switch v := value.(type) {
+	case nil, string, bool, int, float64:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case float32:
+		return float64(v), nil
+	case []any:
+		result := make([]any, len(v))
+		for i := range v {
+			item, err := normalize(v[i])
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, val := range v {
+			item, err := normalize(val)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = item
+		}
+		return result, nil
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, val := range v {
+			item, err := normalize(val)
+			if err != nil {
+				return nil, err
+			}
+			result[fmt.Sprint(key)] = item
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf(`%w: %T`, ErrInvalidValue, value)
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+func LoadState() {
+
+	loaded := map[string]map[string]Entry{}
+
+	if err := datafiles.LoadYaml(WorldStateFile, &loaded); err != nil {
+		mudlog.Error("LoadWorldState", "error", err.Error())
+		return
+	}
+
+	keyCt := 0
+	for namespace, keys := range loaded {
+		for key, entry := range keys {
+			value, err := normalize(entry.Value)
+			if err != nil {
+				mudlog.Error("LoadWorldState", "namespace", namespace, "key", key, "error", err.Error())
+				delete(keys, key)
+				continue
+			}
+			entry.Value = value
+			keys[key] = entry
+			keyCt++
+		}
+	}
+
+	stateLock.Lock()
+	stateData = loaded
+	stateDirty = false
+	stateLock.Unlock()
+
+	mudlog.Info("LoadWorldState", "namespaces", len(loaded), "keys", keyCt)
+}
+
+// Writes the state to disk if anything has changed since it was last saved. Expired values are dropped.
+func SaveState() error {
+
+	stateLock.Lock()
+
+	if !stateDirty {
+		stateLock.Unlock()
+		return nil
+	}
+
+	expired := removeExpired()
+	stateDirty = false
+
+	stateLock.Unlock()
+
+	raiseExpired(expired)
+
+	if err := datafiles.SaveYaml(WorldStateFile, &stateData, stateLock.RLocker()); err != nil {
+		mudlog.Error("SaveWorldState", "error", err.Error())
+
+		// Try again next time
+		stateLock.Lock()
+		stateDirty = true
+		stateLock.Unlock()
+
+		return err
+	}
+
+	return nil
+}
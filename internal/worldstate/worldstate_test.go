@@ -0,0 +1,221 @@
+package worldstate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/datafiles/datafilestest"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+	os.Exit(m.Run())
+}
+
+func setupStateFile(t *testing.T) string {
+	t.Helper()
+
+	dir := datafilestest.TempDir(t)
+
+	stateLock.Lock()
+	stateData = map[string]map[string]Entry{}
+	stateDirty = false
+	stateLock.Unlock()
+
+	return filepath.Join(dir, WorldStateFile)
+}
+
+func TestSetGet(t *testing.T) {
+	setupStateFile(t)
+
+	require.NoError(t, Set(`bridge`, `repaired`, true, 0))
+	require.NoError(t, Set(`dragon`, `hoard`, map[string]any{`gold`: int64(500), `gems`: []any{`ruby`}}, 0))
+
+	assert.Equal(t, true, Get(`bridge`, `repaired`))
+	assert.Equal(t, map[string]any{`gold`: 500, `gems`: []any{`ruby`}}, Get(`dragon`, `hoard`))
+	assert.Nil(t, Get(`bridge`, `burned`))
+	assert.Nil(t, Get(`nope`, `repaired`))
+
+	// Changing what Get() returned doesn't change what's stored
+	Get(`dragon`, `hoard`).(map[string]any)[`gold`] = 0
+	assert.Equal(t, 500, Get(`dragon`, `hoard`).(map[string]any)[`gold`])
+
+	assert.Equal(t, []string{`bridge`, `dragon`}, GetNamespaces())
+
+	// Setting nil deletes
+	require.NoError(t, Set(`bridge`, `repaired`, nil, 0))
+	assert.Nil(t, Get(`bridge`, `repaired`))
+	assert.Equal(t, []string{`dragon`}, GetNamespaces())
+}
+
+func TestSet_Invalid(t *testing.T) {
+	setupStateFile(t)
+
+	assert.ErrorIs(t, Set(``, `key`, 1, 0), ErrInvalidName)
+	assert.ErrorIs(t, Set(`ns`, `two words`, 1, 0), ErrInvalidName)
+	assert.ErrorIs(t, Set(`ns`, `key`, func() {}, 0), ErrInvalidValue)
+	assert.ErrorIs(t, Set(`ns`, `key`, []any{struct{}{}}, 0), ErrInvalidValue)
+	assert.Empty(t, GetAll(``))
+}
+
+func TestIncrement(t *testing.T) {
+	setupStateFile(t)
+
+	n, err := Increment(`dragon`, `slain`, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = Increment(`dragon`, `slain`, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// Whole numbers set as floats (such as from JSON) still count
+	require.NoError(t, Set(`dragon`, `eggs`, float64(2), 0))
+	n, err = Increment(`dragon`, `eggs`, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	require.NoError(t, Set(`dragon`, `name`, `Smaug`, 0))
+	_, err = Increment(`dragon`, `name`, 1)
+	assert.ErrorIs(t, err, ErrNotANumber)
+	assert.Equal(t, `Smaug`, Get(`dragon`, `name`))
+}
+
+func TestTTL(t *testing.T) {
+	setupStateFile(t)
+
+	require.NoError(t, Set(`event`, `forever`, 1, 0))
+	require.NoError(t, Set(`event`, `soon`, 1, time.Hour))
+
+	ttl, ok := GetTTL(`event`, `forever`)
+	assert.True(t, ok)
+	assert.Zero(t, ttl)
+
+	ttl, ok = GetTTL(`event`, `soon`)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, ttl, float64(time.Minute))
+
+	_, ok = GetTTL(`event`, `missing`)
+	assert.False(t, ok)
+
+	// Incrementing keeps the expiry
+	_, err := Increment(`event`, `soon`, 1)
+	require.NoError(t, err)
+	_, ok = GetTTL(`event`, `soon`)
+	assert.True(t, ok)
+	assert.True(t, SetTTL(`event`, `soon`, 0))
+	ttl, _ = GetTTL(`event`, `soon`)
+	assert.Zero(t, ttl)
+
+	// Expired values are gone
+	stateLock.Lock()
+	entry := stateData[`event`][`forever`]
+	entry.ExpiresAt = time.Now().Add(-time.Second)
+	stateData[`event`][`forever`] = entry
+	stateLock.Unlock()
+
+	assert.Nil(t, Get(`event`, `forever`))
+	assert.False(t, Delete(`event`, `forever`))
+	assert.False(t, SetTTL(`event`, `forever`, time.Hour))
+	assert.Len(t, GetAll(`event`), 1)
+
+	n, err := Increment(`event`, `forever`, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestExpireState(t *testing.T) {
+	setupStateFile(t)
+
+	changes := []events.WorldStateChanged{}
+	id := events.RegisterListener(events.WorldStateChanged{}, func(e events.Event) events.ListenerReturn {
+		changes = append(changes, e.(events.WorldStateChanged))
+		return events.Continue
+	})
+	t.Cleanup(func() {
+		events.UnregisterListener(events.WorldStateChanged{}, id)
+	})
+
+	require.NoError(t, Set(`bridge`, `repaired`, true, time.Hour))
+	require.NoError(t, Set(`bridge`, `built`, true, 0))
+	events.ProcessEvents()
+	changes = changes[:0]
+
+	stateLock.Lock()
+	entry := stateData[`bridge`][`repaired`]
+	entry.ExpiresAt = time.Now().Add(-time.Second)
+	stateData[`bridge`][`repaired`] = entry
+	stateDirty = false
+	stateLock.Unlock()
+
+	ExpireState()
+	events.ProcessEvents()
+
+	assert.Equal(t, []events.WorldStateChanged{
+		{Namespace: `bridge`, Key: `repaired`, Value: nil, OldValue: true},
+	}, changes)
+	assert.True(t, stateDirty)
+
+	stateLock.RLock()
+	_, ok := stateData[`bridge`][`repaired`]
+	stateLock.RUnlock()
+	assert.False(t, ok)
+
+	// Only once
+	ExpireState()
+	events.ProcessEvents()
+	assert.Len(t, changes, 1)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := setupStateFile(t)
+
+	// Nothing to save yet
+	require.NoError(t, SaveState())
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, Set(`bridge`, `repaired`, true, 0))
+	require.NoError(t, Set(`dragon`, `hoard`, map[string]any{`gold`: 500, `gems`: []any{`ruby`, `opal`}}, 0))
+	require.NoError(t, Set(`event`, `weekly`, `goblins`, 7*24*time.Hour))
+	require.NoError(t, SaveState())
+	assert.FileExists(t, path)
+
+	stateLock.Lock()
+	stateData = map[string]map[string]Entry{}
+	stateLock.Unlock()
+
+	LoadState()
+
+	assert.Equal(t, true, Get(`bridge`, `repaired`))
+	assert.Equal(t, map[string]any{`gold`: 500, `gems`: []any{`ruby`, `opal`}}, Get(`dragon`, `hoard`))
+	assert.Equal(t, `goblins`, Get(`event`, `weekly`))
+
+	ttl, ok := GetTTL(`event`, `weekly`)
+	assert.True(t, ok)
+	assert.Greater(t, ttl, 6*24*time.Hour)
+}
+
+func TestGetAll(t *testing.T) {
+	setupStateFile(t)
+
+	require.NoError(t, Set(`b`, `two`, 2, 0))
+	require.NoError(t, Set(`b`, `one`, 1, 0))
+	require.NoError(t, Set(`a`, `three`, 3, 0))
+
+	all := GetAll(``)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{`a three`, `b one`, `b two`}, []string{
+		all[0].Namespace + ` ` + all[0].Key,
+		all[1].Namespace + ` ` + all[1].Key,
+		all[2].Namespace + ` ` + all[2].Key,
+	})
+
+	assert.Len(t, GetAll(`b`), 2)
+	assert.Empty(t, GetAll(`c`))
+}
@@ -1,7 +1,9 @@
 //go:generate go run cmd/generate/module-imports.go
+//go:generate go run ./cmd/generate/scripttypes
 package main
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -17,20 +19,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/apitokens"
 	"github.com/GoMudEngine/GoMud/internal/audio"
-	"github.com/GoMudEngine/GoMud/internal/buffs"
-	"github.com/GoMudEngine/GoMud/internal/characters"
-	"github.com/GoMudEngine/GoMud/internal/colorpatterns"
+	"github.com/GoMudEngine/GoMud/internal/bans"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/copyover"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/flags"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
 	"github.com/GoMudEngine/GoMud/internal/hooks"
 	"github.com/GoMudEngine/GoMud/internal/inputhandlers"
 	"github.com/GoMudEngine/GoMud/internal/integrations/discord"
-	"github.com/GoMudEngine/GoMud/internal/items"
-	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/journal"
 	"github.com/GoMudEngine/GoMud/internal/language"
 	"github.com/GoMudEngine/GoMud/internal/migration"
 	"github.com/GoMudEngine/GoMud/internal/usercommands"
@@ -38,22 +39,17 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/GoMudEngine/GoMud/internal/mapper"
-	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
-	"github.com/GoMudEngine/GoMud/internal/mutators"
-	"github.com/GoMudEngine/GoMud/internal/pets"
 	"github.com/GoMudEngine/GoMud/internal/plugins"
-	"github.com/GoMudEngine/GoMud/internal/quests"
-	"github.com/GoMudEngine/GoMud/internal/races"
-	"github.com/GoMudEngine/GoMud/internal/rooms"
 	"github.com/GoMudEngine/GoMud/internal/scripting"
-	"github.com/GoMudEngine/GoMud/internal/spells"
 	"github.com/GoMudEngine/GoMud/internal/suggestions"
 	"github.com/GoMudEngine/GoMud/internal/templates"
 	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/GoMudEngine/GoMud/internal/web"
+	"github.com/GoMudEngine/GoMud/internal/world"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
 	_ "github.com/GoMudEngine/GoMud/modules"
 	textLang "golang.org/x/text/language"
 )
@@ -65,13 +61,23 @@ import (
 // 2. Consider whether any migration code is needed for breaking changes, particularly in datafiles (see internal/migration)
 const VERSION = "0.9.1"
 
+const (
+	tlsHandshakeTimeout = 10 * time.Second
+	proxyHeaderTimeout  = 5 * time.Second // Trusted proxies send the PROXY header immediately
+)
+
 var (
 	sigChan            = make(chan os.Signal, 1)
 	workerShutdownChan = make(chan bool, 1)
 
 	serverAlive atomic.Bool
 
-	worldManager = NewWorld(sigChan)
+	// Telnet listeners by port, handed to the new process on copyover
+	telnetListeners = map[int]net.Listener{}
+	// Set when this process was started by a copyover
+	copyoverState *copyover.State
+
+	worldManager = world.NewWorld(sigChan)
 
 	// Start a pool of worker goroutines
 	wg sync.WaitGroup
@@ -103,8 +109,32 @@ func main() {
 	flags.HandleFlags(VERSION)
 
 	configs.ReloadConfig()
+
+	// A replay runs against a throwaway copy of the journal's data files
+	var replayHeader journal.Header
+	var replayEntries []journal.Entry
+	if replayPath := flags.ReplayPath(); replayPath != `` {
+		h, entries, cleanup, err := prepareReplay(replayPath)
+		if err != nil {
+			mudlog.Error("Replay", "journal", replayPath, "error", err)
+			os.Exit(1)
+		}
+		defer cleanup()
+		replayHeader, replayEntries = h, entries
+	}
+
 	c := configs.GetConfig()
 
+	// If a copyover started this process, there are listeners and connections to pick up
+	if flags.ReplayPath() == `` {
+		if state, err := copyover.Load(); err != nil {
+			mudlog.Error("Copyover", "error", err)
+		} else if state != nil {
+			copyoverState = state
+			mudlog.Info("Copyover", "listeners", len(state.Listeners), "connections", len(state.Connections))
+		}
+	}
+
 	lastKnownVersion, err := version.Parse(string(configs.GetServerConfig().CurrentVersion))
 	if err != nil {
 		mudlog.Error("Versioning", "error", err)
@@ -112,6 +142,9 @@ func main() {
 	}
 
 	currentVersion, _ := version.Parse(VERSION)
+	version.SetServerVersion(currentVersion)
+
+	util.SetServerStartTime(serverStartTime)
 
 	if err = migration.Run(lastKnownVersion, currentVersion); err != nil {
 		mudlog.Error("migration.Run()", "error", err)
@@ -164,9 +197,11 @@ func main() {
 
 	// Register the plugin filesystem with the template system
 	templates.RegisterFS(plugins.GetPluginRegistry())
+	scripting.RegisterFS(plugins.GetPluginRegistry())
 	usercommands.AddFunctionExporter(plugins.GetPluginRegistry())
 
 	inputhandlers.AddIACHandler(plugins.GetPluginRegistry())
+	inputhandlers.AddOOBHandler(plugins.GetPluginRegistry())
 
 	//
 	// System Configurations
@@ -202,7 +237,7 @@ func main() {
 	mudlog.Info(`========================`)
 
 	// Load all the data files up front.
-	loadAllDataFiles(false)
+	world.LoadAllDataFiles(false)
 
 	mudlog.Info(`========================`)
 
@@ -223,6 +258,10 @@ func main() {
 	idx.Rebuild()
 	mudlog.Info("UserIndex", "info", "User index recreated.")
 
+	bans.LoadBans()
+	apitokens.LoadTokens()
+	worldstate.LoadState()
+
 	// Load the round count from the file
 	if util.LoadRoundCount(c.FilePaths.DataFiles.String()+`/`+util.RoundCountFilename) == util.RoundCountMinimum {
 		gametime.SetToDay(-3)
@@ -241,6 +280,20 @@ func main() {
 
 	web.SetWebPlugin(plugins.GetPluginRegistry())
 
+	if flags.ReplayPath() != `` {
+		if err := runReplay(replayHeader, replayEntries, flags.ReplayOutputPath()); err != nil {
+			mudlog.Error("Replay", "error", err)
+		}
+		return
+	}
+
+	if recordPath := flags.RecordPath(); recordPath != `` {
+		if err := startRecording(recordPath); err != nil {
+			mudlog.Error("Journal", "journal", recordPath, "error", err)
+			os.Exit(1)
+		}
+	}
+
 	//
 	// Capture OS signals to gracefully shutdown the server
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -255,25 +308,39 @@ func main() {
 	// Set the server to be alive
 	serverAlive.Store(true)
 
+	// Restored connections keep their ids, so register them before any new connections are accepted
+	restoredConnections := restoreCopyoverConnections()
+
 	mudlog.Info(`========================`)
 	web.Listen(&wg, HandleWebSocketConnection)
 
 	allServerListeners := make([]net.Listener, 0, len(c.Network.TelnetPort))
 	for _, port := range c.Network.TelnetPort {
 		if p, err := strconv.Atoi(port); err == nil {
-			if s := TelnetListenOnPort(``, p, &wg, int(c.Network.MaxTelnetConnections)); s != nil {
+			if s := TelnetListenOnPort(``, p, &wg, int(c.Network.MaxTelnetConnections), bool(c.Network.ProxyProtocol)); s != nil {
 				allServerListeners = append(allServerListeners, s)
 			}
 		}
 	}
 
+	if c.Network.TelnetTlsPort > 0 {
+		if s := TelnetTlsListenOnPort(``, int(c.Network.TelnetTlsPort), &wg, int(c.Network.MaxTelnetConnections), bool(c.Network.ProxyProtocol)); s != nil {
+			allServerListeners = append(allServerListeners, s)
+		}
+	}
+
 	if c.Network.LocalPort > 0 {
-		TelnetListenOnPort(`127.0.0.1`, int(c.Network.LocalPort), &wg, 0)
+		TelnetListenOnPort(`127.0.0.1`, int(c.Network.LocalPort), &wg, 0, false)
 	}
 
+	worldManager.SetCopyoverFunc(copyoverServer)
+
 	go worldManager.InputWorker(workerShutdownChan, &wg)
 	go worldManager.MainWorker(workerShutdownChan, &wg)
 
+	restoreCopyoverUsers(restoredConnections, &wg)
+	copyoverState.CloseUnclaimed()
+
 	mudlog.Info("Server Ready", "Time Taken", time.Since(serverStartTime))
 
 	// block until a signal comes in
@@ -329,18 +396,21 @@ func main() {
 	// Otherwise we end up getting flushed file saves incomplete.
 	wg.Wait()
 
+	if err := journal.Stop(); err != nil {
+		mudlog.Error("Journal", "error", err)
+	}
+
 	// Give it a second to disaptch any final messages in the event queue
 	// Example: discord server shutdown
 	time.Sleep(1 * time.Second)
 }
 
-func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync.WaitGroup) {
+// userObject is nil for new connections, or the already logged in user for connections restored by a copyover
+func handleTelnetConnection(connDetails *connections.ConnectionDetails, userObject *users.UserRecord, wg *sync.WaitGroup) {
 	defer func() {
 		wg.Done()
 	}()
 
-	mudlog.Info("New Connection", "connectionID", connDetails.ConnectionId(), "remoteAddr", connDetails.RemoteAddr().String())
-
 	// Setup shared state map for this connection's handlers
 	// Needs to be created BEFORE the first handler call
 	var sharedState map[string]any = make(map[string]any)
@@ -354,6 +424,21 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 	// Text Processing
 	connDetails.AddInputHandler("CleanserInputHandler", inputhandlers.CleanserInputHandler)
 
+	if userObject != nil {
+		mudlog.Info("Restored Connection", "connectionID", connDetails.ConnectionId(), "remoteAddr", connDetails.RemoteAddr().String(), "userId", userObject.UserId)
+		// Negotiation and login already happened before the copyover
+		addLoggedInInputHandlers(connDetails, userObject)
+	} else {
+		mudlog.Info("New Connection", "connectionID", connDetails.ConnectionId(), "remoteAddr", connDetails.RemoteAddr().String(), "peerAddr", connDetails.PeerAddr().String())
+		startTelnetLogin(connDetails, sharedState)
+	}
+
+	telnetInputLoop(connDetails, userObject, sharedState)
+}
+
+// Negotiates telnet options, sends the splash screen and the first login prompt
+func startTelnetLogin(connDetails *connections.ConnectionDetails, sharedState map[string]any) {
+
 	loginHandler := inputhandlers.GetLoginPromptHandler()           // Get the configured handler func
 	connDetails.AddInputHandler("LoginPromptHandler", loginHandler) // Add it with a unique name
 
@@ -393,6 +478,30 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 		connDetails.ConnectionId(),
 	)
 
+	// Let crawlers know we can report server status
+	if configs.GetIntegrationsConfig().MSSP.Enabled {
+		connections.SendTo(
+			term.MsspEnable.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+	}
+
+	// Offer compression
+	if configs.GetNetworkConfig().MCCPEnabled {
+		connections.SendTo(
+			term.MccpEnable.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+	}
+
+	// Offer clickable links
+	if configs.GetNetworkConfig().MXPEnabled {
+		connections.SendTo(
+			term.MxpEnable.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+	}
+
 	connections.SendTo(
 		term.TelnetSuppressGoAhead.BytesWithPayload(nil),
 		connDetails.ConnectionId(),
@@ -412,19 +521,6 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 	plugins.OnNetConnect(connDetails)
 
-	// an input buffer for reading data sent over the network
-	inputBuffer := make([]byte, connections.ReadBufferSize)
-
-	// Describes whatever the client sent us
-	clientInput := &connections.ClientInput{
-		ConnectionId: connDetails.ConnectionId(),
-		DataIn:       []byte{},
-		Buffer:       make([]byte, 0, connections.ReadBufferSize), // DataIn is appended to this buffer after processing
-		EnterPressed: false,
-		Clipboard:    []byte{},
-		History:      connections.InputHistory{},
-	}
-
 	if audioConfig := audio.GetFile(`intro`); audioConfig.FilePath != `` {
 		v := 100
 		if audioConfig.Volume > 0 && audioConfig.Volume <= 100 {
@@ -432,7 +528,7 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 		}
 		connections.SendTo(
 			term.MspCommand.BytesWithPayload([]byte("!!MUSIC("+audioConfig.FilePath+" V="+strconv.Itoa(v)+" L=-1 C=1)")),
-			clientInput.ConnectionId,
+			connDetails.ConnectionId(),
 		)
 	}
 
@@ -453,8 +549,23 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 	// 2. Calls advanceAndSendPromptCustom -> sendPromptFunc for the *first* step (username).
 	// 3. Returns false (which we ignore here, as we aren't in the main loop yet).
 	loginHandler(initialTriggerInput, sharedState)
+}
+
+func telnetInputLoop(connDetails *connections.ConnectionDetails, userObject *users.UserRecord, sharedState map[string]any) {
+
+	// an input buffer for reading data sent over the network
+	inputBuffer := make([]byte, connections.ReadBufferSize)
+
+	// Describes whatever the client sent us
+	clientInput := &connections.ClientInput{
+		ConnectionId: connDetails.ConnectionId(),
+		DataIn:       []byte{},
+		Buffer:       make([]byte, 0, connections.ReadBufferSize), // DataIn is appended to this buffer after processing
+		EnterPressed: false,
+		Clipboard:    []byte{},
+		History:      connections.InputHistory{},
+	}
 
-	var userObject *users.UserRecord
 	var sug suggestions.Suggestions
 	lastInput := time.Now()
 	c := configs.GetConfig()
@@ -605,18 +716,7 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 			// Remove the prompt handler (it signaled completion by returning true)
 			connDetails.RemoveInputHandler("LoginPromptHandler")
-			// Replace it with a regular echo handler.
-			connDetails.AddInputHandler("EchoInputHandler", inputhandlers.EchoInputHandler)
-			// Add admin command handler
-			connDetails.AddInputHandler("HistoryInputHandler", inputhandlers.HistoryInputHandler) // Put history tracking after login handling, since login handling aborts input until complete
-
-			if userObject.Role == users.RoleAdmin {
-				connDetails.AddInputHandler("SystemCommandInputHandler", inputhandlers.SystemCommandInputHandler)
-			}
-
-			// Add a signal handler (shortcut ctrl combos) after the AnsiHandler
-			// This captures signals and replaces user input so should happen after AnsiHandler to ensure it happens before other processes.
-			connDetails.AddInputHandler("SignalHandler", inputhandlers.SignalHandler, "AnsiHandler")
+			addLoggedInInputHandlers(connDetails, userObject)
 
 			connDetails.SetState(connections.LoggedIn)
 
@@ -679,7 +779,7 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 				}
 
-				wi := WorldInput{
+				wi := world.WorldInput{
 					FromId:    userObject.UserId,
 					InputText: string(clientInput.Buffer),
 				}
@@ -704,15 +804,50 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 }
 
-func HandleWebSocketConnection(conn *websocket.Conn) {
+// Input handlers for a connection once the user has logged in
+func addLoggedInInputHandlers(connDetails *connections.ConnectionDetails, userObject *users.UserRecord) {
+
+	// Replace the login prompt with a regular echo handler.
+	connDetails.AddInputHandler("EchoInputHandler", inputhandlers.EchoInputHandler)
+	// Add admin command handler
+	connDetails.AddInputHandler("HistoryInputHandler", inputhandlers.HistoryInputHandler) // Put history tracking after login handling, since login handling aborts input until complete
+
+	if userObject.Role == users.RoleAdmin {
+		connDetails.AddInputHandler("SystemCommandInputHandler", inputhandlers.SystemCommandInputHandler)
+	}
+
+	// Add a signal handler (shortcut ctrl combos) after the AnsiHandler
+	// This captures signals and replaces user input so should happen after AnsiHandler to ensure it happens before other processes.
+	connDetails.AddInputHandler("SignalHandler", inputhandlers.SignalHandler, "AnsiHandler")
+}
+
+func HandleWebSocketConnection(conn *websocket.Conn, realAddr net.Addr) {
+
+	remoteAddr := conn.RemoteAddr()
+	if realAddr != nil {
+		remoteAddr = realAddr
+	}
+
+	if ban, banned := bans.CheckIP(remoteAddr); banned {
+		mudlog.Warn("Banned connection", "remoteAddr", remoteAddr.String(), "banId", ban.BanId)
+		conn.WriteMessage(websocket.TextMessage, []byte(ban.Message()))
+		conn.Close()
+		return
+	}
 
 	var userObject *users.UserRecord
 	connDetails := connections.Add(nil, conn)
+	if realAddr != nil {
+		connDetails.SetRemoteAddr(realAddr)
+	}
 
 	// Setup shared state map for this connection's handlers
 	// Needs to be created BEFORE the first handler call
 	var sharedState map[string]any = make(map[string]any)
 
+	// Out-of-band messages (such as GMCP) from the web client must be intercepted before anything else
+	connDetails.AddInputHandler("WebOOBHandler", inputhandlers.WebOOBHandler)
+
 	loginHandler := inputhandlers.GetLoginPromptHandler()           // Get the configured handler func
 	connDetails.AddInputHandler("LoginPromptHandler", loginHandler) // Add it with a unique name
 
@@ -849,18 +984,7 @@ func HandleWebSocketConnection(conn *websocket.Conn) {
 
 			// Remove the prompt handler (it signaled completion by returning true)
 			connDetails.RemoveInputHandler("LoginPromptHandler")
-			// Replace it with a regular echo handler.
-			connDetails.AddInputHandler("EchoInputHandler", inputhandlers.EchoInputHandler)
-			// Add admin command handler
-			connDetails.AddInputHandler("HistoryInputHandler", inputhandlers.HistoryInputHandler) // Put history tracking after login handling, since login handling aborts input until complete
-
-			if userObject.Role == users.RoleAdmin {
-				connDetails.AddInputHandler("SystemCommandInputHandler", inputhandlers.SystemCommandInputHandler)
-			}
-
-			// Add a signal handler (shortcut ctrl combos) after the AnsiHandler
-			// This captures signals and replaces user input so should happen after AnsiHandler to ensure it happens before other processes.
-			connDetails.AddInputHandler("SignalHandler", inputhandlers.SignalHandler, "AnsiHandler")
+			addLoggedInInputHandlers(connDetails, userObject)
 
 			connDetails.SetState(connections.LoggedIn)
 
@@ -870,7 +994,7 @@ func HandleWebSocketConnection(conn *websocket.Conn) {
 			continue
 		}
 
-		wi := WorldInput{
+		wi := world.WorldInput{
 			FromId:    userObject.UserId,
 			InputText: string(message),
 		}
@@ -882,81 +1006,156 @@ func HandleWebSocketConnection(conn *websocket.Conn) {
 	}
 }
 
-func TelnetListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxConnections int) net.Listener {
+// When acceptProxy is true, connections from TrustedProxies may send a PROXY protocol header with the real client address.
+func TelnetListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxConnections int, acceptProxy bool) net.Listener {
 
-	server, err := net.Listen("tcp", fmt.Sprintf("%s:%d", hostname, portNum))
+	server, err := listenTelnet(hostname, portNum)
 	if err != nil {
 		mudlog.Error("Error creating server", "error", err)
 		return nil
 	}
 
 	// Start a goroutine to accept incoming connections, so that we can use a signal to stop the server
-	go func() {
+	go telnetAcceptLoop(server, nil, wg, maxConnections, acceptProxy)
 
-		// Loop to accept connections
-		for {
-			conn, err := server.Accept()
+	return server
+}
 
-			if !serverAlive.Load() {
-				mudlog.Warn("Connections disabled.")
-				return
-			}
+// Same as TelnetListenOnPort, but wraps connections in TLS using the https cert/key files
+func TelnetTlsListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxConnections int, acceptProxy bool) net.Listener {
+
+	filePaths := configs.GetFilePathsConfig()
+
+	if len(filePaths.HttpsCertFile) == 0 || len(filePaths.HttpsKeyFile) == 0 {
+		mudlog.Info("Telnet TLS", "stage", "skipping", "error", "Undefined public/private key files", "Public Cert", filePaths.HttpsCertFile, "Private Key", filePaths.HttpsKeyFile)
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(string(filePaths.HttpsCertFile), string(filePaths.HttpsKeyFile))
+	if err != nil {
+		mudlog.Error("Telnet TLS", "error", fmt.Errorf("Error loading certificate and key: %w", err))
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	// A plain listener, since a PROXY header (if any) comes before the TLS handshake
+	server, err := listenTelnet(hostname, portNum)
+	if err != nil {
+		mudlog.Error("Error creating server", "error", err)
+		return nil
+	}
+
+	mudlog.Info("Telnet TLS", "stage", "Starting telnet tls server", "port", portNum)
+
+	go telnetAcceptLoop(server, tlsConfig, wg, maxConnections, acceptProxy)
+
+	return server
+}
+
+// Reuses the listener inherited from a copyover if there is one, otherwise opens a new one
+func listenTelnet(hostname string, portNum int) (net.Listener, error) {
+
+	server, err := copyoverState.Listener(portNum)
+	if err != nil {
+		mudlog.Error("Copyover", "port", portNum, "error", err)
+	}
+
+	if server == nil {
+		if server, err = net.Listen("tcp", fmt.Sprintf("%s:%d", hostname, portNum)); err != nil {
+			return nil, err
+		}
+	}
+
+	telnetListeners[portNum] = server
 
-			if err != nil {
-				mudlog.Warn("Connection error", "error", err)
+	return server, nil
+}
+
+func telnetAcceptLoop(server net.Listener, tlsConfig *tls.Config, wg *sync.WaitGroup, maxConnections int, acceptProxy bool) {
+
+	// Loop to accept connections
+	for {
+		conn, err := server.Accept()
+
+		if !serverAlive.Load() {
+			mudlog.Warn("Connections disabled.")
+			return
+		}
+
+		if err != nil {
+			mudlog.Warn("Connection error", "error", err)
+			continue
+		}
+
+		if maxConnections > 0 {
+			if connections.ActiveConnectionCount() >= maxConnections {
+				conn.Write([]byte(fmt.Sprintf("\n\n\n!!! Server is full (%d connections). Try again later. !!!\n\n\n", connections.ActiveConnectionCount())))
+				conn.Close()
 				continue
 			}
+		}
+
+		wg.Add(1)
+
+		// PROXY headers and TLS handshakes both wait on the client, so do them in
+		// their own goroutine where a slow client can't hold up the accept loop.
+		go func(conn net.Conn) {
+
+			var realAddr net.Addr
 
-			if maxConnections > 0 {
-				if connections.ActiveConnectionCount() >= maxConnections {
-					conn.Write([]byte(fmt.Sprintf("\n\n\n!!! Server is full (%d connections). Try again later. !!!\n\n\n", connections.ActiveConnectionCount())))
+			if acceptProxy && connections.IsTrustedProxy(conn.RemoteAddr(), configs.GetNetworkConfig().TrustedProxies) {
+				proxiedConn, addr, err := connections.ReadProxyHeader(conn, proxyHeaderTimeout)
+				if err != nil {
+					mudlog.Warn("PROXY protocol", "peerAddr", conn.RemoteAddr().String(), "error", err)
 					conn.Close()
-					continue
+					wg.Done()
+					return
 				}
+				conn, realAddr = proxiedConn, addr
 			}
 
-			wg.Add(1)
-			// hand off the connection to a handler goroutine so that we can continue handling new connections
-			go handleTelnetConnection(
-				connections.Add(conn, nil),
-				wg,
-			)
+			// TLS connections must finish their handshake before anything is written to them.
+			if tlsConfig != nil {
+				tlsConn := tls.Server(conn, tlsConfig)
+				tlsConn.SetDeadline(time.Now().Add(tlsHandshakeTimeout))
+				if err := tlsConn.Handshake(); err != nil {
+					remoteAddr := tlsConn.RemoteAddr()
+					if realAddr != nil {
+						remoteAddr = realAddr
+					}
+					mudlog.Warn("Telnet TLS", "remoteAddr", remoteAddr.String(), "error", err)
+					tlsConn.Close()
+					wg.Done()
+					return
+				}
+				tlsConn.SetDeadline(time.Time{})
+				conn = tlsConn
+			}
 
-		}
-	}()
+			remoteAddr := conn.RemoteAddr()
+			if realAddr != nil {
+				remoteAddr = realAddr
+			}
 
-	return server
-}
+			if ban, banned := bans.CheckIP(remoteAddr); banned {
+				mudlog.Warn("Banned connection", "remoteAddr", remoteAddr.String(), "banId", ban.BanId)
+				conn.Write([]byte(term.CRLFStr + ban.Message() + term.CRLFStr))
+				conn.Close()
+				wg.Done()
+				return
+			}
 
-func loadAllDataFiles(isReload bool) {
+			connDetails := connections.Add(conn, nil)
+			if realAddr != nil {
+				connDetails.SetRemoteAddr(realAddr)
+			}
 
-	if isReload {
+			handleTelnetConnection(connDetails, nil, wg)
+		}(conn)
 
-		defer func() {
-			if r := recover(); r != nil {
-				mudlog.Error("RELOAD FAILED", "err", r)
-			}
-		}()
-
-	}
-
-	// Force clear all cached VM's
-	scripting.PruneVMs(true)
-
-	// Load biomes before rooms since rooms reference biomes
-	rooms.LoadBiomeDataFiles()
-	spells.LoadSpellFiles()
-	rooms.LoadDataFiles()
-	buffs.LoadDataFiles() // Load buffs before items for cost calculation reasons
-	items.LoadDataFiles()
-	races.LoadDataFiles()
-	mobs.LoadDataFiles()
-	pets.LoadDataFiles()
-	quests.LoadDataFiles()
-	templates.LoadAliases(plugins.GetPluginRegistry())
-	keywords.LoadAliases(plugins.GetPluginRegistry())
-	mutators.LoadDataFiles()
-	colorpatterns.LoadColorPatterns()
-	audio.LoadAudioConfig()
-	characters.CompileAdjectiveSwaps() // This should come after loading color patterns.
+	}
 }
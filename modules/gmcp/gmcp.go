@@ -60,7 +60,9 @@ func init() {
 	gmcpModule.plug.ExportFunction(`IsMudlet`, gmcpModule.IsMudletExportedFunction)
 
 	gmcpModule.plug.Callbacks.SetIACHandler(gmcpModule.HandleIAC)
+	gmcpModule.plug.Callbacks.SetOOBHandler(gmcpModule.HandleOOB)
 	gmcpModule.plug.Callbacks.SetOnNetConnect(gmcpModule.onNetConnect)
+	gmcpModule.plug.Callbacks.SetCopyoverHandlers(gmcpModule.copyoverSave, gmcpModule.copyoverRestore)
 
 	events.RegisterListener(GMCPOut{}, gmcpModule.dispatchGMCP)
 	events.RegisterListener(events.PlayerSpawn{}, gmcpModule.handlePlayerJoin)
@@ -153,6 +155,34 @@ func (g *GMCPModule) onNetConnect(n plugins.NetConnection) {
 	g.sendGMCPEnableRequest(n.ConnectionId())
 }
 
+// Keeps what the client negotiated, so it doesn't need to be asked again after a copyover
+func (g *GMCPModule) copyoverSave(connectionId uint64) []byte {
+
+	gmcpData, ok := g.cache.Get(connectionId)
+	if !ok {
+		return nil
+	}
+
+	b, err := json.Marshal(gmcpData)
+	if err != nil {
+		mudlog.Error("GMCP", "action", "copyoverSave", "connectionId", connectionId, "error", err)
+		return nil
+	}
+
+	return b
+}
+
+func (g *GMCPModule) copyoverRestore(connectionId uint64, data []byte) {
+
+	gmcpData := GMCPSettings{}
+	if err := json.Unmarshal(data, &gmcpData); err != nil {
+		mudlog.Error("GMCP", "action", "copyoverRestore", "connectionId", connectionId, "error", err)
+		return
+	}
+
+	g.cache.Add(connectionId, gmcpData)
+}
+
 func (g *GMCPModule) isGMCPCommand(b []byte) bool {
 	return len(b) > 2 && b[0] == term.TELNET_IAC && b[2] == TELNET_GMCP
 }
@@ -248,127 +278,74 @@ func (g *GMCPModule) HandleIAC(connectionId uint64, iacCmd []byte) bool {
 		requestBody := iacCmd[3 : len(iacCmd)-2]
 		//mudlog.Debug("Received", "type", "GMCP", "size", len(iacCmd), "data", string(requestBody))
 
-		spaceAt := 0
-		for i := 0; i < len(requestBody); i++ {
-			if requestBody[i] == 32 {
-				spaceAt = i
-				break
-			}
-		}
+		g.handleGMCPMessage(connectionId, requestBody)
 
-		command := ``
-		payload := []byte{}
+		return true
+	}
 
-		if spaceAt > 0 && spaceAt < len(requestBody) {
-			command = string(requestBody[0:spaceAt])
-			payload = requestBody[spaceAt+1:]
-		} else {
-			command = string(requestBody)
-		}
+	// Unhanlded IAC command, log it
+	mudlog.Debug("Received", "type", "GMCP?", "data-size", len(iacCmd), "data-string", string(iacCmd), "data-bytes", iacCmd)
 
-		mudlog.Debug("Received", "type", "GMCP (Handling)", "command", command, "payload", string(payload))
+	return true
+}
 
-		switch command {
+// Websocket clients send GMCP as `!!GMCP(Package.Name {json})`, mirroring what they receive.
+func (g *GMCPModule) HandleOOB(connectionId uint64, oobCmd []byte) bool {
 
-		case `Core.Hello`:
-			decoded := GMCPHello{}
-			if err := json.Unmarshal(payload, &decoded); err == nil {
+	ok, requestBody := term.Matches(oobCmd, GmcpWebPayload)
+	if !ok {
+		return false
+	}
 
-				gmcpData, ok := g.cache.Get(connectionId)
-				if !ok {
-					gmcpData = GMCPSettings{}
-					gmcpData.GMCPAccepted = true
-				}
+	g.handleGMCPMessage(connectionId, bytes.TrimSpace(requestBody))
 
-				gmcpData.Client.Name = decoded.Client
-				gmcpData.Client.Version = decoded.Version
+	return true
+}
 
-				if strings.EqualFold(decoded.Client, `mudlet`) {
-					gmcpData.Client.IsMudlet = true
+// Processes a client GMCP message body such as `Core.Supports.Set ["Char 1"]`
+func (g *GMCPModule) handleGMCPMessage(connectionId uint64, requestBody []byte) {
 
-					// Trigger the Mudlet detected event
-					userId := 0
-					// Try to find the user ID associated with this connection
-					for _, user := range users.GetAllActiveUsers() {
-						if user.ConnectionId() == connectionId {
-							userId = user.UserId
-							break
-						}
-					}
+	spaceAt := 0
+	for i := 0; i < len(requestBody); i++ {
+		if requestBody[i] == 32 {
+			spaceAt = i
+			break
+		}
+	}
 
-					if userId > 0 {
-						events.AddToQueue(GMCPMudletDetected{
-							ConnectionId: connectionId,
-							UserId:       userId,
-						})
-					}
-				}
+	command := ``
+	payload := []byte{}
 
-				g.cache.Add(connectionId, gmcpData)
-			}
-		case `Core.Supports.Set`:
-			decoded := GMCPSupportsSet{}
-			if err := json.Unmarshal(payload, &decoded); err == nil {
-
-				gmcpData, ok := g.cache.Get(connectionId)
-				if !ok {
-					gmcpData = GMCPSettings{}
-					gmcpData.GMCPAccepted = true
-				}
+	if spaceAt > 0 && spaceAt < len(requestBody) {
+		command = string(requestBody[0:spaceAt])
+		payload = requestBody[spaceAt+1:]
+	} else {
+		command = string(requestBody)
+	}
 
-				gmcpData.EnabledModules = map[string]int{}
-
-				for name, value := range decoded.GetSupportedModules() {
-
-					// Break it down into:
-					// Char.Inventory.Backpack
-					// Char.Inventory
-					// Char
-					for {
-						gmcpData.EnabledModules[name] = value
-						idx := strings.LastIndex(name, ".")
-						if idx == -1 {
-							break
-						}
-						name = name[:idx]
-					}
+	mudlog.Debug("Received", "type", "GMCP (Handling)", "command", command, "payload", string(payload))
 
-				}
+	switch command {
 
-				g.cache.Add(connectionId, gmcpData)
+	case `Core.Hello`:
+		decoded := GMCPHello{}
+		if err := json.Unmarshal(payload, &decoded); err == nil {
 
+			gmcpData, ok := g.cache.Get(connectionId)
+			if !ok {
+				gmcpData = GMCPSettings{}
+				gmcpData.GMCPAccepted = true
 			}
-		case `Core.Supports.Remove`:
-			decoded := GMCPSupportsRemove{}
-			if err := json.Unmarshal(payload, &decoded); err == nil {
-
-				gmcpData, ok := g.cache.Get(connectionId)
-				if !ok {
-					gmcpData = GMCPSettings{}
-					gmcpData.GMCPAccepted = true
-				}
 
-				if len(gmcpData.EnabledModules) > 0 {
-					for _, name := range decoded {
-						delete(gmcpData.EnabledModules, name)
-					}
-				}
-
-				g.cache.Add(connectionId, gmcpData)
+			gmcpData.Client.Name = decoded.Client
+			gmcpData.Client.Version = decoded.Version
 
-			}
-		case `Char.Login`:
-			decoded := GMCPLogin{}
-			if err := json.Unmarshal(payload, &decoded); err == nil {
-				mudlog.Debug("GMCP LOGIN", "username", decoded.Name, "password", strings.Repeat(`*`, len(decoded.Password)))
-			}
+			if strings.EqualFold(decoded.Client, `mudlet`) {
+				gmcpData.Client.IsMudlet = true
 
-		// Handle Discord-related messages
-		default:
-			// Check if it's a Discord message
-			if strings.HasPrefix(command, "External.Discord") {
-				// Try to find the user ID associated with this connection
+				// Trigger the Mudlet detected event
 				userId := 0
+				// Try to find the user ID associated with this connection
 				for _, user := range users.GetAllActiveUsers() {
 					if user.ConnectionId() == connectionId {
 						userId = user.UserId
@@ -377,31 +354,103 @@ func (g *GMCPModule) HandleIAC(connectionId uint64, iacCmd []byte) bool {
 				}
 
 				if userId > 0 {
-					// Extract the Discord command (Hello, Get, Status)
-					discordCommand := ""
-					if parts := strings.Split(command, "."); len(parts) >= 3 {
-						discordCommand = parts[2] // External.Discord.Hello -> Hello
-					}
-
-					// Dispatch a GMCPDiscordMessage event
-					events.AddToQueue(GMCPDiscordMessage{
+					events.AddToQueue(GMCPMudletDetected{
 						ConnectionId: connectionId,
-						Command:      discordCommand,
-						Payload:      payload,
+						UserId:       userId,
 					})
+				}
+			}
+
+			g.cache.Add(connectionId, gmcpData)
+		}
+	case `Core.Supports.Set`:
+		decoded := GMCPSupportsSet{}
+		if err := json.Unmarshal(payload, &decoded); err == nil {
+
+			gmcpData, ok := g.cache.Get(connectionId)
+			if !ok {
+				gmcpData = GMCPSettings{}
+				gmcpData.GMCPAccepted = true
+			}
+
+			gmcpData.EnabledModules = map[string]int{}
+
+			for name, value := range decoded.GetSupportedModules() {
+
+				// Break it down into:
+				// Char.Inventory.Backpack
+				// Char.Inventory
+				// Char
+				for {
+					gmcpData.EnabledModules[name] = value
+					idx := strings.LastIndex(name, ".")
+					if idx == -1 {
+						break
+					}
+					name = name[:idx]
+				}
+
+			}
+
+			g.cache.Add(connectionId, gmcpData)
+
+		}
+	case `Core.Supports.Remove`:
+		decoded := GMCPSupportsRemove{}
+		if err := json.Unmarshal(payload, &decoded); err == nil {
+
+			gmcpData, ok := g.cache.Get(connectionId)
+			if !ok {
+				gmcpData = GMCPSettings{}
+				gmcpData.GMCPAccepted = true
+			}
 
-					mudlog.Debug("GMCP", "type", "Discord", "command", discordCommand, "userId", userId)
+			if len(gmcpData.EnabledModules) > 0 {
+				for _, name := range decoded {
+					delete(gmcpData.EnabledModules, name)
 				}
 			}
+
+			g.cache.Add(connectionId, gmcpData)
+
+		}
+	case `Char.Login`:
+		decoded := GMCPLogin{}
+		if err := json.Unmarshal(payload, &decoded); err == nil {
+			mudlog.Debug("GMCP LOGIN", "username", decoded.Name, "password", strings.Repeat(`*`, len(decoded.Password)))
 		}
 
-		return true
-	}
+	// Handle Discord-related messages
+	default:
+		// Check if it's a Discord message
+		if strings.HasPrefix(command, "External.Discord") {
+			// Try to find the user ID associated with this connection
+			userId := 0
+			for _, user := range users.GetAllActiveUsers() {
+				if user.ConnectionId() == connectionId {
+					userId = user.UserId
+					break
+				}
+			}
 
-	// Unhanlded IAC command, log it
-	mudlog.Debug("Received", "type", "GMCP?", "data-size", len(iacCmd), "data-string", string(iacCmd), "data-bytes", iacCmd)
+			if userId > 0 {
+				// Extract the Discord command (Hello, Get, Status)
+				discordCommand := ""
+				if parts := strings.Split(command, "."); len(parts) >= 3 {
+					discordCommand = parts[2] // External.Discord.Hello -> Hello
+				}
 
-	return true
+				// Dispatch a GMCPDiscordMessage event
+				events.AddToQueue(GMCPDiscordMessage{
+					ConnectionId: connectionId,
+					Command:      discordCommand,
+					Payload:      payload,
+				})
+
+				mudlog.Debug("GMCP", "type", "Discord", "command", discordCommand, "userId", userId)
+			}
+		}
+	}
 }
 
 // Checks whether their level is too high for a guide
@@ -463,7 +512,7 @@ func (g *GMCPModule) dispatchGMCP(e events.Event) events.ListenerReturn {
 			v = append([]byte(gmcp.Module+` `), v...)
 		}
 
-		if gmcpSettings.Client.Name == `WebClient` {
+		if connections.IsWebsocket(connId) {
 			connections.SendTo(GmcpWebPayload.BytesWithPayload(v), connId)
 		} else {
 			connections.SendTo(GmcpPayload.BytesWithPayload(v), connId)
@@ -485,7 +534,7 @@ func (g *GMCPModule) dispatchGMCP(e events.Event) events.ListenerReturn {
 			v = gmcp.Module + ` ` + v
 		}
 
-		if gmcpSettings.Client.Name == `WebClient` {
+		if connections.IsWebsocket(connId) {
 			connections.SendTo(GmcpWebPayload.BytesWithPayload([]byte(v)), connId)
 		} else {
 			connections.SendTo(GmcpPayload.BytesWithPayload([]byte(v)), connId)
@@ -512,7 +561,7 @@ func (g *GMCPModule) dispatchGMCP(e events.Event) events.ListenerReturn {
 			payload = append([]byte(gmcp.Module+` `), payload...)
 		}
 
-		if gmcpSettings.Client.Name == `WebClient` {
+		if connections.IsWebsocket(connId) {
 			connections.SendTo(GmcpWebPayload.BytesWithPayload(payload), connId)
 		} else {
 			connections.SendTo(GmcpPayload.BytesWithPayload(payload), connId)
@@ -63,6 +63,7 @@ func init() {
 	t.plug.Callbacks.SetOnSave(t.saveLBs)
 
 	t.plug.Web.WebPage(`Leaderboards`, `/leaderboards`, `leaderboards.html`, true, t.webLeaderboardData)
+	t.plug.Web.ApiData(`leaderboards`, t.apiLeaderboardData)
 
 	events.RegisterListener(events.NewRound{}, t.newRoundHandler)
 
@@ -100,6 +101,49 @@ func (l *LeaderboardModule) webLeaderboardData(r *http.Request) map[string]any {
 
 }
 
+type apiLeaderboard struct {
+	Name    string                `json:"name"`
+	Entries []apiLeaderboardEntry `json:"entries"`
+}
+
+type apiLeaderboardEntry struct {
+	Rank       int    `json:"rank"`
+	Character  string `json:"character"`
+	Profession string `json:"profession"`
+	Level      int    `json:"level"`
+	Score      int    `json:"score"`
+}
+
+// Served at /api/v1/leaderboards
+func (l *LeaderboardModule) apiLeaderboardData(r *http.Request) any {
+
+	result := []apiLeaderboard{}
+
+	for _, lb := range l.getCurrentLeaderboards() {
+
+		board := apiLeaderboard{Name: lb.Name, Entries: []apiLeaderboardEntry{}}
+
+		for i, entry := range lb.Top {
+
+			if entry.UserId == 0 {
+				continue
+			}
+
+			board.Entries = append(board.Entries, apiLeaderboardEntry{
+				Rank:       i + 1,
+				Character:  entry.CharacterName,
+				Profession: entry.CharacterClass,
+				Level:      entry.Level,
+				Score:      entry.ScoreValue,
+			})
+		}
+
+		result = append(result, board)
+	}
+
+	return result
+}
+
 func (l *LeaderboardModule) loadLBs() {
 
 	l.plug.ReadIntoStruct(`latest-leaderboards`, &l)
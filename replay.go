@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/journal"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// Starting -record and -replay. The playback itself is World.Replay().
+// A replay boots from a throwaway copy of the journal's data snapshot, with no listeners.
+//
+
+// Points the data files at a copy of the journal's snapshot. Must happen before anything is loaded.
+// Returns a cleanup func that removes the copy.
+func prepareReplay(journalPath string) (journal.Header, []journal.Entry, func(), error) {
+
+	h, entries, err := journal.Load(journalPath)
+	if err != nil {
+		return h, nil, nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(``, `gomud_replay_*`)
+	if err != nil {
+		return h, nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	dataFiles := util.FilePath(tmpDir, `/`, `datafiles`)
+	if err := journal.Snapshot(h.Snapshot, dataFiles); err != nil {
+		cleanup()
+		return h, nil, nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	if err := configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dataFiles}); err != nil {
+		cleanup()
+		return h, nil, nil, err
+	}
+
+	return h, entries, cleanup, nil
+}
+
+// Starts recording, once the world is loaded and before anyone can connect
+func startRecording(journalPath string) error {
+
+	c := configs.GetConfig()
+
+	// Reseed so the replay can start from the same point
+	seed := time.Now().UnixNano()
+	util.SeedRand(seed)
+
+	return journal.Start(journalPath, c.FilePaths.DataFiles.String(), journal.Header{
+		Started:       time.Now(),
+		Seed:          seed,
+		Turn:          util.GetTurnCount(),
+		Round:         util.GetRoundCount(),
+		TurnMs:        int(c.Timing.TurnMs),
+		TurnsPerRound: c.Timing.TurnsPerRound(),
+	})
+}
+
+// Replays a journal prepared by prepareReplay() and writes the output to a file
+func runReplay(h journal.Header, entries []journal.Entry, outputPath string) error {
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := worldManager.Replay(h, entries, f); err != nil {
+		return err
+	}
+
+	mudlog.Info("Replay", "output", outputPath)
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package worldtest
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/races"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+// A player in the world, on a connection that keeps everything it's sent
+type User struct {
+	t       testing.TB
+	record  *users.UserRecord
+	output  *userOutput
+	created bool // Made by NewUser(), so deleted afterwards
+}
+
+// Creates a new character named name and puts them in roomId (0 for the start room).
+// The name can't already be taken in the world's users folder. The user is deleted again when the test finishes.
+func (w *World) NewUser(name string, roomId int) *User {
+	w.t.Helper()
+
+	output := &userOutput{}
+	connDetails := connections.AddVirtual(output)
+
+	u := users.NewUserRecord(0, connDetails.ConnectionId())
+	if err := u.SetUsername(name); err != nil {
+		connections.Remove(connDetails.ConnectionId())
+		w.t.Fatalf("worldtest: NewUser(%q): %s", name, err)
+	}
+
+	// Skips character creation, and starts them off the way the start command would
+	u.Character.Name = name
+	if r := firstSelectableRace(); r != nil {
+		u.Character.RaceId = r.Id()
+		u.Character.Alignment = r.DefaultAlignment
+	}
+	u.Character.Validate()
+	u.Character.Health = u.Character.HealthMax.Value
+	u.Character.Mana = u.Character.ManaMax.Value
+	u.Character.ActionPoints = u.Character.ActionPointsMax.Value
+	u.Character.ExtraLives = int(configs.GetGamePlayConfig().LivesStart)
+
+	if _, found := users.NewUserIndex().FindByUsername(name); found {
+		connections.Remove(connDetails.ConnectionId())
+		w.t.Fatalf("worldtest: NewUser(%q): that username is taken", name)
+	}
+
+	if err := users.CreateUser(u); err != nil {
+		connections.Remove(connDetails.ConnectionId())
+		w.t.Fatalf("worldtest: NewUser(%q): %s", name, err)
+	}
+
+	user := w.enter(u, connDetails, output, roomId)
+	user.created = true
+
+	return user
+}
+
+// New characters get the selectable race with the lowest id
+func firstSelectableRace() *races.Race {
+	var first *races.Race
+	for _, r := range races.GetRaces() {
+		if r.Selectable && (first == nil || r.Id() < first.Id()) {
+			first = &r
+		}
+	}
+	return first
+}
+
+// Logs in a user that's already in the world's users folder, and puts them in the room they were saved in
+func (w *World) Login(name string) *User {
+	w.t.Helper()
+
+	u, err := users.LoadUser(name)
+	if err != nil {
+		w.t.Fatalf("worldtest: Login(%q): %s", name, err)
+	}
+
+	output := &userOutput{}
+	connDetails := connections.AddVirtual(output)
+
+	if u, _, err = users.LoginUser(u, connDetails.ConnectionId()); err != nil {
+		connections.Remove(connDetails.ConnectionId())
+		w.t.Fatalf("worldtest: Login(%q): %s", name, err)
+	}
+
+	return w.enter(u, connDetails, output, u.Character.RoomId)
+}
+
+func (w *World) enter(u *users.UserRecord, connDetails *connections.ConnectionDetails, output *userOutput, roomId int) *User {
+
+	connDetails.SetState(connections.LoggedIn)
+
+	worldManager.EnterWorld(u.UserId, roomId, false)
+	worldManager.EventLoop()
+
+	user := &User{t: w.t, record: u, output: output}
+	w.users = append(w.users, user)
+
+	return user
+}
+
+// Takes the user out of the world, and deletes them if NewUser() made them
+func (u *User) leave() {
+
+	worldManager.LeaveWorld(u.record.UserId)
+	worldManager.EventLoop()
+
+	if !u.created {
+		return
+	}
+
+	if err := users.NewUserIndex().RemoveByUsername(u.record.Username); err != nil {
+		u.t.Errorf("worldtest: removing %s: %s", u.record.Username, err)
+	}
+
+	os.Remove(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, `users`, `/`, strconv.Itoa(u.record.UserId)+`.yaml`))
+}
+
+// Types a command, the same as if it came in from a connection.
+// It goes through the same event as live input and is handled right away, unless an earlier command
+// has the user waiting (or the command itself takes turns), in which case it needs AdvanceTurns() to finish.
+func (u *User) Command(text string) {
+
+	events.AddToQueue(events.Input{
+		UserId:    u.record.UserId,
+		InputText: text,
+		ReadyTurn: util.GetTurnCount(),
+	})
+
+	worldManager.EventLoop()
+}
+
+// The user record, for checks the assertions don't cover
+func (u *User) Record() *users.UserRecord {
+	return u.record
+}
+
+// Everything sent to the user since they entered (or since ClearOutput()), without colors
+func (u *User) Output() string {
+	return u.output.String()
+}
+
+func (u *User) ClearOutput() {
+	u.output.Reset()
+}
+
+func (u *User) AssertRoom(roomId int) bool {
+	u.t.Helper()
+
+	if u.record.Character.RoomId != roomId {
+		u.t.Errorf("%s is in room %d, expected room %d", u.record.Username, u.record.Character.RoomId, roomId)
+		return false
+	}
+	return true
+}
+
+// Checks the backpack for an item, matched by name the same way commands do
+func (u *User) AssertHasItem(itemName string) bool {
+	u.t.Helper()
+
+	if _, found := u.record.Character.FindInBackpack(itemName); !found {
+		u.t.Errorf("%s isn't carrying %q", u.record.Username, itemName)
+		return false
+	}
+	return true
+}
+
+func (u *User) AssertNoItem(itemName string) bool {
+	u.t.Helper()
+
+	if _, found := u.record.Character.FindInBackpack(itemName); found {
+		u.t.Errorf("%s is carrying %q", u.record.Username, itemName)
+		return false
+	}
+	return true
+}
+
+func (u *User) AssertWearing(itemName string) bool {
+	u.t.Helper()
+
+	if _, found := u.record.Character.FindOnBody(itemName); !found {
+		u.t.Errorf("%s isn't wearing %q", u.record.Username, itemName)
+		return false
+	}
+	return true
+}
+
+// Checks the user has reached a quest step, given as a token like "4-start" (a later step counts too)
+func (u *User) AssertQuest(questToken string) bool {
+	u.t.Helper()
+
+	if !u.record.Character.HasQuest(questToken) {
+		u.t.Errorf("%s hasn't reached quest step %q, their quest progress is %v", u.record.Username, questToken, u.record.Character.GetQuestProgress())
+		return false
+	}
+	return true
+}
+
+func (u *User) AssertQuestDone(questToken string) bool {
+	u.t.Helper()
+
+	if !u.record.Character.IsQuestDone(questToken) {
+		u.t.Errorf("%s hasn't finished quest %q, their quest progress is %v", u.record.Username, questToken, u.record.Character.GetQuestProgress())
+		return false
+	}
+	return true
+}
+
+func (u *User) AssertOutputContains(text string) bool {
+	u.t.Helper()
+
+	if output := u.Output(); !strings.Contains(output, text) {
+		u.t.Errorf("%s wasn't sent %q, they were sent:\n%s", u.record.Username, text, output)
+		return false
+	}
+	return true
+}
+
+func (u *User) AssertOutputNotContains(text string) bool {
+	u.t.Helper()
+
+	if output := u.Output(); strings.Contains(output, text) {
+		u.t.Errorf("%s was sent %q, they were sent:\n%s", u.record.Username, text, output)
+		return false
+	}
+	return true
+}
+
+// Keeps the text sent to one connection
+type userOutput struct {
+	lock sync.Mutex
+	sb   strings.Builder
+}
+
+// Colors and cursor movement would only get in the way of matching text
+var escapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+func (o *userOutput) Write(p []byte) (int, error) {
+
+	// Telnet commands (sound, GMCP and so on) are always sent on their own, and aren't text
+	if len(p) > 0 && p[0] == term.TELNET_IAC {
+		return len(p), nil
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.sb.WriteString(strings.ReplaceAll(escapeRegex.ReplaceAllString(string(p), ``), "\r", ``))
+
+	return len(p), nil
+}
+
+func (o *userOutput) String() string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.sb.String()
+}
+
+func (o *userOutput) Reset() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.sb.Reset()
+}
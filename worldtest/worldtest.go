@@ -0,0 +1,312 @@
+// Package worldtest runs a world without any sockets or timers, so that world builders can test their content with go test.
+//
+// A test loads the world, adds players and types commands for them, then checks where they ended up,
+// what they're carrying, how far along their quests are and what they were sent:
+//
+//	func TestFindTheKey(t *testing.T) {
+//		w := worldtest.New(t, worldtest.Options{DataFiles: `../world`})
+//		bob := w.NewUser(`bob`, 1)
+//
+//		bob.Command(`south`)
+//		bob.AssertRoom(2)
+//		bob.Command(`get key`)
+//		bob.AssertHasItem(`key`)
+//
+//		w.AdvanceRounds(2)
+//		bob.AssertOutputContains(`The guard notices you`)
+//	}
+//
+// The game is made of package level state, so the world is only loaded once per test binary.
+// Later tests see whatever earlier tests left behind (mobs, items on the floor and so on), apart from
+// the players, who leave when their test finishes. The world is loaded from a copy of the data files,
+// so nothing a test does is saved back to them.
+package worldtest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/bans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/gametime"
+	"github.com/GoMudEngine/GoMud/internal/hooks"
+	"github.com/GoMudEngine/GoMud/internal/journal"
+	"github.com/GoMudEngine/GoMud/internal/language"
+	"github.com/GoMudEngine/GoMud/internal/mapper"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/plugins"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/usercommands"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/world"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
+	_ "github.com/GoMudEngine/GoMud/modules"
+	textLang "golang.org/x/text/language"
+)
+
+type Options struct {
+	Root      string // Folder holding _datafiles/config.yaml. Defaults to the nearest one at or above the working directory.
+	DataFiles string // The world to load. Defaults to FilePaths.DataFiles from the config.
+	Seed      int64  // Seeds util.Rand() at the start of every test. Defaults to 1.
+}
+
+// A loaded world for one test
+type World struct {
+	t     testing.TB
+	users []*User
+}
+
+var (
+	bootOnce sync.Once
+	bootErr  error
+	booted   Options
+
+	worldManager *world.World
+	copyDir      string // Where the data files were copied to
+
+	// Turns are stepped by hand, so the time is made up too
+	turnMs        int
+	turnsPerRound int
+	timeNow       time.Time
+)
+
+// Loads the world (the first time only) and returns a handle for the test.
+// Any players the test added are removed from the world when it finishes.
+func New(t testing.TB, opts Options) *World {
+	t.Helper()
+
+	opts, err := resolveOptions(opts)
+	if err != nil {
+		t.Fatalf("worldtest: %s", err)
+	}
+
+	bootOnce.Do(func() {
+		bootErr = boot(opts)
+		booted = opts
+	})
+
+	if bootErr != nil {
+		t.Fatalf("worldtest: loading the world failed: %s", bootErr)
+	}
+
+	if opts.Root != booted.Root || opts.DataFiles != booted.DataFiles {
+		t.Fatalf("worldtest: the world was already loaded from %s, one test binary can only load one world", booted.DataFiles)
+	}
+
+	util.SeedRand(opts.Seed)
+
+	w := &World{t: t}
+
+	t.Cleanup(w.cleanup)
+
+	return w
+}
+
+// Removes the copy of the data files. Optional, for a TestMain to call after m.Run().
+func Close() error {
+	if copyDir == `` {
+		return nil
+	}
+	return os.RemoveAll(copyDir)
+}
+
+// Advances the game n turns, running all of the events each one brings
+func (w *World) AdvanceTurns(n int) {
+	for i := 0; i < n; i++ {
+
+		timeNow = timeNow.Add(time.Duration(turnMs) * time.Millisecond)
+
+		worldManager.NextTurn(turnsPerRound, timeNow)
+
+		// The live event loop runs every millisecond, so requeued events get as many chances as they would in a real turn
+		for j := 0; j < turnMs; j++ {
+			worldManager.EventLoop()
+		}
+	}
+}
+
+// Advances the game to the start of the round n rounds from now
+func (w *World) AdvanceRounds(n int) {
+	targetRound := util.GetRoundCount() + uint64(n)
+	for util.GetRoundCount() < targetRound {
+		w.AdvanceTurns(1)
+	}
+}
+
+func (w *World) Turn() uint64 {
+	return util.GetTurnCount()
+}
+
+func (w *World) Round() uint64 {
+	return util.GetRoundCount()
+}
+
+func (w *World) cleanup() {
+	for _, u := range w.users {
+		u.leave()
+	}
+}
+
+// Fills in the defaults and makes paths absolute, since boot() changes the working directory
+func resolveOptions(opts Options) (Options, error) {
+
+	var err error
+
+	if opts.Root == `` {
+		if opts.Root, err = findRoot(); err != nil {
+			return opts, err
+		}
+	}
+
+	if opts.Root, err = filepath.Abs(opts.Root); err != nil {
+		return opts, err
+	}
+
+	if opts.DataFiles != `` {
+		if opts.DataFiles, err = filepath.Abs(opts.DataFiles); err != nil {
+			return opts, err
+		}
+	}
+
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+
+	return opts, nil
+}
+
+// Looks for _datafiles/config.yaml at or above the working directory
+func findRoot() (string, error) {
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ``, err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, `_datafiles`, `config.yaml`)); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ``, errors.New("no _datafiles/config.yaml found, set Options.Root")
+		}
+		dir = parent
+	}
+}
+
+// Does what main() does to load the world, minus the network and worker goroutines
+func boot(opts Options) error {
+
+	var err error
+	if copyDir, err = os.MkdirTemp(``, `gomud_worldtest_*`); err != nil {
+		return err
+	}
+
+	// The log goes next to the copy, unless running with -v
+	logLevel := os.Getenv(`LOG_LEVEL`)
+	if logLevel == `` {
+		logLevel = `LOW`
+	}
+	logPath := os.Getenv(`LOG_PATH`)
+	if logPath == `` && !testing.Verbose() {
+		logPath = filepath.Join(copyDir, `worldtest.log`)
+	}
+	mudlog.SetupLogger(nil, logLevel, logPath, false)
+
+	// The config and a few other paths are relative to the root
+	if err := os.Chdir(opts.Root); err != nil {
+		return err
+	}
+
+	if err := configs.ReloadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	dataFiles := opts.DataFiles
+	if dataFiles == `` {
+		dataFiles = configs.GetFilePathsConfig().DataFiles.String()
+	}
+
+	// Only possible when the root is a GoMud checkout
+	if _, err := os.Stat(`_datafiles/world/default`); err == nil {
+		if err := util.ValidateWorldFiles(`_datafiles/world/default`, dataFiles); err != nil {
+			return err
+		}
+	}
+
+	// Users, rooms and the round count get saved as the game runs, so work from a copy
+	dataFilesCopy := filepath.Join(copyDir, `datafiles`)
+	if err := journal.Snapshot(dataFiles, dataFilesCopy); err != nil {
+		return fmt.Errorf("copying %s: %w", dataFiles, err)
+	}
+
+	if err := configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dataFilesCopy}); err != nil {
+		return err
+	}
+
+	c := configs.GetConfig()
+
+	turnMs = int(c.Timing.TurnMs)
+	turnsPerRound = c.Timing.TurnsPerRound()
+	timeNow = time.Now()
+
+	if len(c.Translation.LanguagePaths) == 0 {
+		c.Translation.LanguagePaths = []string{
+			path.Join("_datafiles", "localize"),
+			path.Join(dataFilesCopy, "localize"),
+		}
+	}
+
+	os.Mkdir(filepath.Join(dataFilesCopy, `rooms.instances`), os.ModeDir|0755)
+
+	templates.RegisterFS(plugins.GetPluginRegistry())
+	scripting.RegisterFS(plugins.GetPluginRegistry())
+	usercommands.AddFunctionExporter(plugins.GetPluginRegistry())
+
+	language.InitTranslation(language.BundleCfg{
+		DefaultLanguage: textLang.Make(c.Translation.DefaultLanguage.String()),
+		Language:        textLang.Make(c.Translation.Language.String()),
+		LanguagePaths:   c.Translation.LanguagePaths,
+	})
+
+	hooks.RegisterListeners()
+
+	world.LoadAllDataFiles(false)
+
+	mapper.PreCacheMaps()
+
+	idx := users.NewUserIndex()
+	if err := idx.Create(); err != nil {
+		return err
+	}
+	if err := idx.Rebuild(); err != nil {
+		return err
+	}
+
+	bans.LoadBans()
+	worldstate.LoadState()
+
+	util.LoadRoundCount(dataFilesCopy + `/` + util.RoundCountFilename)
+
+	gametime.GetZodiac(1)
+
+	scripting.Setup(int(c.Scripting.LoadTimeoutMs), int(c.Scripting.RoomTimeoutMs))
+
+	plugins.Load(dataFilesCopy)
+
+	worldManager = world.NewWorld(make(chan os.Signal, 1))
+
+	mudlog.Info("worldtest", "dataFiles", dataFiles, "copy", dataFilesCopy)
+
+	return nil
+}
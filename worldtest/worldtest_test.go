@@ -0,0 +1,292 @@
+package worldtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/usercommands"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/worldstate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	Close()
+	os.Exit(code)
+}
+
+func TestMove(t *testing.T) {
+	w := New(t, Options{})
+
+	alice := w.NewUser(`alice`, 1)
+	alice.AssertRoom(1)
+
+	alice.ClearOutput()
+	alice.Command(`north`)
+
+	alice.AssertRoom(2)
+	alice.AssertOutputContains(`Exits`)
+
+	alice.Command(`xyzzy`)
+	alice.AssertOutputContains(`xyzzy not recognized`)
+}
+
+func TestItems(t *testing.T) {
+	w := New(t, Options{})
+
+	bob := w.NewUser(`bob`, 1)
+	bob.Record().Character.StoreItem(items.New(10001))
+	bob.AssertHasItem(`sharp stick`)
+
+	bob.Command(`drop stick`)
+	bob.AssertNoItem(`sharp stick`)
+
+	bob.Command(`get stick`)
+	bob.AssertHasItem(`sharp stick`)
+
+	bob.Command(`equip stick`)
+	bob.AssertWearing(`sharp stick`)
+}
+
+func TestQuest(t *testing.T) {
+	w := New(t, Options{})
+
+	carol := w.NewUser(`carol`, 26)
+	carol.Record().Character.GiveQuestToken(`2-start`)
+	carol.Command(`press raven`)
+
+	carol.AssertRoom(31)
+	carol.AssertQuest(`2-investigate`)
+	carol.AssertOutputContains(`You press the eyes of the raven`)
+}
+
+func TestAdvance(t *testing.T) {
+	w := New(t, Options{})
+
+	round := w.Round()
+	w.AdvanceRounds(2)
+	assert.Equal(t, round+2, w.Round())
+
+	turn := w.Turn()
+	w.AdvanceTurns(3)
+	assert.Equal(t, turn+3, w.Turn())
+}
+
+func TestOutputIsPerUser(t *testing.T) {
+	w := New(t, Options{})
+
+	dave := w.NewUser(`dave`, 1)
+	erin := w.NewUser(`erin`, 1)
+
+	dave.ClearOutput()
+	erin.ClearOutput()
+
+	dave.Command(`say hello there`)
+
+	dave.AssertOutputContains(`You say, "hello there"`)
+	erin.AssertOutputContains(`dave says, "hello there"`)
+	erin.AssertOutputNotContains(`You say`)
+}
+
+func TestCombatScripts(t *testing.T) {
+	w := New(t, Options{})
+
+	writeScript(t, items.GetItemSpec(10001).GetScriptPath(), `
+function onAttack(user, item, room, attack) {
+    attack.SendToAttacker("Your stick twitches.");
+}`)
+
+	rat := mobs.NewMobById(1, 12)
+	require.NotNil(t, rat)
+	rooms.LoadRoom(12).AddMob(rat.InstanceId)
+
+	writeScript(t, rat.GetScriptPath(), `
+function onDefend(mob, room, eventDetails) {
+    eventDetails.attack.Cancel();
+    eventDetails.attack.SendToAttacker("The rat slips away.");
+}`)
+
+	gina := w.NewUser(`gina`, 12)
+	gina.Record().Character.StoreItem(items.New(10001))
+	gina.Command(`equip stick`)
+
+	gina.Command(fmt.Sprintf(`attack #%d`, rat.InstanceId))
+	w.AdvanceRounds(1)
+
+	gina.AssertOutputContains(`Your stick twitches.`)
+	gina.AssertOutputContains(`The rat slips away.`)
+	assert.Equal(t, rat.Character.HealthMax.Value, rat.Character.Health)
+}
+
+func TestScriptCommands(t *testing.T) {
+	w := New(t, Options{})
+
+	scriptPath := filepath.Join(configs.GetFilePathsConfig().DataFiles.String(), `commands`, `wave.js`)
+	writeScript(t, scriptPath, `
+const command = {
+    name: "wave",
+    aliases: ["wiggle"],
+    help: "Waves at the room."
+};
+function onCommand(rest, user, room) {
+    user.SendText("You wave " + rest + ".");
+}`)
+	usercommands.LoadScriptCommands()
+	t.Cleanup(usercommands.LoadScriptCommands)
+
+	hank := w.NewUser(`hank`, 1)
+
+	hank.Command(`wave hello`)
+	hank.AssertOutputContains(`You wave hello.`)
+
+	hank.Command(`wiggle slowly`)
+	hank.AssertOutputContains(`You wave slowly.`)
+
+	hank.Command(`help wiggle`)
+	hank.AssertOutputContains(`Waves at the room.`)
+
+	// The example command that ships with the world
+	hank.Command(`roll 1d2`)
+	hank.AssertOutputContains(`You roll`)
+
+	// Deleting the script takes its help topic and aliases with it
+	require.NoError(t, os.Remove(scriptPath))
+	usercommands.LoadScriptCommands()
+
+	assert.Equal(t, `wiggle`, keywords.TryCommandAlias(`wiggle`))
+	assert.Equal(t, `wiggle`, keywords.TryHelpAlias(`wiggle`))
+	assert.NotContains(t, keywords.GetAllHelpTopics(), `wave`)
+}
+
+func TestWorldState(t *testing.T) {
+	w := New(t, Options{})
+
+	writeScript(t, rooms.LoadRoom(2).GetScriptPath(), `
+function onWorldStateChange(namespace, key, value, oldValue, room) {
+    if ( namespace == "bridge" ) {
+        room.SendText("The bridge is " + value + ", it was " + oldValue + ".");
+        UtilIncrementWorldState("counts", "bridge");
+    }
+}`)
+
+	ivy := w.NewUser(`ivy`, 2)
+	ivy.Record().Role = users.RoleAdmin
+	ivy.Command(`look`) // Loads the room's script
+
+	ivy.Command(`worldstate set bridge state repaired`)
+	w.AdvanceTurns(1)
+	ivy.AssertOutputContains(`The bridge is repaired, it was null.`)
+
+	ivy.Command(`worldstate set bridge state "burned"`)
+	w.AdvanceTurns(1)
+	ivy.AssertOutputContains(`The bridge is burned, it was repaired.`)
+
+	assert.Equal(t, 2, worldstate.Get(`counts`, `bridge`))
+
+	ivy.Command(`worldstate delete bridge state`)
+	w.AdvanceTurns(1)
+	ivy.AssertOutputContains(`The bridge is null, it was burned.`)
+
+	t.Cleanup(func() {
+		worldstate.Delete(`counts`, `bridge`)
+	})
+}
+
+func TestScriptTimers(t *testing.T) {
+	w := New(t, Options{})
+
+	writeScript(t, rooms.LoadRoom(3).GetScriptPath(), `
+function onCommand_pull(rest, user, room) {
+    room.SetTimeout(function(room) { room.SendText("The lever snaps back."); }, 2);
+    room.SetInterval(drip, 1, true);
+    return true;
+}
+function drip(room) {
+    room.SendText("Water drips.");
+}`)
+	t.Cleanup(func() {
+		rooms.LoadRoom(3).ScriptTimers = nil
+		scripting.PruneTimers(3)
+	})
+
+	kim := w.NewUser(`kim`, 3)
+	kim.Record().Role = users.RoleAdmin
+
+	kim.Command(`pull lever`)
+	w.AdvanceTurns(turnsPerRound)
+	kim.AssertOutputContains(`Water drips.`)
+	kim.AssertOutputNotContains(`The lever snaps back.`)
+
+	w.AdvanceTurns(turnsPerRound)
+	kim.AssertOutputContains(`The lever snaps back.`)
+
+	// Pulling it again replaces the persistent timer instead of adding another
+	kim.Command(`pull lever`)
+	require.Len(t, rooms.LoadRoom(3).ScriptTimers, 1)
+	assert.Equal(t, `drip`, rooms.LoadRoom(3).ScriptTimers[0].Function)
+
+	kim.ClearOutput()
+	kim.Command(`script timers`)
+	kim.AssertOutputContains(`drip`)
+	kim.AssertOutputContains(`room 3`)
+
+	// As if the server restarted: nothing is running, but the room still has its saved timer
+	scripting.PruneTimers(3)
+	rooms.LoadRoom(3).ScriptTimers[0].TimerId = 0
+	kim.ClearOutput()
+	w.AdvanceTurns(turnsPerRound * 2)
+	kim.AssertOutputContains(`Water drips.`)
+
+	kim.Command(fmt.Sprintf(`script timers stop %d`, rooms.LoadRoom(3).ScriptTimers[0].TimerId))
+	assert.Empty(t, rooms.LoadRoom(3).ScriptTimers)
+	kim.ClearOutput()
+	w.AdvanceTurns(turnsPerRound * 2)
+	kim.AssertOutputNotContains(`Water drips.`)
+
+	// Mob timers stop when the mob is gone
+	rat := mobs.NewMobById(1, 3)
+	require.NotNil(t, rat)
+	rooms.LoadRoom(3).AddMob(rat.InstanceId)
+
+	kim.Command(`script mob rat mob.SetTurnTimeout((mob, room) => room.SendText("The rat squeaks."), 1)`)
+	w.AdvanceTurns(1)
+	kim.AssertOutputContains(`The rat squeaks.`)
+
+	kim.ClearOutput()
+	kim.Command(`script mob rat mob.SetTurnInterval((mob, room) => room.SendText("The rat squeaks."), 1)`)
+	rooms.LoadRoom(3).RemoveMob(rat.InstanceId)
+	mobs.DestroyInstance(rat.InstanceId)
+	w.AdvanceTurns(2)
+	kim.AssertOutputNotContains(`The rat squeaks.`)
+	assert.Empty(t, scripting.GetTimers())
+
+	// Item timers get whoever is carrying the item
+	kim.Record().Character.StoreItem(items.New(10001))
+	kim.Command(`script eval user.GetBackpackItems()[0].SetTurnTimeout((actor, item, room) => actor.SendText("Your " + item.NameSimple() + " hums."), 1)`)
+	w.AdvanceTurns(1)
+	kim.AssertOutputContains(`Your stick hums.`)
+
+	kim.Command(`script eval user.SetTimeout(() => 1, 1)`)
+	kim.AssertOutputContains(`only mobs can have timers`)
+}
+
+// Adds a script to the world until the test finishes
+func writeScript(t *testing.T, scriptPath string, script string) {
+	require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0644))
+	scripting.PruneVMs(true)
+
+	t.Cleanup(func() {
+		os.Remove(scriptPath)
+		scripting.PruneVMs(true)
+	})
+}